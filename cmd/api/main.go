@@ -9,16 +9,28 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"score-play/docs"
+	"score-play/internal/adapters/auth"
+	"score-play/internal/adapters/auth/jwt"
 	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/apikey"
 	file2 "score-play/internal/adapters/handlers/http/chi/v1/file"
 	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/adapters/handlers/http/openapi"
+	"score-play/internal/adapters/health"
+	"score-play/internal/adapters/metrics"
+	"score-play/internal/adapters/ratelimit"
 	"score-play/internal/adapters/repository/postgres"
 	"score-play/internal/adapters/storage/minio"
+	"score-play/internal/adapters/tracing"
 	"score-play/internal/config"
 	"score-play/internal/core/port"
+	apikeyservice "score-play/internal/core/service/apikey"
 	"score-play/internal/core/service/cleanup"
 	"score-play/internal/core/service/file"
 	tagservice "score-play/internal/core/service/tag"
+	tusservice "score-play/internal/core/service/tus"
 	"sync"
 	"syscall"
 	"time"
@@ -55,26 +67,118 @@ func main() {
 	}(db)
 	logger.Info("db connection established")
 
+	//tracing
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing, "score-play-api")
+	if err != nil {
+		logger.Error("failed to init tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	//storage
 	minioAdapter, err := minio.NewAdapter(ctx, cfg.Minio, logger)
 	if err != nil {
 		logger.Error("failed to init minio", "error", err)
 		os.Exit(1)
 	}
+	var fileStorage port.FileStorage = minioAdapter
 
 	//repositories
 	tagRepo := postgres.NewSqlTagRepository(db)
+	apiKeyRepo := postgres.NewSqlAPIKeyRepository(db)
 	unitOfWork := postgres.NewUnitOfWork(db)
 
+	var tracingMiddleware func(http.Handler) http.Handler
+	if cfg.Tracing.Exporter != tracing.ExporterNone {
+		fileStorage = tracing.NewFileStorage(fileStorage)
+		tagRepo = tracing.NewTagRepository(tagRepo)
+		apiKeyRepo = tracing.NewAPIKeyRepository(apiKeyRepo)
+		unitOfWork = tracing.NewUnitOfWork(unitOfWork)
+		tracingMiddleware = chi.TracingMiddleware()
+	}
+
 	tagService := tagservice.NewTagService(tagRepo)
-	fileService := file.NewFileService(unitOfWork, minioAdapter, cfg.Upload)
-	cleanupService := cleanup.NewCleanupService(unitOfWork, minioAdapter, logger)
+	fileService := file.NewFileService(unitOfWork, fileStorage, cfg.Upload)
+	apiKeyService := apikeyservice.NewAPIKeyService(apiKeyRepo, cfg.Auth.APIKeyRotationGrace, logger)
+	cleanupService := cleanup.NewCleanupService(unitOfWork, fileStorage, cfg.Upload, logger)
+
+	//metrics
+	var metricsMiddleware func(http.Handler) http.Handler
+	if cfg.Metrics.Enabled {
+		m := metrics.New()
+		m.WatchDB(db)
+		m.WatchSessions(unitOfWork.UploadSessionRepo())
+		fileService = metrics.NewFileService(fileService, m)
+		cleanupService = metrics.NewCleanupService(cleanupService, m)
+		metricsMiddleware = chi.MetricsMiddleware(m)
+		go serveMetrics(ctx, m, cfg.Metrics.Addr, logger)
+	}
+
+	// a single replica cleans up at a time
+	cleanupService = cleanup.NewLeaderCleanupService(cleanupService, postgres.NewJobLock(db), logger)
+
+	tusService := tusservice.NewTusService(fileService, unitOfWork, fileStorage, cfg.Upload.MultipartUploadMaxSize, logger)
 
 	//http
 	tagHandler := tag.NewTagHandlerV1(tagService, logger)
 	fileHandler := file2.NewFileHandlerV1(fileService, logger)
+	apiKeyHandler := apikey.NewAPIKeyHandlerV1(apiKeyService, logger)
+	tusHandler := tus.NewTusHandlerV1(tusService, cfg.Upload.TusMaxConcurrent, logger)
+
+	var authMiddleware func(http.Handler) http.Handler
+	if cfg.Auth.Enabled {
+		var jwtVerifier port.TokenVerifier
+		if cfg.Auth.HMACSecret != "" || cfg.Auth.JWKSFile != "" || cfg.Auth.JWKSURL != "" {
+			verifier, err := jwt.NewVerifier(ctx, cfg.Auth)
+			if err != nil {
+				logger.Error("failed to init auth", "error", err)
+				os.Exit(1)
+			}
+			jwtVerifier = verifier
+		}
+		authMiddleware = chi.AuthMiddleware(auth.NewVerifier(apiKeyService, jwtVerifier), cfg.Auth.RequiredScope, logger)
+	}
+
+	var rateLimitMiddleware func(http.Handler) http.Handler
+	if cfg.Limit.Enabled {
+		rates, err := ratelimit.ParseRules(cfg.Limit.Rules)
+		if err != nil {
+			logger.Error("failed to init rate limiting", "error", err)
+			os.Exit(1)
+		}
+		rateLimitMiddleware = chi.RateLimitMiddleware(ratelimit.NewMemoryStore(), rates, logger)
+	}
+
+	var openAPIMiddleware func(http.Handler) http.Handler
+	if cfg.OpenAPI.ValidateRequests || cfg.OpenAPI.ValidateResponses {
+		spec, err := openapi.Load(docs.OpenAPI)
+		if err != nil {
+			logger.Error("failed to load openapi spec", "error", err)
+			os.Exit(1)
+		}
+		openAPIMiddleware = chi.OpenAPIMiddleware(spec, cfg.OpenAPI.ValidateRequests, cfg.OpenAPI.ValidateResponses, logger)
+	}
 
-	router := chi.NewRouter(logger, tagHandler, fileHandler, cfg.Env.Env)
+	healthChecker := health.NewChecker(cfg.Health.Timeout, cfg.Health.CacheTTL)
+	healthChecker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+		return nil, db.PingContext(ctx)
+	})
+	healthChecker.Add("minio", minioAdapter.CheckBuckets)
+
+	router := chi.NewRouter(chi.RouterConfig{
+		Logger:      logger,
+		Tag:         tagHandler,
+		File:        fileHandler,
+		APIKey:      apiKeyHandler,
+		Tus:         tusHandler,
+		Health:      healthChecker,
+		Env:         cfg.Env.Env,
+		Middlewares: []func(http.Handler) http.Handler{tracingMiddleware, metricsMiddleware, authMiddleware, rateLimitMiddleware, openAPIMiddleware},
+	})
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
 		Handler: router,
@@ -93,11 +197,13 @@ func main() {
 	}()
 
 	// init cleanup task
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		initCleanupTask(ctx, cleanupService, cfg.Upload.CleanupEvery, logger)
-	}()
+	if cfg.Upload.CleanupEnabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			initCleanupTask(ctx, cleanupService, cfg.Upload, logger)
+		}()
+	}
 
 	//wait for context cancel
 	<-ctx.Done()
@@ -146,22 +252,24 @@ func initDB(cfg config.DatabaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
-func initCleanupTask(ctx context.Context, service port.CleanupService, every time.Duration, logger *slog.Logger) {
-	ticker := time.NewTicker(every)
+func serveMetrics(ctx context.Context, m *metrics.Metrics, addr string, logger *slog.Logger) {
+	logger.Info("starting metrics server", "addr", addr)
+	if err := m.ListenAndServe(ctx, addr); err != nil {
+		logger.Error("failed to start metrics server", "error", err)
+	}
+}
+
+func initCleanupTask(ctx context.Context, service port.CleanupService, cfg config.FileUploadConfig, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.CleanupEvery)
 	defer ticker.Stop()
 
-	logger.Info("cleanup task initialized", "interval", every)
+	logger.Info("cleanup task initialized", "interval", cfg.CleanupEvery)
 
 	for {
 		select {
 		case <-ticker.C:
 			logger.Info("cleanup task starting")
-			err := service.CleanupExpiredSessions(ctx, time.Now().Add(every))
-			if err != nil {
-				logger.Error("failed to cleanup expired files", "error", err)
-			} else {
-				logger.Info("cleanup task completed successfully")
-			}
+			runCleanup(ctx, service, cfg, time.Now().Add(cfg.CleanupEvery), logger)
 		case <-ctx.Done():
 			logger.Info("cleanup task stopped")
 			return
@@ -169,3 +277,13 @@ func initCleanupTask(ctx context.Context, service port.CleanupService, every tim
 	}
 
 }
+
+// runCleanup cleans up the sessions expired at sessionsBefore and the uploads stale for CleanupFilesAfter, the service logs the summaries
+func runCleanup(ctx context.Context, service port.CleanupService, cfg config.FileUploadConfig, sessionsBefore time.Time, logger *slog.Logger) {
+	if _, err := service.CleanupExpiredSessions(ctx, sessionsBefore); err != nil {
+		logger.Error("failed to cleanup expired sessions", "error", err)
+	}
+	if _, err := service.CleanupExpiredFiles(ctx, time.Now().Add(-cfg.CleanupFilesAfter)); err != nil {
+		logger.Error("failed to cleanup expired files", "error", err)
+	}
+}
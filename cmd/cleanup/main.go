@@ -0,0 +1,171 @@
+// Command cleanup runs the cleanup of expired uploads outside the api, once with -once or every UPLOAD_CLEANUP_EVERY.
+// Runs are guarded by an advisory lock, so several instances never clean up at the same time.
+//
+// The reconcile subcommand compares the buckets with the database and prints the report as JSON:
+//
+//	cleanup reconcile [-dry-run=false] [-min-age 1h]
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/adapters/storage/minio"
+	"score-play/internal/adapters/tracing"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"score-play/internal/core/service/cleanup"
+	"score-play/internal/core/service/reconcile"
+	"syscall"
+	"time"
+)
+
+func main() {
+	var once bool
+	flag.BoolVar(&once, "once", false, "Run the cleanup once and exit")
+	flag.Parse()
+
+	var reconcileOpts domain.ReconcileOptions
+	reconcileCmd := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	reconcileCmd.BoolVar(&reconcileOpts.DryRun, "dry-run", true, "Only report, without deleting orphan objects, aborting stray uploads or failing files")
+	reconcileCmd.DurationVar(&reconcileOpts.MinAge, "min-age", time.Hour, "Ignore the objects and uploads younger than this, as they may still be in flight")
+	runReconcile := flag.Arg(0) == "reconcile"
+	if runReconcile {
+		_ = reconcileCmd.Parse(flag.Args()[1:])
+	}
+
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	db, err := initDB(cfg.Database)
+	if err != nil {
+		logger.Error("failed to init database", "error", err)
+		os.Exit(1)
+	}
+	defer func(db *sql.DB) {
+		if err := db.Close(); err != nil {
+			logger.Error("failed to close database", "error", err)
+		}
+	}(db)
+
+	//tracing
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing, "score-play-cleanup")
+	if err != nil {
+		logger.Error("failed to init tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	minioAdapter, err := minio.NewAdapter(ctx, cfg.Minio, logger)
+	if err != nil {
+		logger.Error("failed to init minio", "error", err)
+		os.Exit(1)
+	}
+	var fileStorage port.FileStorage = minioAdapter
+	unitOfWork := postgres.NewUnitOfWork(db)
+	if cfg.Tracing.Exporter != tracing.ExporterNone {
+		fileStorage = tracing.NewFileStorage(fileStorage)
+		unitOfWork = tracing.NewUnitOfWork(unitOfWork)
+	}
+
+	if runReconcile {
+		reconcileService := reconcile.NewReconcileService(unitOfWork, fileStorage, minioAdapter.Buckets(), cfg.Upload.CleanupBatchSize, logger)
+		report, err := reconcileService.Reconcile(ctx, reconcileOpts)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			logger.Error("failed to print reconciliation report", "error", encodeErr)
+		}
+		if err != nil {
+			logger.Error("reconciliation failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cleanupService := cleanup.NewCleanupService(unitOfWork, fileStorage, cfg.Upload, logger)
+	cleanupService = cleanup.NewLeaderCleanupService(cleanupService, postgres.NewJobLock(db), logger)
+
+	if once {
+		if err := runCleanup(ctx, cleanupService, cfg.Upload, time.Now()); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	ticker := time.NewTicker(cfg.Upload.CleanupEvery)
+	defer ticker.Stop()
+	logger.Info("cleanup scheduler started", "interval", cfg.Upload.CleanupEvery)
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = runCleanup(ctx, cleanupService, cfg.Upload, time.Now().Add(cfg.Upload.CleanupEvery))
+		case <-ctx.Done():
+			logger.Info("cleanup scheduler stopped")
+			return
+		}
+	}
+}
+
+// runCleanup cleans up the sessions expired at sessionsBefore and the uploads stale for CleanupFilesAfter.
+// The service logs the summaries, the errors of both jobs are returned.
+func runCleanup(ctx context.Context, service port.CleanupService, cfg config.FileUploadConfig, sessionsBefore time.Time) error {
+	_, sessionsErr := service.CleanupExpiredSessions(ctx, sessionsBefore)
+	_, filesErr := service.CleanupExpiredFiles(ctx, time.Now().Add(-cfg.CleanupFilesAfter))
+	return errors.Join(sessionsErr, filesErr)
+}
+
+func initDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.SSLMode,
+	)
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenCons)
+	db.SetMaxIdleConns(cfg.MaxIdleCons)
+	db.SetConnMaxLifetime(cfg.ConMaxLifeTime)
+
+	return db, nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"score-play/pkg/client"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// uploadResult is the output of the upload commands
+type uploadResult struct {
+	FileID    uuid.UUID `json:"file_id"`
+	Filename  string    `json:"filename"`
+	SizeBytes int64     `json:"size_bytes"`
+}
+
+func fileUpload(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	flags := flag.NewFlagSet("file upload", flag.ContinueOnError)
+	multipart := flags.Bool("multipart", false, "Upload in parts sent in parallel, for large files")
+	concurrency := flags.Int("concurrency", client.DefaultConcurrency, "Number of parts uploaded at once")
+	statePath := flags.String("state", "", "File the multipart progress is saved to and resumed from (default <path>"+stateSuffix+")")
+	if err := flags.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if flags.NArg() < 2 {
+		return usageError("usage: file upload [-multipart] [-concurrency n] [-state path] <path> <tag>...")
+	}
+
+	src, err := client.OpenFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	tags := flags.Args()[1:]
+
+	if !*multipart {
+		fileID, err := c.Upload(ctx, src, tags)
+		if err != nil {
+			return err
+		}
+		return printUpload(out, fileID, src)
+	}
+
+	opts := multipartOptions(out, *concurrency, statePathOf(*statePath, flags.Arg(0)))
+	fileID, session, err := c.UploadMultipart(ctx, src, tags, opts)
+	if err != nil {
+		reportInterrupted(out, err, session != nil, opts.StatePath)
+		return err
+	}
+	return printUpload(out, fileID, src)
+}
+
+func fileResume(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	flags := flag.NewFlagSet("file resume", flag.ContinueOnError)
+	sessionID := flags.String("session", "", "Id of the multipart upload session, read from the state file if not set")
+	partSize := flags.Int("part-size", 0, "Part size of the session, as returned when it was opened")
+	concurrency := flags.Int("concurrency", client.DefaultConcurrency, "Number of parts uploaded at once")
+	statePath := flags.String("state", "", "File the multipart progress is saved to and resumed from (default <path>"+stateSuffix+")")
+	if err := flags.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if flags.NArg() != 1 {
+		return usageError("usage: file resume [-session <id> -part-size <n>] [-concurrency n] [-state path] <path>")
+	}
+	opts := multipartOptions(out, *concurrency, statePathOf(*statePath, flags.Arg(0)))
+
+	session := client.MultipartSession{PartSize: *partSize}
+	if *sessionID != "" {
+		id, err := uuid.Parse(*sessionID)
+		if err != nil || *partSize <= 0 {
+			return usageError("-session must be a session id and -part-size a positive size")
+		}
+		session.SessionID = id
+	} else {
+		state, err := client.LoadUploadState(opts.StatePath)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return usageError("no upload state at " + opts.StatePath + ", pass -session and -part-size")
+		}
+		session = client.MultipartSession{SessionID: state.SessionID, PartSize: state.PartSize}
+	}
+
+	src, err := client.OpenFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	fileID, err := c.ResumeMultipartUpload(ctx, session, src, opts)
+	if err != nil {
+		reportInterrupted(out, err, true, opts.StatePath)
+		return err
+	}
+	return printUpload(out, fileID, src)
+}
+
+// stateSuffix is appended to the path of an uploaded file to name its state file
+const stateSuffix = ".upload.json"
+
+// statePathOf returns statePath, or the default state file of the file at path
+func statePathOf(statePath string, path string) string {
+	if statePath != "" {
+		return statePath
+	}
+	return path + stateSuffix
+}
+
+// reportInterrupted tells how to go on after a multipart upload failed
+func reportInterrupted(out *printer, err error, started bool, statePath string) {
+	switch {
+	case errors.Is(err, client.ErrSessionExpired):
+		out.progress("the upload session expired, run the command again to start over")
+	case errors.Is(err, client.ErrStateMismatch):
+		out.progress("remove %s or pass another -state to upload this file", statePath)
+	case started:
+		out.progress("progress saved to %s, run the command again to resume", statePath)
+	}
+}
+
+// multipartOptions saves the progress to statePath and reports each part on stderr
+func multipartOptions(out *printer, concurrency int, statePath string) client.MultipartOptions {
+	var done atomic.Int64
+	return client.MultipartOptions{
+		Concurrency: concurrency,
+		StatePath:   statePath,
+		OnPart: func(part client.CompletedPart, skipped bool) {
+			status := "uploaded"
+			if skipped {
+				status = "already uploaded"
+			}
+			out.progress("part %d %s (%d done)", part.PartNumber, status, done.Add(1))
+		},
+	}
+}
+
+func printUpload(out *printer, fileID uuid.UUID, src *client.Source) error {
+	result := uploadResult{FileID: fileID, Filename: src.Filename, SizeBytes: src.Size}
+	return out.print(result, []string{"FILE ID", "FILENAME", "SIZE"}, [][]string{{fileID.String(), src.Filename, strconv.FormatInt(src.Size, 10)}})
+}
+
+func fileParts(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	if len(args) != 1 {
+		return usageError("usage: file parts <session-id>")
+	}
+	sessionID, err := uuid.Parse(args[0])
+	if err != nil {
+		return usageError("invalid session id: " + err.Error())
+	}
+
+	parts, err := c.ListParts(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(parts))
+	for _, part := range parts {
+		rows = append(rows, []string{strconv.Itoa(part.PartNumber), part.ETag})
+	}
+	return out.print(parts, []string{"PART", "ETAG"}, rows)
+}
+
+func fileGet(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	fileID, err := fileIDArg(args)
+	if err != nil {
+		return err
+	}
+
+	info, err := c.GetFile(ctx, fileID)
+	if errors.Is(err, client.ErrFileRestoring) {
+		return fmt.Errorf("%w, retry later", err)
+	}
+	if err != nil {
+		return err
+	}
+	return out.print(info, []string{"FILENAME", "TAGS", "CHECKSUM", "EXPIRES AT", "URL"}, [][]string{{
+		info.Filename, strings.Join(info.Tags, ", "), info.ChecksumSHA256, info.ExpiresAt.Format(time.RFC3339), info.URL,
+	}})
+}
+
+func fileDownload(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	flags := flag.NewFlagSet("file download", flag.ContinueOnError)
+	output := flags.String("o", "", "Path to write the file to, the original filename in the working directory by default")
+	if err := flags.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	fileID, err := fileIDArg(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	path := *output
+	if path == "" {
+		info, err := c.GetFile(ctx, fileID)
+		if err != nil {
+			return err
+		}
+		path = filepath.Base(info.Filename)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := c.Download(ctx, fileID, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	result := map[string]string{"path": path, "filename": info.Filename, "checksum_sha256": info.ChecksumSHA256}
+	return out.print(result, []string{"PATH", "CHECKSUM"}, [][]string{{path, info.ChecksumSHA256}})
+}
+
+func fileIDArg(args []string) (uuid.UUID, error) {
+	if len(args) != 1 {
+		return uuid.Nil, usageError("expected a single file id")
+	}
+	fileID, err := uuid.Parse(args[0])
+	if err != nil {
+		return uuid.Nil, usageError("invalid file id: " + err.Error())
+	}
+	return fileID, nil
+}
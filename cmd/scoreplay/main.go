@@ -0,0 +1,102 @@
+// Command scoreplay is a command line client of the score-play api, built on pkg/client.
+//
+//	scoreplay [global flags] tag create <name>...
+//	scoreplay [global flags] tag list [-limit n] [-marker m] [-all]
+//	scoreplay [global flags] file upload [-multipart] [-concurrency n] [-state path] <path> <tag>...
+//	scoreplay [global flags] file resume [-session <id> -part-size <n>] [-concurrency n] [-state path] <path>
+//	scoreplay [global flags] file parts <session-id>
+//	scoreplay [global flags] file get <file-id>
+//	scoreplay [global flags] file download [-o path] <file-id>
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"score-play/pkg/client"
+	"syscall"
+)
+
+// command runs a subcommand with its arguments
+type command func(ctx context.Context, c *client.Client, out *printer, args []string) error
+
+func main() {
+	flag.Usage = usage
+	baseURL := flag.String("url", envOr("API_URL", client.DefaultBaseURL), "Base url of the api (API_URL)")
+	apiKey := flag.String("api-key", os.Getenv("SCOREPLAY_API_KEY"), "Api key sent as a bearer token (SCOREPLAY_API_KEY)")
+	tenant := flag.String("tenant", os.Getenv("SCOREPLAY_TENANT"), "Tenant of the requests, for api keys not bound to one (SCOREPLAY_TENANT)")
+	storageHost := flag.String("storage-host", os.Getenv("S3_HOST"), "Host to send presigned requests to, e.g. localhost:9000 when the api signs for minio:9000 (S3_HOST)")
+	output := flag.String("output", formatTable, "Output format, table or json")
+	deduplicate := flag.Bool("deduplicate", false, "Skip the uploads of content the api already stores, the files then share it")
+	flag.Parse()
+
+	if *output != formatTable && *output != formatJSON {
+		fmt.Fprintf(os.Stderr, "unknown output format %q\n", *output)
+		os.Exit(2)
+	}
+
+	commands := map[string]map[string]command{
+		"tag": {
+			"create": tagCreate,
+			"list":   tagList,
+		},
+		"file": {
+			"upload":   fileUpload,
+			"resume":   fileResume,
+			"parts":    fileParts,
+			"get":      fileGet,
+			"download": fileDownload,
+		},
+	}
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	run, ok := commands[args[0]][args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := []client.Option{client.WithAPIKey(*apiKey), client.WithTenant(*tenant), client.WithStorageHost(*storageHost)}
+	if *deduplicate {
+		opts = append(opts, client.WithDeduplication())
+	}
+	err := run(ctx, client.New(*baseURL, opts...), &printer{format: *output, w: os.Stdout}, args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+// usageError is returned when a subcommand is called with wrong arguments
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: scoreplay [global flags] <tag|file> <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:\n  tag create, tag list\n  file upload, file resume, file parts, file get, file download")
+	fmt.Fprintln(os.Stderr, "\nglobal flags:")
+	flag.PrintDefaults()
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"score-play/pkg/client"
+	"strings"
+	"time"
+)
+
+func tagCreate(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	if len(args) == 0 {
+		return usageError("usage: tag create <name>...")
+	}
+	if err := c.CreateTags(ctx, args...); err != nil {
+		return err
+	}
+	return out.print(map[string][]string{"created": args}, []string{"CREATED"}, [][]string{{strings.Join(args, ", ")}})
+}
+
+func tagList(ctx context.Context, c *client.Client, out *printer, args []string) error {
+	flags := flag.NewFlagSet("tag list", flag.ContinueOnError)
+	limit := flags.Int("limit", 10, "Number of tags per page")
+	marker := flags.String("marker", "", "Marker of the page to list, from a previous page")
+	all := flags.Bool("all", false, "Follow the markers and list every page")
+	if err := flags.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+
+	var tags []client.Tag
+	next := *marker
+	for {
+		page, err := c.ListTags(ctx, *limit, next)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, page.Tags...)
+		next = page.NextMarker
+		if !*all || next == "" {
+			break
+		}
+	}
+
+	rows := make([][]string, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, []string{tag.ID.String(), tag.Name, tag.CreatedAt.Format(time.RFC3339)})
+	}
+	if next != "" {
+		rows = append(rows, []string{"", "next marker: " + next, ""})
+	}
+	return out.print(client.TagPage{Tags: tags, NextMarker: next}, []string{"ID", "NAME", "CREATED AT"}, rows)
+}
@@ -6,13 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"score-play/internal/adapters/eventbroker/nats"
+	"score-play/internal/adapters/health"
+	"score-play/internal/adapters/metrics"
+	"score-play/internal/adapters/remote"
 	"score-play/internal/adapters/repository/postgres"
 	"score-play/internal/adapters/storage/minio"
+	"score-play/internal/adapters/tracing"
 	"score-play/internal/config"
+	"score-play/internal/core/port"
 	"score-play/internal/core/service/file"
+	"score-play/internal/core/service/fileimport"
 	"score-play/internal/core/service/minioevent"
 	"syscall"
 	"time"
@@ -47,19 +54,56 @@ func main() {
 	}()
 	logger.Info("db connection established")
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing, "score-play-worker")
+	if err != nil {
+		logger.Error("failed to init tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	minioAdapter, err := minio.NewAdapter(ctx, cfg.Minio, logger)
 	if err != nil {
 		logger.Error("failed to init minio", "error", err)
 		os.Exit(1)
 	}
 	logger.Info("minio adapter initialized")
+	var fileStorage port.FileStorage = minioAdapter
 
 	// Initialize repositories
 	unitOfWork := postgres.NewUnitOfWork(db)
 
+	traced := cfg.Tracing.Exporter != tracing.ExporterNone
+	if traced {
+		fileStorage = tracing.NewFileStorage(fileStorage)
+		unitOfWork = tracing.NewUnitOfWork(unitOfWork)
+	}
+
 	// Initialize services
-	fileService := file.NewFileService(unitOfWork, minioAdapter, cfg.Upload)
-	minioMessageService := minioevent.NewMinioEventService(minioAdapter, unitOfWork, fileService, logger)
+	fileService := file.NewFileService(unitOfWork, fileStorage, cfg.Upload)
+	var m *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		m = metrics.New()
+		m.WatchDB(db)
+		fileService = metrics.NewFileService(fileService, m)
+	}
+	minioMessageService := minioevent.NewMinioEventService(fileStorage, unitOfWork, fileService, logger)
+	if traced {
+		minioMessageService = tracing.NewMessageService(minioMessageService)
+	}
+	if m != nil {
+		minioMessageService = metrics.NewMessageService(minioMessageService, m)
+		go func() {
+			logger.Info("starting metrics server", "addr", cfg.Metrics.Addr)
+			if err := m.ListenAndServe(ctx, cfg.Metrics.Addr); err != nil {
+				logger.Error("failed to start metrics server", "error", err)
+			}
+		}()
+	}
 
 	// Initialize NATS consumer
 	natsConsumer, err := nats.NewNATSConsumer(cfg.NATS, logger)
@@ -81,6 +125,25 @@ func main() {
 	}
 	logger.Info("NATS subscription active")
 
+	// Import the files requested from remote urls
+	importService := fileimport.NewImportService(unitOfWork, fileStorage, fileService, remote.NewHTTPSource(cfg.Upload.Import), cfg.Upload, logger)
+	go initImportTask(ctx, importService, cfg.Upload.Import, logger)
+
+	// Serve health probes
+	healthChecker := health.NewChecker(cfg.Health.Timeout, cfg.Health.CacheTTL)
+	healthChecker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+		return nil, db.PingContext(ctx)
+	})
+	healthChecker.Add("minio", minioAdapter.CheckBuckets)
+	healthChecker.Add("nats", natsConsumer.Check)
+	healthServer := &http.Server{Addr: cfg.Health.Addr, Handler: healthChecker.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		logger.Info("starting health server", "addr", cfg.Health.Addr)
+		if err := healthServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("failed to start health server", "error", err)
+		}
+	}()
+
 	// Wait for termination signal
 	<-ctx.Done()
 	logger.Info("gracefully shutting down video processing service")
@@ -89,6 +152,10 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
+	if err := healthServer.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to shutdown health server", "error", err)
+	}
+
 	// Close NATS consumer
 	if err := natsConsumer.Close(); err != nil {
 		logger.Error("failed to close NATS consumer during shutdown", "error", err)
@@ -103,6 +170,32 @@ func main() {
 	logger.Info("video processing service shutdown complete")
 }
 
+// initImportTask imports the pending files every PollEvery, until there is none left
+func initImportTask(ctx context.Context, service port.ImportService, cfg config.ImportConfig, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.PollEvery)
+	defer ticker.Stop()
+
+	logger.Info("import task initialized", "interval", cfg.PollEvery)
+
+	for {
+		select {
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				imported, err := service.ImportNext(ctx)
+				if err != nil {
+					logger.Error("failed to import file", "error", err)
+				}
+				if !imported || err != nil {
+					break
+				}
+			}
+		case <-ctx.Done():
+			logger.Info("import task stopped")
+			return
+		}
+	}
+}
+
 func initDB(cfg config.DatabaseConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
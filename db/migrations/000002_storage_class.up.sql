@@ -0,0 +1,2 @@
+alter table file_metadata
+    add column storage_class varchar(64) not null default 'STANDARD';
@@ -0,0 +1,6 @@
+-- empty bucket means the default bucket, for files created before routing existed
+alter table file_metadata
+    add column bucket varchar(63) not null default '';
+
+alter table file_metadata drop constraint file_metadata_storage_key_key;
+create unique index file_metadata_bucket_storage_key_uk on file_metadata (bucket, storage_key);
@@ -0,0 +1,28 @@
+alter table file_metadata add column current_version int not null default 1;
+
+create table file_version (
+                              id uuid primary key default gen_random_uuid(),
+                              file_id uuid not null references file_metadata(id) on delete cascade,
+                              version int not null check (version > 0),
+                              filename varchar(255) not null,
+                              mime_type varchar(127) not null,
+                              size_bytes bigint not null check (size_bytes >= 0),
+                              bucket varchar(63) not null default '',
+                              storage_key varchar(512) not null,
+                              checksum varchar(128),
+                              status varchar(30) not null check (status in ('uploading','completed','failed')),
+                              created_at timestamptz not null default now(),
+                              updated_at timestamptz not null default now()
+);
+create unique index file_version_file_version_uk on file_version (file_id, version);
+
+-- existing files become their own first version
+insert into file_version (id, file_id, version, filename, mime_type, size_bytes, bucket, storage_key, checksum, status, created_at)
+select id, id, 1, filename, mime_type, size_bytes, bucket, storage_key, checksum, status, created_at
+from file_metadata
+where status = 'completed' and deleted_at is null;
+
+alter table upload_session add column version_id uuid references file_version(id) on delete cascade;
+
+CREATE TRIGGER update_file_version_updated_at BEFORE UPDATE ON file_version
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
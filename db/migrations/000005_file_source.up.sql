@@ -0,0 +1,3 @@
+-- lineage of server-side copies
+alter table file_metadata
+    add column source_file_id uuid references file_metadata(id) on delete set null;
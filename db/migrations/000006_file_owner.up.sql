@@ -0,0 +1,5 @@
+-- subject of the principal that created the file, null for files created without authentication
+alter table file_metadata
+    add column owner_id text;
+
+create index idx_file_metadata_owner_id on file_metadata(owner_id) where deleted_at is null;
@@ -0,0 +1,14 @@
+-- every tag, file and upload session belongs to a tenant, existing rows go to the default tenant
+alter table tags
+    add column tenant_id text not null default 'default';
+
+drop index tags_name_uk;
+create unique index tags_tenant_name_uk on tags (tenant_id, name);
+
+alter table file_metadata
+    add column tenant_id text not null default 'default';
+
+create index idx_file_metadata_tenant on file_metadata(tenant_id) where deleted_at is null;
+
+alter table upload_session
+    add column tenant_id text not null default 'default';
@@ -0,0 +1,17 @@
+-- long lived credentials of machine clients, only the sha256 of the secret is stored
+create table api_key (
+    id uuid primary key default gen_random_uuid(),
+    tenant_id text not null default 'default',
+    name varchar(100) not null,
+    prefix varchar(16) not null,
+    hash varchar(64) not null,
+    scopes text[] not null,
+    created_by text,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+create unique index api_key_hash_uk on api_key(hash);
+create index idx_api_key_tenant on api_key(tenant_id, created_at);
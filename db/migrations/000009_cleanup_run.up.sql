@@ -0,0 +1,14 @@
+-- summary of each cleanup run, for auditing
+create table cleanup_run (
+    id uuid primary key,
+    job varchar(50) not null,
+    started_at timestamptz not null,
+    finished_at timestamptz not null,
+    found integer not null,
+    cleaned integer not null,
+    failed integer not null,
+    budget_exceeded boolean not null default false,
+    error text
+);
+
+create index idx_cleanup_run_job on cleanup_run(job, started_at);
@@ -0,0 +1,2 @@
+-- bytes received by resumable (tus) uploads, committed once stored
+alter table upload_session add column upload_offset bigint not null default 0;
@@ -0,0 +1,17 @@
+-- files the worker fetches from remote urls, kept after a failed file is deleted to report why
+create table file_import (
+    file_id uuid primary key,
+    session_id uuid not null,
+    tenant_id text not null default 'default',
+    owner_id text,
+    source_url text not null,
+    status varchar(20) not null,
+    size_bytes bigint not null,
+    bytes_received bigint not null default 0,
+    attempts integer not null default 0,
+    error text,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create index idx_file_import_status on file_import(status, updated_at);
@@ -0,0 +1,9 @@
+-- uploads look for a completed file with the same content to share its object
+create index idx_file_metadata_content on file_metadata(tenant_id, checksum, size_bytes)
+    where status = 'completed' and deleted_at is null;
+
+-- files sharing an object have the same bucket and storage key, the unique index of 000003 is replaced by a plain one
+-- an object is only deleted once no other file or version references it
+drop index file_metadata_bucket_storage_key_uk;
+create index idx_file_metadata_bucket_storage_key on file_metadata(bucket, storage_key);
+create index idx_file_version_storage_key on file_version(storage_key);
@@ -0,0 +1,4 @@
+-- the subject of the principals of a key, rotated keys inherit it so they keep owning the files of the key they replace
+alter table api_key add column subject_id uuid;
+update api_key set subject_id = id;
+alter table api_key alter column subject_id set not null;
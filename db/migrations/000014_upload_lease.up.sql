@@ -0,0 +1,3 @@
+-- request writing to a resumable (tus) upload, until its lease expires
+alter table upload_session add column lease_holder uuid;
+alter table upload_session add column lease_until timestamptz;
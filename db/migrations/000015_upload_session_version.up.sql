@@ -0,0 +1,4 @@
+-- a file has one open upload of its content and one per version, versions of a file are uploaded concurrently
+drop index uniq_open_session;
+create unique index uniq_open_session on upload_session(file_id) where status = 'open' and version_id is null;
+create unique index uniq_open_version_session on upload_session(version_id) where status = 'open' and version_id is not null;
@@ -0,0 +1,2 @@
+-- SHA-256 state of the bytes received by resumable (tus) uploads, to checksum files sent without one
+alter table upload_session add column checksum_state bytea;
@@ -0,0 +1,9 @@
+// Package docs embeds the api documentation so it ships with the binary.
+package docs
+
+import _ "embed"
+
+// OpenAPI is the openapi spec of the api, served at /api/v1/openapi.yaml and used to validate requests
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
@@ -0,0 +1,220 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"score-play/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often an unknown kid can trigger a jwks download
+const minRefreshInterval = time.Minute
+
+// jwk is a json web key, only the members needed for RSA and EC public keys are decoded
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+type publicKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+// keySet caches the public keys of a jwks document loaded from a file or an url
+type keySet struct {
+	load         func(ctx context.Context) ([]byte, error)
+	refreshEvery time.Duration
+
+	mu        sync.RWMutex
+	keys      []publicKey
+	fetchedAt time.Time
+}
+
+func newFileKeySet(path string) *keySet {
+	return &keySet{
+		load: func(ctx context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+	}
+}
+
+func newURLKeySet(url string, refresh time.Duration, client *http.Client) *keySet {
+	return &keySet{
+		refreshEvery: refresh,
+		load: func(ctx context.Context) ([]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected jwks status %d", resp.StatusCode)
+			}
+			return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		},
+	}
+}
+
+// find returns the key matching kid and alg, downloading the jwks again when it is stale or the kid is unknown
+func (k *keySet) find(ctx context.Context, kid string, alg string) (crypto.PublicKey, error) {
+	k.mu.RLock()
+	key, found := k.match(kid, alg)
+	stale := k.refreshEvery > 0 && time.Since(k.fetchedAt) > k.refreshEvery
+	canRefresh := k.refreshEvery > 0 && time.Since(k.fetchedAt) > minRefreshInterval
+	k.mu.RUnlock()
+
+	if stale || (!found && canRefresh) {
+		if err := k.refresh(ctx); err != nil {
+			if found {
+				return key, nil
+			}
+			return nil, err
+		}
+		k.mu.RLock()
+		key, found = k.match(kid, alg)
+		k.mu.RUnlock()
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: unknown signing key", domain.ErrUnauthenticated)
+	}
+	return key, nil
+}
+
+// match must be called with the lock held. Without kid, the key is only picked if it is the only one for alg.
+func (k *keySet) match(kid string, alg string) (crypto.PublicKey, bool) {
+	var candidate crypto.PublicKey
+	count := 0
+	for _, key := range k.keys {
+		if key.alg != "" && key.alg != alg {
+			continue
+		}
+		if kid != "" {
+			if key.kid == kid {
+				return key.key, true
+			}
+			continue
+		}
+		candidate = key.key
+		count++
+	}
+	return candidate, count == 1
+}
+
+func (k *keySet) refresh(ctx context.Context) error {
+	data, err := k.load(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading jwks: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.fetchedAt = time.Now()
+	return nil
+}
+
+func parseJWKS(data []byte) ([]publicKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error decoding jwks: %w", err)
+	}
+
+	keys := make([]publicKey, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		pub, alg, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding jwk %q: %w", key.Kid, err)
+		}
+		if pub == nil || (key.Alg != "" && key.Alg != alg) {
+			continue
+		}
+		keys = append(keys, publicKey{kid: key.Kid, alg: alg, key: pub})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing key")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the key. Unsupported key types are skipped with a nil key.
+func (j jwk) publicKey() (crypto.PublicKey, string, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, "", err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, "", err
+		}
+		if !e.IsInt64() {
+			return nil, "", errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, AlgRS256, nil
+	case "EC":
+		if j.Crv != "P-256" {
+			return nil, "", nil
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, "", err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, "", err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, "", errors.New("point is not on curve P-256")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, AlgES256, nil
+	default:
+		return nil, "", nil
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
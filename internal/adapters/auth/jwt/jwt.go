@@ -0,0 +1,218 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+)
+
+// Verifier verifies signed JWTs and implements port.TokenVerifier
+type Verifier struct {
+	config config.AuthConfig
+	secret []byte
+	keys   *keySet
+}
+
+// NewVerifier creates a Verifier. At least one of the hmac secret, jwks file or jwks url must be configured.
+func NewVerifier(ctx context.Context, cfg config.AuthConfig) (*Verifier, error) {
+	v := &Verifier{
+		config: cfg,
+	}
+	if cfg.HMACSecret != "" {
+		v.secret = []byte(cfg.HMACSecret)
+	}
+
+	switch {
+	case cfg.JWKSFile != "" && cfg.JWKSURL != "":
+		return nil, errors.New("only one of jwks file and jwks url can be configured")
+	case cfg.JWKSFile != "":
+		v.keys = newFileKeySet(cfg.JWKSFile)
+	case cfg.JWKSURL != "":
+		v.keys = newURLKeySet(cfg.JWKSURL, cfg.JWKSRefresh, &http.Client{Timeout: 10 * time.Second})
+	case v.secret == nil:
+		return nil, errors.New("auth enabled without hmac secret or jwks")
+	}
+
+	if v.keys != nil {
+		if err := v.keys.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	Scope     string   `json:"scope"`
+	Scp       []string `json:"scp"`
+	Roles     []string `json:"roles"`
+	Tenant    string   `json:"tenant_id"`
+}
+
+// audience is the aud claim, which is either a string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Verify checks the signature and the registered claims of a compact JWT and returns its principal
+func (v *Verifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", domain.ErrUnauthenticated)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: invalid header", domain.ErrUnauthenticated)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature encoding", domain.ErrUnauthenticated)
+	}
+	if err := v.verifySignature(ctx, h, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("%w: invalid claims", domain.ErrUnauthenticated)
+	}
+	if err := v.validateClaims(c); err != nil {
+		return nil, err
+	}
+
+	scopes := c.Scp
+	if c.Scope != "" {
+		scopes = strings.Fields(c.Scope)
+	}
+
+	return &domain.Principal{
+		Subject: c.Subject,
+		Issuer:  c.Issuer,
+		Scopes:  scopes,
+		Roles:   c.Roles,
+		Tenant:  c.Tenant,
+	}, nil
+}
+
+func (v *Verifier) verifySignature(ctx context.Context, h header, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch h.Alg {
+	case AlgHS256:
+		if v.secret == nil {
+			return fmt.Errorf("%w: unsupported algorithm %s", domain.ErrUnauthenticated, h.Alg)
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("%w: invalid signature", domain.ErrUnauthenticated)
+		}
+		return nil
+	case AlgRS256, AlgES256:
+		if v.keys == nil {
+			return fmt.Errorf("%w: unsupported algorithm %s", domain.ErrUnauthenticated, h.Alg)
+		}
+		key, err := v.keys.find(ctx, h.Kid, h.Alg)
+		if err != nil {
+			return err
+		}
+		switch pub := key.(type) {
+		case *rsa.PublicKey:
+			if h.Alg != AlgRS256 || rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) != nil {
+				return fmt.Errorf("%w: invalid signature", domain.ErrUnauthenticated)
+			}
+		case *ecdsa.PublicKey:
+			if h.Alg != AlgES256 || len(signature) != 64 {
+				return fmt.Errorf("%w: invalid signature", domain.ErrUnauthenticated)
+			}
+			r := new(big.Int).SetBytes(signature[:32])
+			s := new(big.Int).SetBytes(signature[32:])
+			if !ecdsa.Verify(pub, digest[:], r, s) {
+				return fmt.Errorf("%w: invalid signature", domain.ErrUnauthenticated)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported key type", domain.ErrUnauthenticated)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", domain.ErrUnauthenticated, h.Alg)
+	}
+}
+
+func (v *Verifier) validateClaims(c claims) error {
+	now := time.Now()
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("%w: missing exp claim", domain.ErrUnauthenticated)
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(v.config.Leeway)) {
+		return fmt.Errorf("%w: token expired", domain.ErrUnauthenticated)
+	}
+	if c.NotBefore != nil && now.Add(v.config.Leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return fmt.Errorf("%w: token not valid yet", domain.ErrUnauthenticated)
+	}
+	if c.Subject == "" {
+		return fmt.Errorf("%w: missing sub claim", domain.ErrUnauthenticated)
+	}
+	// the issuer and subjects of api keys are reserved, a token claiming them would own the files of a key
+	if c.Issuer == domain.APIKeyIssuer || strings.HasPrefix(c.Subject, domain.APIKeyIssuer+":") {
+		return fmt.Errorf("%w: reserved api key issuer", domain.ErrUnauthenticated)
+	}
+	if v.config.Issuer != "" && c.Issuer != v.config.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", domain.ErrUnauthenticated)
+	}
+	if v.config.Audience != "" && !slices.Contains(c.Audience, v.config.Audience) {
+		return fmt.Errorf("%w: unexpected audience", domain.ErrUnauthenticated)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
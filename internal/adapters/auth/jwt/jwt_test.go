@@ -0,0 +1,305 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"score-play/internal/adapters/auth/jwt"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret   = "test-secret"
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "score-play"
+)
+
+func encode(t *testing.T, v any) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"iss":   testIssuer,
+		"sub":   "user-1",
+		"aud":   []string{testAudience},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "files:read files:write",
+	}
+}
+
+func signHS256(t *testing.T, secret string, claims map[string]any) string {
+	input := encode(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encode(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	input := encode(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encode(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	input := encode(t, map[string]string{"alg": "ES256", "kid": kid}) + "." + encode(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func jwksDocument(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	b64 := base64.RawURLEncoding.EncodeToString
+	doc := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-1",
+				"use": "sig",
+				"n":   b64(rsaKey.N.Bytes()),
+				"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-1",
+				"crv": "P-256",
+				"x":   b64(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   b64(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	}
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return data
+}
+
+func generateKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return rsaKey, ecKey
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	ctx := context.Background()
+	verifier, err := jwt.NewVerifier(ctx, config.AuthConfig{
+		HMACSecret: testSecret,
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+	})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		// Act
+		principal, err := verifier.Verify(ctx, signHS256(t, testSecret, validClaims()))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+		assert.Equal(t, testIssuer, principal.Issuer)
+		assert.Equal(t, []string{"files:read", "files:write"}, principal.Scopes)
+	})
+
+	t.Run("success - tenant claim", func(t *testing.T) {
+		// Arrange
+		claims := validClaims()
+		claims["tenant_id"] = "acme"
+
+		// Act
+		principal, err := verifier.Verify(ctx, signHS256(t, testSecret, claims))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "acme", principal.Tenant)
+	})
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"wrong secret", func() string { return signHS256(t, "other", validClaims()) }},
+		{"expired", func() string {
+			claims := validClaims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return signHS256(t, testSecret, claims)
+		}},
+		{"missing exp", func() string {
+			claims := validClaims()
+			delete(claims, "exp")
+			return signHS256(t, testSecret, claims)
+		}},
+		{"not valid yet", func() string {
+			claims := validClaims()
+			claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			return signHS256(t, testSecret, claims)
+		}},
+		{"wrong issuer", func() string {
+			claims := validClaims()
+			claims["iss"] = "https://evil.example.com"
+			return signHS256(t, testSecret, claims)
+		}},
+		{"wrong audience", func() string {
+			claims := validClaims()
+			claims["aud"] = "other-api"
+			return signHS256(t, testSecret, claims)
+		}},
+		{"alg none", func() string {
+			return encode(t, map[string]string{"alg": "none"}) + "." + encode(t, validClaims()) + "."
+		}},
+		{"malformed", func() string { return "not-a-jwt" }},
+	}
+	for _, tt := range tests {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Act
+			principal, err := verifier.Verify(ctx, tt.token())
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+			assert.Nil(t, principal)
+		})
+	}
+}
+
+func TestVerifier_APIKeyIssuer(t *testing.T) {
+	ctx := context.Background()
+	verifier, err := jwt.NewVerifier(ctx, config.AuthConfig{HMACSecret: testSecret})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		claims func() map[string]any
+	}{
+		{"api key issuer", func() map[string]any {
+			claims := validClaims()
+			claims["iss"] = domain.APIKeyIssuer
+			return claims
+		}},
+		{"api key subject", func() map[string]any {
+			claims := validClaims()
+			claims["sub"] = domain.APIKeyIssuer + ":" + uuid.NewString()
+			return claims
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Act
+			principal, err := verifier.Verify(ctx, signHS256(t, testSecret, tt.claims()))
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+			assert.Nil(t, principal)
+		})
+	}
+}
+
+func TestVerifier_JWKSFile(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	rsaKey, ecKey := generateKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, rsaKey, ecKey), 0o600))
+
+	verifier, err := jwt.NewVerifier(ctx, config.AuthConfig{JWKSFile: path, Audience: testAudience})
+	require.NoError(t, err)
+
+	t.Run("success - RS256", func(t *testing.T) {
+		principal, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", validClaims()))
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("success - ES256", func(t *testing.T) {
+		principal, err := verifier.Verify(ctx, signES256(t, ecKey, "ec-1", validClaims()))
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("error - unknown kid", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-2", validClaims()))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("error - signed by another key", func(t *testing.T) {
+		otherKey, _ := generateKeys(t)
+
+		_, err := verifier.Verify(ctx, signRS256(t, otherKey, "rsa-1", validClaims()))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("error - HS256 without secret", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, signHS256(t, testSecret, validClaims()))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+}
+
+func TestVerifier_JWKSURL(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	rsaKey, ecKey := generateKeys(t)
+	document := jwksDocument(t, rsaKey, ecKey)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(document)
+	}))
+	defer server.Close()
+
+	verifier, err := jwt.NewVerifier(ctx, config.AuthConfig{JWKSURL: server.URL, JWKSRefresh: time.Hour})
+	require.NoError(t, err)
+
+	// Act
+	principal, err := verifier.Verify(ctx, signES256(t, ecKey, "ec-1", validClaims()))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+}
+
+func TestNewVerifier_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no key material", func(t *testing.T) {
+		_, err := jwt.NewVerifier(ctx, config.AuthConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("unreachable jwks url", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := jwt.NewVerifier(ctx, config.AuthConfig{JWKSURL: server.URL})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"strings"
+)
+
+type verifier struct {
+	apiKeys port.TokenVerifier
+	jwt     port.TokenVerifier
+}
+
+// NewVerifier creates a port.TokenVerifier that verifies api keys (sk_ prefix) with apiKeys and other tokens with jwt.
+// Tokens whose verifier is nil are rejected.
+func NewVerifier(apiKeys, jwt port.TokenVerifier) port.TokenVerifier {
+	return &verifier{
+		apiKeys: apiKeys,
+		jwt:     jwt,
+	}
+}
+
+// Verify verifies token with the verifier of its kind
+func (v *verifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	next := v.jwt
+	if strings.HasPrefix(token, domain.APIKeyPrefix) {
+		next = v.apiKeys
+	}
+	if next == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	return next.Verify(ctx, token)
+}
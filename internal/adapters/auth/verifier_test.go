@@ -0,0 +1,60 @@
+package auth_test
+
+import (
+	"context"
+	"score-play/internal/adapters/auth"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("api keys go to the api key verifier", func(t *testing.T) {
+		// Arrange
+		apiKeys := &apikey.MockAPIKeyService{}
+		jwt := &apikey.MockAPIKeyService{}
+		apiKeys.On("Verify", ctx, "sk_secret").Return(&domain.Principal{Subject: "apikey:1"}, nil)
+
+		// Act
+		principal, err := auth.NewVerifier(apiKeys, jwt).Verify(ctx, "sk_secret")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:1", principal.Subject)
+		apiKeys.AssertExpectations(t)
+		jwt.AssertNotCalled(t, "Verify")
+	})
+
+	t.Run("other tokens go to the jwt verifier", func(t *testing.T) {
+		// Arrange
+		apiKeys := &apikey.MockAPIKeyService{}
+		jwt := &apikey.MockAPIKeyService{}
+		jwt.On("Verify", ctx, "eyJhbGciOi").Return(&domain.Principal{Subject: "user-1"}, nil)
+
+		// Act
+		principal, err := auth.NewVerifier(apiKeys, jwt).Verify(ctx, "eyJhbGciOi")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+		jwt.AssertExpectations(t)
+		apiKeys.AssertNotCalled(t, "Verify")
+	})
+
+	t.Run("jwts are rejected without jwt verifier", func(t *testing.T) {
+		// Arrange
+		apiKeys := &apikey.MockAPIKeyService{}
+
+		// Act
+		_, err := auth.NewVerifier(apiKeys, nil).Verify(ctx, "eyJhbGciOi")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		apiKeys.AssertNotCalled(t, "Verify")
+	})
+}
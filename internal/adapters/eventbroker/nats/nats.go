@@ -6,11 +6,14 @@ import (
 	"log/slog"
 	"score-play/internal/config"
 	"score-play/internal/core/port"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // Consumer is a struct to interact with nats
@@ -20,8 +23,11 @@ type Consumer struct {
 	js     jetstream.JetStream
 	config config.NATSConfig
 	sub    *nats.Subscription
-	iter   jetstream.MessagesContext
-	wg     sync.WaitGroup
+	// mu guards cons and iter, set by Subscribe while the health probes read them
+	mu   sync.Mutex
+	cons jetstream.Consumer
+	iter jetstream.MessagesContext
+	wg   sync.WaitGroup
 }
 
 // NewNATSConsumer creates a new consumer
@@ -77,7 +83,10 @@ func (n *Consumer) Subscribe(ctx context.Context, handler port.MessageService) e
 	if err != nil {
 		return err
 	}
+	n.mu.Lock()
+	n.cons = cons
 	n.iter = iter
+	n.mu.Unlock()
 
 	n.wg.Add(1)
 	go func() {
@@ -99,28 +108,85 @@ func (n *Consumer) Subscribe(ctx context.Context, handler port.MessageService) e
 					return
 				}
 
-				if handleErr := handler.HandleMessage(ctx, msg.Data()); handleErr != nil {
+				// publishers propagating a trace context in the headers get the handling in their trace
+				msgCtx := otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers()))
+				if handleErr := handler.HandleMessage(msgCtx, msg.Data()); handleErr != nil {
 					errNak := msg.Nak()
 					if errNak != nil {
 						n.logger.Error("failed to nak message", "error", errNak)
 					}
 					n.logger.Warn("failed to handle message", "error", handleErr)
+					observeDelivery(handler, msg, false)
 					continue
 				}
 				ackErr := msg.Ack()
 				if ackErr != nil {
 					n.logger.Error("failed to ack message", "error", ackErr)
 				}
+				observeDelivery(handler, msg, true)
 			}
 		}
 	}()
 	return nil
 }
 
+// observeDelivery reports the acknowledgement of msg to handlers implementing port.DeliveryObserver
+func observeDelivery(handler port.MessageService, msg jetstream.Msg, acked bool) {
+	observer, ok := handler.(port.DeliveryObserver)
+	if !ok {
+		return
+	}
+	var numDelivered uint64 = 1
+	if meta, err := msg.Metadata(); err == nil {
+		numDelivered = meta.NumDelivered
+	}
+	observer.ObserveDelivery(numDelivered, acked)
+}
+
+// headerCarrier reads the message headers case insensitively, as propagators look for lowercase keys
+func headerCarrier(headers nats.Header) propagation.MapCarrier {
+	carrier := propagation.MapCarrier{}
+	for k, v := range headers {
+		if len(v) > 0 {
+			carrier[strings.ToLower(k)] = v[0]
+		}
+	}
+	return carrier
+}
+
+// Check reports the connection status and the lag of the consumer, failing when the lag exceeds NATS_MAX_CONSUMER_LAG
+func (n *Consumer) Check(ctx context.Context) (map[string]any, error) {
+	if status := n.conn.Status(); status != nats.CONNECTED {
+		return nil, fmt.Errorf("connection is %s", status)
+	}
+	n.mu.Lock()
+	cons := n.cons
+	n.mu.Unlock()
+	if cons == nil {
+		return nil, fmt.Errorf("not subscribed")
+	}
+	info, err := cons.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consumer info: %w", err)
+	}
+	details := map[string]any{
+		"pending":     info.NumPending,
+		"ack_pending": info.NumAckPending,
+		"redelivered": info.NumRedelivered,
+	}
+	if n.config.MaxConsumerLag > 0 && info.NumPending > n.config.MaxConsumerLag {
+		return details, fmt.Errorf("consumer lag %d exceeds %d", info.NumPending, n.config.MaxConsumerLag)
+	}
+	return details, nil
+}
+
 // Close graceful shutdown
 func (n *Consumer) Close() error {
-	if n.iter != nil {
-		n.iter.Stop()
+	n.mu.Lock()
+	iter := n.iter
+	n.mu.Unlock()
+	if iter != nil {
+		iter.Stop()
 	}
 
 	n.wg.Wait()
@@ -249,3 +249,46 @@ func TestConsumer_GracefulShutdown(t *testing.T) {
 	case <-time.After(500 * time.Millisecond):
 	}
 }
+
+func TestConsumer_CheckWhileSubscribing(t *testing.T) {
+	// Arrange
+	natsURL, cleanup := setupNATSContainer(t)
+	defer cleanup()
+
+	nc, err := nats.Connect(natsURL)
+	require.NoError(t, err)
+	defer nc.Close()
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+	setupStream(t, js, "test-stream", "test.subject")
+
+	cfg := config.NATSConfig{
+		URL:          natsURL,
+		StreamName:   "test-stream",
+		Subject:      "test.subject",
+		ConsumerName: "test-consumer",
+	}
+	consumer, err := nats2.NewNATSConsumer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	defer consumer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Act
+	// the probes run concurrently with the subscription, go test -race reports unguarded accesses
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			_, _ = consumer.Check(ctx)
+		}
+	}()
+	err = consumer.Subscribe(ctx, &mockHandler{})
+	<-done
+
+	// Assert
+	require.NoError(t, err)
+	_, err = consumer.Check(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+package chi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"strings"
+)
+
+// AuthMiddleware authenticates requests with a bearer token and stores the principal in the request context.
+// When requiredScope is not empty, principals without that scope are rejected with 403.
+// Api keys (sk_ prefix, verified by the api key verifier) are checked against the scope of the route instead, see apiKeyScope.
+func AuthMiddleware(verifier port.TokenVerifier, requiredScope string, l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || token == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthenticated, "missing bearer token")
+				return
+			}
+
+			principal, err := verifier.Verify(r.Context(), token)
+			switch {
+			case errors.Is(err, domain.ErrUnauthenticated):
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthenticated, "invalid token")
+				return
+			case err != nil:
+				l.Error("error verifying token", "error", err)
+				problem.Write(w, r, http.StatusServiceUnavailable, problem.CodeServiceUnavailable, "")
+				return
+			case strings.HasPrefix(token, domain.APIKeyPrefix):
+				if scope := apiKeyScope(r); !principal.HasScope(scope) && !principal.HasScope(domain.ScopeAdmin) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+					problem.Write(w, r, http.StatusForbidden, problem.CodeInsufficientScope, "missing scope "+scope)
+					return
+				}
+			case requiredScope != "" && !principal.HasScope(requiredScope):
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+requiredScope+`"`)
+				problem.Write(w, r, http.StatusForbidden, problem.CodeInsufficientScope, "missing scope "+requiredScope)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), *principal)))
+		})
+	}
+}
+
+// apiKeyScope returns the scope an api key needs for the request, the admin scope grants every other one
+func apiKeyScope(r *http.Request) string {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/v1/apikeys"):
+		return domain.ScopeAdmin
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return domain.ScopeRead
+	case strings.HasPrefix(r.URL.Path, "/api/v1/tag"):
+		return domain.ScopeTagsWrite
+	default:
+		return domain.ScopeUpload
+	}
+}
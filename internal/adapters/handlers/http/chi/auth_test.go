@@ -0,0 +1,254 @@
+package chi_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/auth"
+	"score-play/internal/adapters/auth/jwt"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	tagservice "score-play/internal/core/service/tag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func hs256Token(t *testing.T, claims map[string]any) string {
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := encode(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	verifier, err := jwt.NewVerifier(context.Background(), config.AuthConfig{HMACSecret: testSecret})
+	require.NoError(t, err)
+
+	newRouter := func(mockTagService *tagservice.MockTagService) http2.Handler {
+		handler := tag.NewTagHandlerV1(mockTagService, discardLogger)
+		return chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler, Middlewares: []func(http2.Handler) http2.Handler{chi.AuthMiddleware(verifier, "tags:read", discardLogger)}})
+	}
+
+	t.Run("success - principal is passed to the service", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		hasPrincipal := mock.MatchedBy(func(ctx context.Context) bool {
+			principal, ok := domain.PrincipalFromContext(ctx)
+			return ok && principal.Subject == "user-1"
+		})
+		mockTagService.On("ListTags", hasPrincipal, 3, (*string)(nil)).Return([]domain.Tag{}, (*string)(nil), nil)
+
+		token := hs256Token(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix(), "scope": "tags:read"})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag?limit=3", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		mockTagService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized - missing token", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+		mockTagService.AssertNotCalled(t, "ListTags")
+	})
+
+	t.Run("unauthorized - expired token", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		token := hs256Token(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix(), "scope": "tags:read"})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+		mockTagService.AssertNotCalled(t, "ListTags")
+	})
+
+	t.Run("forbidden - missing scope", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		token := hs256Token(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix(), "scope": "files:read"})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `error="insufficient_scope"`)
+		mockTagService.AssertNotCalled(t, "ListTags")
+	})
+
+	t.Run("unauthorized - jwt claiming an api key", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		token := hs256Token(t, map[string]any{"sub": domain.APIKeyIssuer + ":" + uuid.NewString(), "iss": domain.APIKeyIssuer, "exp": time.Now().Add(time.Hour).Unix()})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusUnauthorized, w.Code)
+		mockTagService.AssertNotCalled(t, "ListTags")
+	})
+
+	t.Run("health is not authenticated", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http2.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(&tagservice.MockTagService{}).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	})
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	newRouter := func(mockTagService *tagservice.MockTagService, scopes ...string) http2.Handler {
+		apiKeys := &apikey.MockAPIKeyService{}
+		apiKeys.On("Verify", mock.Anything, "sk_secret").Return(&domain.Principal{Subject: "apikey:1", Issuer: domain.APIKeyIssuer, Scopes: scopes, Tenant: "acme"}, nil)
+		handler := tag.NewTagHandlerV1(mockTagService, discardLogger)
+		verifier := auth.NewVerifier(apiKeys, nil)
+		// the required scope only applies to jwts
+		return chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler, Middlewares: []func(http2.Handler) http2.Handler{chi.AuthMiddleware(verifier, "tags:read", discardLogger)}})
+	}
+
+	t.Run("success - read scope lists tags of the key's tenant", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		inTenant := mock.MatchedBy(func(ctx context.Context) bool {
+			tenant, _ := domain.TenantFromContext(ctx)
+			return tenant == "acme"
+		})
+		mockTagService.On("ListTags", inTenant, 3, (*string)(nil)).Return([]domain.Tag{}, (*string)(nil), nil)
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag?limit=3", nil)
+		req.Header.Set("Authorization", "Bearer sk_secret")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService, domain.ScopeRead).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		mockTagService.AssertExpectations(t)
+	})
+
+	t.Run("success - admin scope grants every scope", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		mockTagService.On("CreateTags", mock.Anything, []string{"goal"}).Return(nil)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/tag", strings.NewReader(`{"tags":["goal"]}`))
+		req.Header.Set("Authorization", "Bearer sk_secret")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService, domain.ScopeAdmin).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockTagService.AssertExpectations(t)
+	})
+
+	t.Run("forbidden - creating tags needs tags:write", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/tag", strings.NewReader(`{"tags":["goal"]}`))
+		req.Header.Set("Authorization", "Bearer sk_secret")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService, domain.ScopeRead, domain.ScopeUpload).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `scope="tags:write"`)
+		mockTagService.AssertNotCalled(t, "CreateTags")
+	})
+
+	t.Run("forbidden - tokens of another verifier need the required scope", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		jwts := &apikey.MockAPIKeyService{}
+		jwts.On("Verify", mock.Anything, "jwt").Return(&domain.Principal{Subject: "apikey:1", Issuer: domain.APIKeyIssuer, Scopes: []string{domain.ScopeRead}}, nil)
+		handler := tag.NewTagHandlerV1(mockTagService, discardLogger)
+		router := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler, Middlewares: []func(http2.Handler) http2.Handler{chi.AuthMiddleware(auth.NewVerifier(nil, jwts), "tags:read", discardLogger)}})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		req.Header.Set("Authorization", "Bearer jwt")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `scope="tags:read"`)
+		mockTagService.AssertNotCalled(t, "ListTags")
+	})
+
+	t.Run("unauthorized - jwts are rejected without jwt verifier", func(t *testing.T) {
+		// Arrange
+		mockTagService := &tagservice.MockTagService{}
+		token := hs256Token(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix(), "scope": "tags:read"})
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockTagService, domain.ScopeRead).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,27 @@
+package chi
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+)
+
+// ConcurrencyLimitMiddleware serves up to limit requests at a time, the others are answered 503 with Retry-After
+// rather than waiting for a slot. A limit of 0 or less does not limit.
+func ConcurrencyLimitMiddleware(limit int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		slots := make(chan struct{}, limit)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				next.ServeHTTP(w, r)
+			default:
+				w.Header().Set("Retry-After", "1")
+				problem.Write(w, r, http.StatusServiceUnavailable, problem.CodeServiceUnavailable, "")
+			}
+		})
+	}
+}
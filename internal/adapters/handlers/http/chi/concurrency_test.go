@@ -0,0 +1,58 @@
+package chi_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	t.Run("requests beyond the limit are answered 503 until a slot is free", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		release := make(chan struct{})
+		blocking := http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) {
+			if r.URL.Path == "/slow" {
+				close(started)
+				<-release
+			}
+			w.WriteHeader(http2.StatusOK)
+		})
+		handler := chi.ConcurrencyLimitMiddleware(1)(blocking)
+		send := func(path string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http2.MethodPatch, path, nil))
+			return w
+		}
+		slow := make(chan *httptest.ResponseRecorder)
+		go func() { slow <- send("/slow") }()
+		<-started
+
+		// Act
+		limited := send("/fast")
+		close(release)
+		first := <-slow
+		freed := send("/fast")
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, first.Code)
+		assert.Equal(t, http2.StatusServiceUnavailable, limited.Code)
+		assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+		assert.Equal(t, http2.StatusOK, freed.Code)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		// Arrange
+		handler := chi.ConcurrencyLimitMiddleware(0)(http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) { w.WriteHeader(http2.StatusOK) }))
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, httptest.NewRequest(http2.MethodPatch, "/", nil))
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+	})
+}
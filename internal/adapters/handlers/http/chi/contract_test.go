@@ -0,0 +1,304 @@
+package chi_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/docs"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/apikey"
+	"score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/adapters/handlers/http/openapi"
+	"score-play/internal/adapters/health"
+	"score-play/internal/core/domain"
+	apikeyservice "score-play/internal/core/service/apikey"
+	fileservice "score-play/internal/core/service/file"
+	tagservice "score-play/internal/core/service/tag"
+	tusservice "score-play/internal/core/service/tus"
+	"strings"
+	"testing"
+	"time"
+
+	gochi "github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type contractMocks struct {
+	tags    *tagservice.MockTagService
+	files   *fileservice.MockFileService
+	apiKeys *apikeyservice.MockAPIKeyService
+	tus     *tusservice.MockTusService
+	health  *health.Checker
+}
+
+func contractRouter(spec *openapi.Spec, mocks contractMocks) http2.Handler {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return chi.NewRouter(chi.RouterConfig{
+		Logger:      discardLogger,
+		Tag:         tag.NewTagHandlerV1(mocks.tags, discardLogger),
+		File:        file.NewFileHandlerV1(mocks.files, discardLogger),
+		APIKey:      apikey.NewAPIKeyHandlerV1(mocks.apiKeys, discardLogger),
+		Tus:         tus.NewTusHandlerV1(mocks.tus, 0, discardLogger),
+		Health:      mocks.health,
+		Middlewares: []func(http2.Handler) http2.Handler{chi.OpenAPIMiddleware(spec, true, false, discardLogger)},
+	})
+}
+
+// TestContract exercises every operation of docs/openapi.yaml through the router and checks the responses match the spec
+func TestContract(t *testing.T) {
+	spec, err := openapi.Load(docs.OpenAPI)
+	require.NoError(t, err)
+	spec.Strict = true
+
+	fileID := uuid.New()
+	sessionID := uuid.New()
+	keyID := uuid.New()
+	now := time.Now().UTC()
+	url := "https://minio.local/bucket/key?X-Amz-Signature=abc"
+	filename := "video.mp4"
+	headers := map[string]string{"x-amz-checksum-sha256": "abc"}
+	version := &domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2, Filename: filename, MimeType: "video/mp4", SizeBytes: 1024, Status: domain.FileStatusCompleted, CreatedAt: now}
+	key := &domain.APIKey{ID: keyID, Name: "camera-1", Prefix: "sk_Ab3dE9xQ", Scopes: []string{domain.ScopeUpload}, ExpiresAt: &now, CreatedAt: now}
+	uploadBody := `{"filename":"video.mp4","content_type":"video/mp4","size_bytes":1024,"checksum_sha256":"abc","tags":["football"]}`
+	versionBody := `{"filename":"video.mp4","content_type":"video/mp4","size_bytes":1024,"checksum_sha256":"abc"}`
+	tusPath := "/api/v1/tus/" + sessionID.String()
+	tusHeaders := http2.Header{tus.HeaderResumable: {tus.Version}}
+	writeHeaders := http2.Header{tus.HeaderResumable: {tus.Version}, "Content-Type": {tus.OffsetContentType}, tus.HeaderUploadOffset: {"0"}}
+	// requestHeaders are the headers of the tests sending any
+	requestHeaders := map[string]http2.Header{
+		"tus create upload":                   {tus.HeaderResumable: {tus.Version}, tus.HeaderUploadLength: {"1024"}, tus.HeaderUploadMetadata: {"filename dmlkZW8ubXA0,filetype dmlkZW8vbXA0,checksum YWJj,tags Zm9vdGJhbGw="}},
+		"tus create upload too large":         {tus.HeaderResumable: {tus.Version}, tus.HeaderUploadLength: {"4096"}},
+		"tus create upload with old protocol": {tus.HeaderResumable: {"0.2.0"}},
+		"tus get offset":                      tusHeaders,
+		"tus write upload":                    writeHeaders,
+		"tus write upload at wrong offset":    writeHeaders,
+		"tus write upload with bad checksum":  {tus.HeaderResumable: {tus.Version}, "Content-Type": {tus.OffsetContentType}, tus.HeaderUploadOffset: {"0"}, tus.HeaderUploadChecksum: {"sha256 YWJj"}},
+		"tus write locked upload":             writeHeaders,
+		"tus write upload as json":            {tus.HeaderResumable: {tus.Version}, "Content-Type": {"application/json"}, tus.HeaderUploadOffset: {"0"}},
+		"tus terminate upload":                tusHeaders,
+	}
+	healthChecker := health.NewChecker(time.Second, 0)
+	healthChecker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+		return map[string]any{"open_connections": 1}, nil
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(m contractMocks)
+		expectedStatus int
+	}{
+		{"create tags", http2.MethodPost, "/api/v1/tag", `{"tags":["football"]}`, func(m contractMocks) {
+			m.tags.On("CreateTags", mock.Anything, []string{"football"}).Return(nil)
+		}, http2.StatusCreated},
+		{"create existing tags", http2.MethodPost, "/api/v1/tag", `{"tags":["football"]}`, func(m contractMocks) {
+			m.tags.On("CreateTags", mock.Anything, []string{"football"}).Return(domain.ErrAlreadyExists)
+		}, http2.StatusConflict},
+		{"create tags without tags", http2.MethodPost, "/api/v1/tag", `{}`, nil, http2.StatusBadRequest},
+		{"list tags", http2.MethodGet, "/api/v1/tag?limit=2", "", func(m contractMocks) {
+			marker := "match"
+			m.tags.On("ListTags", mock.Anything, 2, (*string)(nil)).Return([]domain.Tag{{ID: uuid.New(), Name: "football", CreatedAt: now}}, &marker, nil)
+		}, http2.StatusOK},
+		{"list tags with invalid limit", http2.MethodGet, "/api/v1/tag?limit=0", "", nil, http2.StatusBadRequest},
+		{"upload file", http2.MethodPost, "/api/v1/file/upload", uploadBody, func(m contractMocks) {
+			m.files.On("RequestUploadFile", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&fileID, &url, headers, &now, nil)
+		}, http2.StatusCreated},
+		{"upload file with a form", http2.MethodPost, "/api/v1/file/upload?mode=post", uploadBody, func(m contractMocks) {
+			m.files.On("RequestUploadFilePost", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&fileID, &url, map[string]string{"key": "video/key", "policy": "eyJ"}, &now, nil)
+		}, http2.StatusCreated},
+		{"upload file with unknown mode", http2.MethodPost, "/api/v1/file/upload?mode=form", uploadBody, nil, http2.StatusBadRequest},
+		{"upload file over quota", http2.MethodPost, "/api/v1/file/upload", uploadBody, func(m contractMocks) {
+			m.files.On("RequestUploadFile", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return((*uuid.UUID)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrQuotaExceeded)
+		}, http2.StatusRequestEntityTooLarge},
+		{"upload duplicate file", http2.MethodPost, "/api/v1/file/upload", strings.Replace(uploadBody, "{", `{"deduplicate":true,`, 1), func(m contractMocks) {
+			m.files.On("DeduplicateUpload", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&fileID, nil)
+		}, http2.StatusCreated},
+		{"upload file with string size", http2.MethodPost, "/api/v1/file/upload", strings.Replace(uploadBody, "1024", `"1024"`, 1), nil, http2.StatusBadRequest},
+		{"upload multipart", http2.MethodPost, "/api/v1/file/upload/multipart", uploadBody, func(m contractMocks) {
+			m.files.On("RequestUploadMultipartFile", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&sessionID, 5<<20, nil)
+		}, http2.StatusCreated},
+		{"upload duplicate multipart", http2.MethodPost, "/api/v1/file/upload/multipart", strings.Replace(uploadBody, "{", `{"deduplicate":true,`, 1), func(m contractMocks) {
+			m.files.On("DeduplicateUpload", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&fileID, nil)
+		}, http2.StatusCreated},
+		{"retrieve presigned parts", http2.MethodPost, "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts", `{"parts":[{"part_number":1,"checksum":"abc","content_length":1024}]}`, func(m contractMocks) {
+			m.files.On("GetPresignedParts", mock.Anything, sessionID, mock.Anything).Return([]domain.UploadPart{{PartNumber: 1, PresignedURL: url, Headers: headers, ExpiresAt: &now}}, nil)
+		}, http2.StatusCreated},
+		{"retrieve presigned parts with invalid session", http2.MethodPost, "/api/v1/file/upload/multipart/not-a-uuid/parts", `{"parts":[]}`, nil, http2.StatusBadRequest},
+		{"get parts", http2.MethodGet, "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=2", "", func(m contractMocks) {
+			m.files.On("ListParts", mock.Anything, sessionID, 2, 0).Return([]domain.UploadPart{{PartNumber: 1, ETag: "etag-1"}}, 1, nil)
+		}, http2.StatusOK},
+		{"complete multipart", http2.MethodPost, "/api/v1/file/upload/multipart/" + sessionID.String() + "/complete", `{"parts":[{"part_number":1,"etag":"etag-1","checksum":"abc"}]}`, func(m contractMocks) {
+			m.files.On("CompleteMultipartUpload", mock.Anything, sessionID, mock.Anything).Return(&fileID, nil)
+		}, http2.StatusCreated},
+		{"complete unknown session", http2.MethodPost, "/api/v1/file/upload/multipart/" + sessionID.String() + "/complete", `{"parts":[{"part_number":1,"etag":"etag-1","checksum":"abc"}]}`, func(m contractMocks) {
+			m.files.On("CompleteMultipartUpload", mock.Anything, sessionID, mock.Anything).Return((*uuid.UUID)(nil), domain.ErrSessionNotFound)
+		}, http2.StatusNotFound},
+		{"get file", http2.MethodGet, "/api/v1/file/" + fileID.String(), "", func(m contractMocks) {
+			m.files.On("GetFile", mock.Anything, fileID, mock.Anything).Return(&url, &filename, []domain.Tag{{Name: "football"}}, headers, &now, "abc", nil)
+		}, http2.StatusOK},
+		{"get file with trailing slash", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/?disposition=attachment", "", func(m contractMocks) {
+			m.files.On("GetFile", mock.Anything, fileID, mock.Anything).Return(&url, &filename, []domain.Tag{{Name: "football"}}, map[string]string(nil), &now, "", nil)
+		}, http2.StatusOK},
+		{"get archived file", http2.MethodGet, "/api/v1/file/" + fileID.String(), "", func(m contractMocks) {
+			m.files.On("GetFile", mock.Anything, fileID, mock.Anything).Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrFileRestoring)
+		}, http2.StatusAccepted},
+		{"get file with invalid disposition", http2.MethodGet, "/api/v1/file/" + fileID.String() + "?disposition=download", "", nil, http2.StatusBadRequest},
+		{"upload file version", http2.MethodPost, "/api/v1/file/" + fileID.String() + "/versions", versionBody, func(m contractMocks) {
+			m.files.On("RequestUploadFileVersion", mock.Anything, fileID, filename, "video/mp4", int64(1024), "abc").Return(version, &url, headers, &now, nil)
+		}, http2.StatusCreated},
+		{"upload multipart file version", http2.MethodPost, "/api/v1/file/" + fileID.String() + "/versions", strings.Replace(versionBody, "}", `,"multipart":true}`, 1), func(m contractMocks) {
+			m.files.On("RequestUploadMultipartFileVersion", mock.Anything, fileID, filename, "video/mp4", int64(1024), "abc").Return(version, &sessionID, 5<<20, nil)
+		}, http2.StatusCreated},
+		{"list file versions", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/versions", "", func(m contractMocks) {
+			m.files.On("ListFileVersions", mock.Anything, fileID).Return([]domain.FileVersion{*version}, nil)
+		}, http2.StatusOK},
+		{"get file version", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/versions/2?ttl=60", "", func(m contractMocks) {
+			m.files.On("GetFileVersion", mock.Anything, fileID, 2, mock.Anything).Return(&url, &filename, headers, &now, nil)
+		}, http2.StatusOK},
+		{"get missing file version", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/versions/3", "", func(m contractMocks) {
+			m.files.On("GetFileVersion", mock.Anything, fileID, 3, mock.Anything).Return((*string)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrFileVersionNotFound)
+		}, http2.StatusNotFound},
+		{"get file version zero", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/versions/0", "", nil, http2.StatusBadRequest},
+		{"copy file", http2.MethodPost, "/api/v1/file/" + fileID.String() + "/copy", `{"filename":"highlights.mp4","tags":["football"]}`, func(m contractMocks) {
+			copyID := uuid.New()
+			m.files.On("CopyFile", mock.Anything, fileID, "highlights.mp4", []string{"football"}, (*domain.Clip)(nil)).Return(&copyID, nil)
+		}, http2.StatusCreated},
+		{"copy archived file", http2.MethodPost, "/api/v1/file/" + fileID.String() + "/copy", `{"tags":["football"]}`, func(m contractMocks) {
+			m.files.On("CopyFile", mock.Anything, fileID, "", []string{"football"}, (*domain.Clip)(nil)).Return((*uuid.UUID)(nil), domain.ErrFileRestoring)
+		}, http2.StatusAccepted},
+		{"import file", http2.MethodPost, "/api/v1/file/import", `{"source_url":"https://media.example.com/match.mp4","filename":"match.mp4","content_type":"video/mp4","size_bytes":1024,"tags":["football"]}`, func(m contractMocks) {
+			m.files.On("RequestImportFile", mock.Anything, "https://media.example.com/match.mp4", "match.mp4", "video/mp4", int64(1024), "", []string{"football"}).Return(&fileID, nil)
+		}, http2.StatusAccepted},
+		{"import file from a host not allowed", http2.MethodPost, "/api/v1/file/import", `{"source_url":"http://169.254.169.254/latest","filename":"match.mp4","content_type":"video/mp4","size_bytes":1024,"tags":["football"]}`, func(m contractMocks) {
+			m.files.On("RequestImportFile", mock.Anything, "http://169.254.169.254/latest", "match.mp4", "video/mp4", int64(1024), "", []string{"football"}).Return((*uuid.UUID)(nil), domain.ErrSourceNotAllowed)
+		}, http2.StatusBadRequest},
+		{"get file import", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/import", "", func(m contractMocks) {
+			fileImport := &domain.FileImport{FileID: fileID, SourceURL: "https://media.example.com/match.mp4", Status: domain.ImportStatusFailed, SizeBytes: 1024, Attempts: 3, Error: "source unavailable", CreatedAt: now, UpdatedAt: now}
+			m.files.On("GetFileImport", mock.Anything, fileID).Return(fileImport, domain.FileStatusFailed, nil)
+		}, http2.StatusOK},
+		{"get missing file import", http2.MethodGet, "/api/v1/file/" + fileID.String() + "/import", "", func(m contractMocks) {
+			m.files.On("GetFileImport", mock.Anything, fileID).Return((*domain.FileImport)(nil), domain.FileStatus(""), domain.ErrImportNotFound)
+		}, http2.StatusNotFound},
+		{"get usage", http2.MethodGet, "/api/v1/usage", "", func(m contractMocks) {
+			m.files.On("GetUsage", mock.Anything).Return(&domain.Usage{BytesStored: 1024, FileCount: 1}, &domain.Usage{BytesStored: 512, FileCount: 1, MaxBytes: 4096}, nil)
+		}, http2.StatusOK},
+		{"create api key", http2.MethodPost, "/api/v1/apikeys", `{"name":"camera-1","scopes":["upload"],"expires_at":null}`, func(m contractMocks) {
+			m.apiKeys.On("CreateAPIKey", mock.Anything, "camera-1", []string{"upload"}, (*time.Time)(nil)).Return(key, "sk_secret", nil)
+		}, http2.StatusCreated},
+		{"create api key with unknown scope", http2.MethodPost, "/api/v1/apikeys", `{"name":"camera-1","scopes":["delete"]}`, nil, http2.StatusBadRequest},
+		{"list api keys", http2.MethodGet, "/api/v1/apikeys", "", func(m contractMocks) {
+			m.apiKeys.On("ListAPIKeys", mock.Anything).Return([]domain.APIKey{*key}, nil)
+		}, http2.StatusOK},
+		{"revoke api key", http2.MethodDelete, "/api/v1/apikeys/" + keyID.String(), "", func(m contractMocks) {
+			m.apiKeys.On("RevokeAPIKey", mock.Anything, keyID).Return(nil)
+		}, http2.StatusNoContent},
+		{"rotate api key", http2.MethodPost, "/api/v1/apikeys/" + keyID.String() + "/rotate", "", func(m contractMocks) {
+			m.apiKeys.On("RotateAPIKey", mock.Anything, keyID).Return(key, "sk_secret", nil)
+		}, http2.StatusCreated},
+		{"rotate missing api key", http2.MethodPost, "/api/v1/apikeys/" + keyID.String() + "/rotate", "", func(m contractMocks) {
+			m.apiKeys.On("RotateAPIKey", mock.Anything, keyID).Return((*domain.APIKey)(nil), "", domain.ErrAPIKeyNotFound)
+		}, http2.StatusNotFound},
+		{"tus options", http2.MethodOptions, "/api/v1/tus", "", func(m contractMocks) {
+			m.tus.On("MaxSize").Return(int64(2048))
+		}, http2.StatusNoContent},
+		{"tus create upload", http2.MethodPost, "/api/v1/tus", "", func(m contractMocks) {
+			m.tus.On("MaxSize").Return(int64(2048))
+			m.tus.On("Create", mock.Anything, filename, "video/mp4", int64(1024), "abc", []string{"football"}).Return(&sessionID, nil)
+		}, http2.StatusCreated},
+		{"tus create upload too large", http2.MethodPost, "/api/v1/tus", "", func(m contractMocks) {
+			m.tus.On("MaxSize").Return(int64(2048))
+		}, http2.StatusRequestEntityTooLarge},
+		{"tus create upload with old protocol", http2.MethodPost, "/api/v1/tus", "", nil, http2.StatusPreconditionFailed},
+		{"tus get offset", http2.MethodHead, tusPath, "", func(m contractMocks) {
+			m.tus.On("Offset", mock.Anything, sessionID).Return(int64(512), int64(1024), nil)
+		}, http2.StatusOK},
+		{"tus write upload", http2.MethodPatch, tusPath, "data", func(m contractMocks) {
+			m.tus.On("Write", mock.Anything, sessionID, int64(0), []byte("data"), "").Return(int64(4), nil)
+		}, http2.StatusNoContent},
+		{"tus write upload at wrong offset", http2.MethodPatch, tusPath, "data", func(m contractMocks) {
+			m.tus.On("Write", mock.Anything, sessionID, int64(0), []byte("data"), "").Return(int64(0), domain.ErrOffsetMismatch)
+		}, http2.StatusConflict},
+		{"tus write upload with bad checksum", http2.MethodPatch, tusPath, "data", func(m contractMocks) {
+			m.tus.On("Write", mock.Anything, sessionID, int64(0), []byte("data"), "YWJj").Return(int64(0), domain.ErrMismatchChecksum)
+		}, tus.StatusChecksumMismatch},
+		{"tus write locked upload", http2.MethodPatch, tusPath, "data", func(m contractMocks) {
+			m.tus.On("Write", mock.Anything, sessionID, int64(0), []byte("data"), "").Return(int64(0), domain.ErrUploadLocked)
+		}, http2.StatusLocked},
+		{"tus write upload as json", http2.MethodPatch, tusPath, `{}`, nil, http2.StatusUnsupportedMediaType},
+		{"tus terminate upload", http2.MethodDelete, tusPath, "", func(m contractMocks) {
+			m.tus.On("Terminate", mock.Anything, sessionID).Return(nil)
+		}, http2.StatusNoContent},
+		{"health", http2.MethodGet, "/health", "", nil, http2.StatusOK},
+		{"liveness", http2.MethodGet, "/health/live", "", nil, http2.StatusOK},
+		{"readiness", http2.MethodGet, "/health/ready", "", nil, http2.StatusOK},
+		{"openapi spec", http2.MethodGet, "/api/v1/openapi.yaml", "", nil, http2.StatusOK},
+		{"docs", http2.MethodGet, "/api/v1/docs", "", nil, http2.StatusOK},
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mocks := contractMocks{
+				tags:    &tagservice.MockTagService{},
+				files:   fileservice.NewMockFileService(),
+				apiKeys: &apikeyservice.MockAPIKeyService{},
+				tus:     &tusservice.MockTusService{},
+				health:  healthChecker,
+			}
+			if tt.setup != nil {
+				tt.setup(mocks)
+			}
+			h := contractRouter(spec, mocks)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			for name, values := range requestHeaders[tt.name] {
+				req.Header[name] = values
+			}
+
+			// Act
+			h.ServeHTTP(w, req)
+
+			// Assert
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			route, _, err := spec.FindRoute(req.Method, req.URL.Path)
+			require.NoError(t, err)
+			covered[route.Operation.OperationID] = true
+			assert.NoError(t, spec.ValidateResponse(route, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes()))
+			mocks.tags.AssertExpectations(t)
+			mocks.files.AssertExpectations(t)
+			mocks.apiKeys.AssertExpectations(t)
+			mocks.tus.AssertExpectations(t)
+		})
+	}
+
+	t.Run("every operation is exercised", func(t *testing.T) {
+		for _, route := range spec.Routes() {
+			assert.True(t, covered[route.Operation.OperationID], "%s %s (%s) is not exercised", route.Method, route.Path, route.Operation.OperationID)
+		}
+	})
+
+	t.Run("every route is in the spec", func(t *testing.T) {
+		h := contractRouter(spec, contractMocks{health: healthChecker})
+		routes, ok := h.(gochi.Routes)
+		require.True(t, ok)
+
+		err := gochi.Walk(routes, func(method string, pattern string, _ http2.Handler, _ ...func(http2.Handler) http2.Handler) error {
+			route, _, err := spec.FindRoute(method, pattern)
+			if assert.NoError(t, err, "%s %s is not in the spec", method, pattern) {
+				assert.Equal(t, strings.TrimSuffix(pattern, "/"), route.Path, "%s %s has other parameters in the spec", method, pattern)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package chi_test
+
+import (
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/adapters/metrics"
+	"score-play/internal/core/domain"
+	tagservice "score-play/internal/core/service/tag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	// Arrange
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tagService := &tagservice.MockTagService{}
+	tagService.On("ListTags", mock.Anything, 10, (*string)(nil)).Return([]domain.Tag{}, (*string)(nil), nil)
+	m := metrics.New()
+	h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: tag.NewTagHandlerV1(tagService, discardLogger), Middlewares: []func(http2.Handler) http2.Handler{chi.MetricsMiddleware(m)}})
+
+	// Act
+	for _, path := range []string{"/api/v1/tag?limit=10", "/api/v1/tag?limit=10", "/api/v1/tag?limit=abc"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http2.MethodGet, path, nil))
+	}
+
+	// Assert
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http2.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `scoreplay_http_request_duration_seconds_count{method="GET",route="/api/v1/tag",status="200"} 2`)
+	assert.Contains(t, w.Body.String(), `scoreplay_http_request_duration_seconds_count{method="GET",route="/api/v1/tag",status="400"} 1`)
+	tagService.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+package chi
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"score-play/docs"
+	"score-play/internal/adapters/handlers/http/openapi"
+	"score-play/internal/adapters/handlers/http/problem"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxValidatedResponse is the size above which response bodies are not validated
+const maxValidatedResponse = 1 << 20
+
+// OpenAPIMiddleware validates api requests against the spec, invalid ones are answered with 400.
+// When validateResponses is set, responses are checked too and mismatches are logged, the client already has them.
+// Requests the spec does not describe are let through for the router to answer.
+func OpenAPIMiddleware(spec *openapi.Spec, validateRequests bool, validateResponses bool, l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, params, err := spec.FindRoute(r.Method, r.URL.Path)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if validateRequests {
+				if err := spec.ValidateRequest(r, route, params); err != nil {
+					var verr *openapi.ValidationError
+					if !errors.As(err, &verr) {
+						l.Error("error validating request", "error", err, "operation", route.Operation.OperationID)
+						next.ServeHTTP(w, r)
+						return
+					}
+					problem.BadRequest(w, r, verr.Error())
+					return
+				}
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var body bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&limitedWriter{w: &body, n: maxValidatedResponse})
+			next.ServeHTTP(ww, r)
+
+			if body.Len() > maxValidatedResponse {
+				return
+			}
+			if err := spec.ValidateResponse(route, ww.Status(), ww.Header().Get("Content-Type"), body.Bytes()); err != nil {
+				l.Warn("response does not match the openapi spec",
+					"error", err,
+					"operation", route.Operation.OperationID,
+					"status", ww.Status(),
+					"request_id", middleware.GetReqID(r.Context()),
+				)
+			}
+		})
+	}
+}
+
+// serveOpenAPI serves the spec the api is validated against
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(docs.OpenAPI)
+}
+
+// serveDocs serves a Swagger UI page rendering the spec
+func serveDocs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(docsPage))
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Score-Play API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "openapi.yaml", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// limitedWriter keeps the first n bytes written, then one more to tell the body was truncated
+type limitedWriter struct {
+	w *bytes.Buffer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if room := l.n - l.w.Len(); room >= 0 {
+		if len(p) > room {
+			l.w.Write(p[:room+1])
+		} else {
+			l.w.Write(p)
+		}
+	}
+	return len(p), nil
+}
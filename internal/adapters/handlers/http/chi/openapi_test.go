@@ -0,0 +1,125 @@
+package chi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/docs"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/adapters/handlers/http/openapi"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	tagservice "score-play/internal/core/service/tag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIMiddleware(t *testing.T) {
+	spec, err := openapi.Load(docs.OpenAPI)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		validateRequests  bool
+		validateResponses bool
+		path              string
+		responseBody      string
+		expectedStatus    int
+		expectedDetail    string
+		expectedCalled    bool
+		expectedMismatch  bool
+	}{
+		{"valid request", true, false, "/api/v1/tag?limit=10", `{"tags":[]}`, http2.StatusOK, "", true, false},
+		{"invalid request", true, false, "/api/v1/tag?limit=abc", "", http2.StatusBadRequest, "query parameter limit: must be an integer", false, false},
+		{"missing parameter", true, false, "/api/v1/tag", "", http2.StatusBadRequest, "query parameter limit: is required", false, false},
+		{"request validation disabled", false, false, "/api/v1/tag?limit=abc", `{"tags":[]}`, http2.StatusOK, "", true, false},
+		{"route not in the spec", true, false, "/api/v1/unknown", `{}`, http2.StatusOK, "", true, false},
+		{"response matching the spec", true, true, "/api/v1/tag?limit=10", `{"tags":[{"name":"football"}]}`, http2.StatusOK, "", true, false},
+		{"response not matching the spec", true, true, "/api/v1/tag?limit=10", `{"tags":"football"}`, http2.StatusOK, "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			called := false
+			next := http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) {
+				called = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http2.StatusOK)
+				_, _ = w.Write([]byte(tt.responseBody))
+			})
+			h := chi.OpenAPIMiddleware(spec, tt.validateRequests, tt.validateResponses, logger)(next)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http2.MethodGet, tt.path, nil)
+
+			// Act
+			h.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedCalled, called)
+			assert.Equal(t, tt.expectedMismatch, bytes.Contains(logs.Bytes(), []byte("response does not match the openapi spec")))
+			if tt.expectedDetail != "" {
+				var p problem.Problem
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+				assert.Equal(t, problem.CodeInvalidRequest, p.Code)
+				assert.Equal(t, tt.expectedDetail, p.Detail)
+			} else {
+				assert.Equal(t, tt.responseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_OpenAPIDocs(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tagHandler := tag.NewTagHandlerV1(&tagservice.MockTagService{}, discardLogger)
+	denyAll := func(next http2.Handler) http2.Handler {
+		return http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) {
+			problem.Error(w, r, discardLogger, domain.ErrUnauthenticated)
+		})
+	}
+	h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: tagHandler, Middlewares: []func(http2.Handler) http2.Handler{denyAll}})
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedContentType string
+	}{
+		{"spec", "/api/v1/openapi.yaml", "application/yaml"},
+		{"docs page", "/api/v1/docs", "text/html; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http2.MethodGet, tt.path, nil)
+
+			// Act
+			h.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http2.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+		})
+	}
+
+	t.Run("api routes still run the middlewares", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag?limit=10", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package chi
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/adapters/ratelimit"
+	"score-play/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route groups rate limits are configured for
+const (
+	RateGroupDefault = "default"
+	RateGroupUpload  = "upload"
+	RateGroupParts   = "parts"
+)
+
+// RateLimitMiddleware limits each client with a token bucket per route group.
+// Clients are the authenticated principal, or the client ip. Groups without rate are not limited.
+// When the store fails, requests are let through rather than taking the api down.
+func RateLimitMiddleware(store ratelimit.Store, rates map[string]ratelimit.Rate, l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group := routeGroup(r)
+			rate, ok := rates[group]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := store.Take(r.Context(), group+":"+clientKey(r), rate)
+			if err != nil {
+				l.Error("error taking rate limit token", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Policy", strconv.Itoa(rate.Limit)+";w="+strconv.Itoa(seconds(rate.Period)))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+				problem.Write(w, r, http.StatusTooManyRequests, problem.CodeRateLimited, "")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeGroup classifies api requests: signing parts, starting uploads, versions and copies, and everything else
+func routeGroup(r *http.Request) string {
+	if r.Method != http.MethodPost {
+		return RateGroupDefault
+	}
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "/api/v1/file/upload/multipart/") && strings.HasSuffix(path, "/parts"):
+		return RateGroupParts
+	case strings.HasPrefix(path, "/api/v1/file/") && !strings.HasSuffix(path, "/complete"):
+		return RateGroupUpload
+	default:
+		return RateGroupDefault
+	}
+}
+
+// clientKey identifies the caller by principal, falling back to the ip set by middleware.RealIP
+func clientKey(r *http.Request) string {
+	if principal, ok := domain.PrincipalFromContext(r.Context()); ok {
+		return "sub:" + principal.Issuer + "|" + principal.Subject
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// seconds rounds d up to whole seconds as the RateLimit headers expect
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
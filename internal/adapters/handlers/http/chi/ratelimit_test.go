@@ -0,0 +1,125 @@
+package chi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/ratelimit"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStore answers with result and records the keys tokens were taken for
+type recordingStore struct {
+	result ratelimit.Result
+	err    error
+	keys   []string
+}
+
+func (s *recordingStore) Take(_ context.Context, key string, rate ratelimit.Rate) (ratelimit.Result, error) {
+	s.keys = append(s.keys, key)
+	return s.result, s.err
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rates := map[string]ratelimit.Rate{
+		chi.RateGroupDefault: {Limit: 100, Period: time.Minute},
+		chi.RateGroupParts:   {Limit: 2, Period: time.Minute},
+	}
+	ok := http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) { w.WriteHeader(http2.StatusOK) })
+
+	t.Run("parts are limited per principal with the memory store", func(t *testing.T) {
+		// Arrange
+		handler := chi.RateLimitMiddleware(ratelimit.NewMemoryStore(), rates, discardLogger)(ok)
+		send := func(subject string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart/123/parts", nil)
+			req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{Subject: subject}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			return w
+		}
+
+		// Act
+		first := send("user-1")
+		second := send("user-1")
+		limited := send("user-1")
+		other := send("user-2")
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, first.Code)
+		assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+		assert.Equal(t, http2.StatusOK, second.Code)
+		assert.Equal(t, http2.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+		assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "2", limited.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, http2.StatusOK, other.Code)
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		principal   *domain.Principal
+		expectedKey string
+	}{
+		{"anonymous read by ip", http2.MethodGet, "/api/v1/tag", nil, "default:ip:192.0.2.1"},
+		{"signing parts", http2.MethodPost, "/api/v1/file/upload/multipart/123/parts", nil, "parts:ip:192.0.2.1"},
+		{"completing is not signing", http2.MethodPost, "/api/v1/file/upload/multipart/123/complete", nil, "default:ip:192.0.2.1"},
+		{"principal", http2.MethodGet, "/api/v1/tag", &domain.Principal{Issuer: "iss", Subject: "user-1"}, "default:sub:iss|user-1"},
+	}
+	for _, tt := range tests {
+		t.Run("key - "+tt.name, func(t *testing.T) {
+			// Arrange
+			store := &recordingStore{result: ratelimit.Result{Allowed: true}}
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.principal != nil {
+				req = req.WithContext(domain.ContextWithPrincipal(req.Context(), *tt.principal))
+			}
+
+			// Act
+			chi.RateLimitMiddleware(store, rates, discardLogger)(ok).ServeHTTP(httptest.NewRecorder(), req)
+
+			// Assert
+			require.Len(t, store.keys, 1)
+			assert.Equal(t, tt.expectedKey, store.keys[0])
+		})
+	}
+
+	t.Run("group without rate is not limited", func(t *testing.T) {
+		// Arrange
+		store := &recordingStore{}
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		chi.RateLimitMiddleware(store, rates, discardLogger)(ok).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		assert.Empty(t, store.keys)
+	})
+
+	t.Run("store failure lets requests through", func(t *testing.T) {
+		// Arrange
+		store := &recordingStore{err: errors.New("redis down")}
+		w := httptest.NewRecorder()
+
+		// Act
+		chi.RateLimitMiddleware(store, rates, discardLogger)(ok).ServeHTTP(w, httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil))
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+	})
+}
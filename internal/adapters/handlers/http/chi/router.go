@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"score-play/internal/adapters/handlers/http/chi/v1/apikey"
 	"score-play/internal/adapters/handlers/http/chi/v1/file"
 	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/adapters/health"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,48 +16,103 @@ import (
 	"github.com/go-chi/cors"
 )
 
-// NewRouter builds http.Handler with chi
-func NewRouter(logger *slog.Logger, tagHandler *tag.HandlerV1, fileHandler *file.HandlerV1, env string) http.Handler {
+// RouterConfig holds the handlers and the middlewares of the router
+type RouterConfig struct {
+	Logger *slog.Logger
+	Tag    *tag.HandlerV1
+	File   *file.HandlerV1
+	// APIKey mounts the api key routes, nil when api keys are not managed by the api
+	APIKey *apikey.HandlerV1
+	// Tus mounts the tus routes, nil when resumable uploads are disabled
+	Tus *tus.HandlerV1
+	// Health mounts the health probes
+	Health *health.Checker
+	Env    string
+	// Middlewares run in order on the api routes, nil ones being skipped
+	Middlewares []func(http.Handler) http.Handler
+}
+
+// NewRouter builds http.Handler with chi.
+// The api routes run the middlewares of config in order, e.g. authentication then rate limiting,
+// and are scoped to the tenant of the request. The optional handlers of config are only mounted when they are not nil.
+// The tus routes stream uploads, so their requests are not bounded in size or duration
+// but in number, up to the concurrency limit of the tus handler.
+// The openapi spec and its docs page are public, as are the health probes.
+func NewRouter(config RouterConfig) http.Handler {
 	r := chi.NewRouter()
 
 	//handle requestID to facilitate debug (X-Request-ID)
 	//It fetches from request if exists, or creates it
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(LoggerMiddleware(logger))
+	r.Use(LoggerMiddleware(config.Logger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-	r.Use(middleware.RequestSize(5 << 20)) //5mb
 
-	if env != "prod" {
+	if config.Env != "prod" {
 		r.Use(cors.Handler(cors.Options{
 			AllowedOrigins:   []string{"http://localhost:*", "http://127.0.0.1:*"}, // Ajustez selon vos besoins
-			AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-			ExposedHeaders:   []string{"Link"},
+			AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders:   append([]string{"Accept", "Authorization", "Content-Type", "X-Request-ID", TenantHeader}, tus.RequestHeaders...),
+			ExposedHeaders:   append([]string{"Link", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Location"}, tus.ResponseHeaders...),
 			AllowCredentials: true,
 			MaxAge:           300,
 		}))
 	}
 
-	r.Route("/api/v1", func(r chi.Router) {
-		r.Mount("/tag", tagHandler.Routes())
-		r.Mount("/file", fileHandler.Routes())
+	r.Group(func(r chi.Router) {
+		bounded(r)
+		r.Get("/api/v1/openapi.yaml", serveOpenAPI)
+		r.Get("/api/v1/docs", serveDocs)
+
+		if config.Health != nil {
+			r.Get("/health/live", config.Health.Live)
+			r.Get("/health/ready", config.Health.Ready)
+		}
+
+		// deprecated: it does not check the dependencies, probes should use /health/ready
+		r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+			resp := HealthResponse{
+				Status:    "ok",
+				Timestamp: time.Now(),
+			}
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", `</health/ready>; rel="successor-version"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(resp)
+		})
 	})
 
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		resp := HealthResponse{
-			Status:    "ok",
-			Timestamp: time.Now(),
+	r.Route("/api/v1", func(r chi.Router) {
+		for _, mw := range config.Middlewares {
+			if mw != nil {
+				r.Use(mw)
+			}
+		}
+		r.Use(TenantMiddleware)
+		r.Group(func(r chi.Router) {
+			bounded(r)
+			r.Mount("/tag", config.Tag.Routes())
+			r.Mount("/file", config.File.Routes())
+			r.Get("/usage", config.File.GetUsageV1)
+			if config.APIKey != nil {
+				r.Mount("/apikeys", config.APIKey.Routes())
+			}
+		})
+		if config.Tus != nil {
+			r.With(ConcurrencyLimitMiddleware(config.Tus.MaxConcurrent())).Mount("/tus", config.Tus.Routes())
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(resp)
 	})
 
 	return r
 }
 
+// bounded limits the duration and the body size of the requests of r
+func bounded(r chi.Router) {
+	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(middleware.RequestSize(5 << 20)) //5mb
+}
+
 type HealthResponse struct {
 	Status    string    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
@@ -0,0 +1,44 @@
+package chi
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+)
+
+// TenantHeader names the tenant of requests when auth is disabled or for cross-tenant principals
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware scopes the request context to a tenant.
+// The tenant of the authenticated principal wins, then the X-Tenant-ID header, then domain.DefaultTenant.
+// A header naming another tenant than the principal is rejected with 403. Principals without a tenant
+// may only send the header with the domain.ScopeCrossTenant scope, otherwise anyone could pick a tenant.
+func TenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(TenantHeader)
+		if header != "" && !domain.ValidTenant(header) {
+			problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidTenant, "invalid tenant")
+			return
+		}
+
+		tenant := domain.DefaultTenant
+		if header != "" {
+			tenant = header
+		}
+		if principal, ok := domain.PrincipalFromContext(r.Context()); ok {
+			switch {
+			case principal.Tenant != "":
+				if header != "" && header != principal.Tenant {
+					problem.Write(w, r, http.StatusForbidden, problem.CodeForbidden, "tenant header does not match the token")
+					return
+				}
+				tenant = principal.Tenant
+			case header != "" && !principal.HasScope(domain.ScopeCrossTenant):
+				problem.Write(w, r, http.StatusForbidden, problem.CodeInvalidTenant, "the token cannot choose a tenant")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(domain.ContextWithTenant(r.Context(), tenant)))
+	})
+}
@@ -0,0 +1,55 @@
+package chi_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      *domain.Principal
+		header         string
+		expectedStatus int
+		expectedTenant string
+	}{
+		{"default tenant", nil, "", http2.StatusOK, domain.DefaultTenant},
+		{"header", nil, "acme", http2.StatusOK, "acme"},
+		{"principal tenant", &domain.Principal{Subject: "user-1", Tenant: "acme"}, "", http2.StatusOK, "acme"},
+		{"header matching principal", &domain.Principal{Subject: "user-1", Tenant: "acme"}, "acme", http2.StatusOK, "acme"},
+		{"principal without tenant gets the default tenant", &domain.Principal{Subject: "user-1"}, "", http2.StatusOK, domain.DefaultTenant},
+		{"principal without tenant cannot use header", &domain.Principal{Subject: "user-1"}, "acme", http2.StatusForbidden, ""},
+		{"cross-tenant principal uses header", &domain.Principal{Subject: "ops", Scopes: []string{domain.ScopeCrossTenant}}, "acme", http2.StatusOK, "acme"},
+		{"header naming another tenant", &domain.Principal{Subject: "user-1", Tenant: "acme"}, "globex", http2.StatusForbidden, ""},
+		{"invalid header", nil, "../acme", http2.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var tenant string
+			next := http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) {
+				tenant, _ = domain.TenantFromContext(r.Context())
+			})
+			req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag", nil)
+			if tt.principal != nil {
+				req = req.WithContext(domain.ContextWithPrincipal(req.Context(), *tt.principal))
+			}
+			if tt.header != "" {
+				req.Header.Set(chi.TenantHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			chi.TenantMiddleware(next).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedTenant, tenant)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package chi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts a server span per request, continuing the trace of the traceparent header.
+// Spans are named after the route pattern once the request has been routed.
+func TracingMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("http.route", routePattern(r)))
+		})
+		return otelhttp.NewHandler(routed, "http.request", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + routePattern(r)
+		}))
+	}
+}
+
+// routePattern is the pattern of the route matched so far
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,52 @@
+package chi_test
+
+import (
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	"score-play/internal/core/domain"
+	tagservice "score-play/internal/core/service/tag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tagService := &tagservice.MockTagService{}
+	tagService.On("ListTags", mock.Anything, 10, (*string)(nil)).Return([]domain.Tag{}, (*string)(nil), nil)
+	h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: tag.NewTagHandlerV1(tagService, discardLogger), Middlewares: []func(http2.Handler) http2.Handler{chi.TracingMiddleware()}})
+	req := httptest.NewRequest(http2.MethodGet, "/api/v1/tag?limit=10", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /api/v1/tag", spans[0].Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Contains(t, spans[0].Attributes, attribute.String("http.route", "/api/v1/tag"))
+	tagService.AssertExpectations(t)
+}
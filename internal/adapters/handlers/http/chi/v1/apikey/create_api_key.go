@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"time"
+)
+
+// V1CreateAPIKeyRequest is the body request for create api key. Keys without expires_at never expire.
+type V1CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyV1 is the handler for create api key v1
+func (h *HandlerV1) CreateAPIKeyV1(w http.ResponseWriter, r *http.Request) {
+
+	var req V1CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("error decoding create api key request", "error", err)
+		problem.BadRequest(w, r, "invalid request")
+		return
+	}
+
+	if req.Name == "" || len(req.Name) > 100 {
+		problem.BadRequest(w, r, "name must be between 1 and 100 characters")
+		return
+	}
+	if len(req.Scopes) == 0 {
+		problem.BadRequest(w, r, "scopes required")
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		problem.BadRequest(w, r, "expires_at must be in the future")
+		return
+	}
+
+	key, secret, err := h.apiKeyService.CreateAPIKey(r.Context(), req.Name, req.Scopes, req.ExpiresAt)
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		h.writeSecret(w, http.StatusCreated, key, secret)
+	}
+}
+
+// writeSecret writes a key along with its secret. The response must not be cached.
+func (h *HandlerV1) writeSecret(w http.ResponseWriter, status int, key *domain.APIKey, secret string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(V1APIKeySecretResponse{V1APIKey: toV1APIKey(*key), Key: secret}); err != nil {
+		h.logger.Error("error encoding response", "error", err)
+	}
+}
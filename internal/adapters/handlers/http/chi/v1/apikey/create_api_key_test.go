@@ -0,0 +1,110 @@
+package apikey_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	apikey2 "score-play/internal/adapters/handlers/http/chi/v1/apikey"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newRouter(mockService *apikey.MockAPIKeyService) http2.Handler {
+	handler := apikey2.NewAPIKeyHandlerV1(mockService, discardLogger)
+	return chi.NewRouter(chi.RouterConfig{Logger: discardLogger, APIKey: handler})
+}
+
+func TestCreateAPIKeyV1(t *testing.T) {
+
+	t.Run("success - secret is returned once", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		key := &domain.APIKey{ID: uuid.New(), Name: "camera-1", Prefix: "sk_abcdefgh", Scopes: []string{domain.ScopeUpload}, CreatedAt: time.Now()}
+		mockService.On("CreateAPIKey", mock.Anything, "camera-1", []string{domain.ScopeUpload}, (*time.Time)(nil)).Return(key, "sk_abcdefghsecret", nil)
+		body, err := json.Marshal(apikey2.V1CreateAPIKeyRequest{Name: "camera-1", Scopes: []string{domain.ScopeUpload}})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/apikeys", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http2.StatusCreated, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		var resp apikey2.V1APIKeySecretResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, key.ID.String(), resp.ID)
+		assert.Equal(t, "sk_abcdefgh", resp.Prefix)
+		assert.Equal(t, "sk_abcdefghsecret", resp.Key)
+		mockService.AssertExpectations(t)
+	})
+
+	past := time.Now().Add(-time.Hour)
+	invalid := []struct {
+		name string
+		req  apikey2.V1CreateAPIKeyRequest
+	}{
+		{name: "missing name", req: apikey2.V1CreateAPIKeyRequest{Scopes: []string{domain.ScopeRead}}},
+		{name: "missing scopes", req: apikey2.V1CreateAPIKeyRequest{Name: "camera-1"}},
+		{name: "expiry in the past", req: apikey2.V1CreateAPIKeyRequest{Name: "camera-1", Scopes: []string{domain.ScopeRead}, ExpiresAt: &past}},
+	}
+	for _, tt := range invalid {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &apikey.MockAPIKeyService{}
+			body, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http2.MethodPost, "/api/v1/apikeys", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http2.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "CreateAPIKey")
+		})
+	}
+
+	serviceErrors := []struct {
+		err    error
+		status int
+	}{
+		{err: fmt.Errorf("files:delete: %w", domain.ErrInvalidScope), status: http2.StatusBadRequest},
+		{err: domain.ErrForbidden, status: http2.StatusForbidden},
+		{err: assert.AnError, status: http2.StatusServiceUnavailable},
+	}
+	for _, tt := range serviceErrors {
+		t.Run(fmt.Sprintf("error - %v", tt.err), func(t *testing.T) {
+			// Arrange
+			mockService := &apikey.MockAPIKeyService{}
+			mockService.On("CreateAPIKey", mock.Anything, "camera-1", []string{"files:delete"}, (*time.Time)(nil)).Return((*domain.APIKey)(nil), "", tt.err)
+			body, err := json.Marshal(apikey2.V1CreateAPIKeyRequest{Name: "camera-1", Scopes: []string{"files:delete"}})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http2.MethodPost, "/api/v1/apikeys", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"log/slog"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// HandlerV1 is the handler for v1 api keys routes
+type HandlerV1 struct {
+	apiKeyService port.APIKeyService
+	logger        *slog.Logger
+}
+
+// NewAPIKeyHandlerV1 creates HandlerV1
+func NewAPIKeyHandlerV1(service port.APIKeyService, logger *slog.Logger) *HandlerV1 {
+	return &HandlerV1{
+		apiKeyService: service,
+		logger:        logger,
+	}
+}
+
+// Routes exposes handler routes
+func (h *HandlerV1) Routes() chi.Router {
+	router := chi.NewRouter()
+
+	router.Post("/", h.CreateAPIKeyV1)
+	router.Get("/", h.ListAPIKeysV1)
+	router.Delete("/{keyID}", h.RevokeAPIKeyV1)
+	router.Post("/{keyID}/rotate", h.RotateAPIKeyV1)
+
+	return router
+}
+
+// V1APIKey is an api key, without its secret
+type V1APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// V1APIKeySecretResponse is the response to create and rotate, the only ones returning the secret
+type V1APIKeySecretResponse struct {
+	V1APIKey
+	Key string `json:"key"`
+}
+
+func toV1APIKey(key domain.APIKey) V1APIKey {
+	return V1APIKey{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedBy:  key.CreatedBy,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+)
+
+// V1ListAPIKeysResponse is the response to list api keys
+type V1ListAPIKeysResponse struct {
+	APIKeys []V1APIKey `json:"api_keys"`
+}
+
+// ListAPIKeysV1 is the handler for list api keys v1
+func (h *HandlerV1) ListAPIKeysV1(w http.ResponseWriter, r *http.Request) {
+
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context())
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		resp := V1ListAPIKeysResponse{APIKeys: make([]V1APIKey, 0, len(keys))}
+		for _, key := range keys {
+			resp.APIKeys = append(resp.APIKeys, toV1APIKey(key))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+	}
+}
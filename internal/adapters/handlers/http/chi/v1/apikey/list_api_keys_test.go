@@ -0,0 +1,58 @@
+package apikey_test
+
+import (
+	"encoding/json"
+	http2 "net/http"
+	"net/http/httptest"
+	apikey2 "score-play/internal/adapters/handlers/http/chi/v1/apikey"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAPIKeysV1(t *testing.T) {
+
+	t.Run("success - secrets are not listed", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		lastUsedAt := time.Now()
+		keys := []domain.APIKey{{ID: uuid.New(), Name: "camera-1", Prefix: "sk_abcdefgh", Scopes: []string{domain.ScopeUpload}, LastUsedAt: &lastUsedAt}}
+		mockService.On("ListAPIKeys", mock.Anything).Return(keys, nil)
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/apikeys", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http2.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"key"`)
+		var resp apikey2.V1ListAPIKeysResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.APIKeys, 1)
+		assert.Equal(t, "camera-1", resp.APIKeys[0].Name)
+		assert.NotNil(t, resp.APIKeys[0].LastUsedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error - forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		mockService.On("ListAPIKeys", mock.Anything).Return([]domain.APIKey(nil), domain.ErrForbidden)
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/apikeys", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
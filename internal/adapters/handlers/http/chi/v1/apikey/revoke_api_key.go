@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// RevokeAPIKeyV1 is the handler for revoke api key v1
+func (h *HandlerV1) RevokeAPIKeyV1(w http.ResponseWriter, r *http.Request) {
+
+	keyID, parseErr := uuid.Parse(chi.URLParam(r, "keyID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	err := h.apiKeyService.RevokeAPIKey(r.Context(), keyID)
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
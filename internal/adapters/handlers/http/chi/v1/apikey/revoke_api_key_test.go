@@ -0,0 +1,58 @@
+package apikey_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeAPIKeyV1(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", err: nil, status: http2.StatusNoContent},
+		{name: "not found", err: domain.ErrAPIKeyNotFound, status: http2.StatusNotFound},
+		{name: "forbidden", err: domain.ErrForbidden, status: http2.StatusForbidden},
+		{name: "internal error", err: assert.AnError, status: http2.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &apikey.MockAPIKeyService{}
+			id := uuid.New()
+			mockService.On("RevokeAPIKey", mock.Anything, id).Return(tt.err)
+			req := httptest.NewRequest(http2.MethodDelete, "/api/v1/apikeys/"+id.String(), nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		req := httptest.NewRequest(http2.MethodDelete, "/api/v1/apikeys/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RevokeAPIKey")
+	})
+}
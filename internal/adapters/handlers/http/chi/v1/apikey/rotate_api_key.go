@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// RotateAPIKeyV1 is the handler for rotate api key v1
+func (h *HandlerV1) RotateAPIKeyV1(w http.ResponseWriter, r *http.Request) {
+
+	keyID, parseErr := uuid.Parse(chi.URLParam(r, "keyID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	key, secret, err := h.apiKeyService.RotateAPIKey(r.Context(), keyID)
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		h.writeSecret(w, http.StatusCreated, key, secret)
+	}
+}
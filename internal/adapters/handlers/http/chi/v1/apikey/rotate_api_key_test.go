@@ -0,0 +1,56 @@
+package apikey_test
+
+import (
+	"encoding/json"
+	http2 "net/http"
+	"net/http/httptest"
+	apikey2 "score-play/internal/adapters/handlers/http/chi/v1/apikey"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateAPIKeyV1(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		id := uuid.New()
+		key := &domain.APIKey{ID: uuid.New(), Name: "camera-1", Prefix: "sk_12345678"}
+		mockService.On("RotateAPIKey", mock.Anything, id).Return(key, "sk_12345678secret", nil)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/apikeys/"+id.String()+"/rotate", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http2.StatusCreated, w.Code)
+		var resp apikey2.V1APIKeySecretResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, key.ID.String(), resp.ID)
+		assert.Equal(t, "sk_12345678secret", resp.Key)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// Arrange
+		mockService := &apikey.MockAPIKeyService{}
+		id := uuid.New()
+		mockService.On("RotateAPIKey", mock.Anything, id).Return((*domain.APIKey)(nil), "", domain.ErrAPIKeyNotFound)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/apikeys/"+id.String()+"/rotate", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -2,8 +2,8 @@ package file
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 
 	"github.com/go-chi/chi/v5"
@@ -27,12 +27,12 @@ type V1CompleteMultipartResponse struct {
 func (h *HandlerV1) CompleteMultipartV1(w http.ResponseWriter, r *http.Request) {
 	sessionID := chi.URLParam(r, "sessionID")
 	if sessionID == "" {
-		http.Error(w, "Session ID is required", http.StatusBadRequest)
+		problem.BadRequest(w, r, "session id is required")
 		return
 	}
 	uuidSession, parseErr := uuid.Parse(sessionID)
 	if parseErr != nil {
-		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, parseErr.Error())
 		return
 	}
 
@@ -40,12 +40,12 @@ func (h *HandlerV1) CompleteMultipartV1(w http.ResponseWriter, r *http.Request)
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.logger.Error("error decoding complete multipart request", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, err.Error())
 		return
 	}
 
 	if req.Parts == nil || len(req.Parts) == 0 {
-		http.Error(w, "Request contains no parts", http.StatusBadRequest)
+		problem.BadRequest(w, r, "request contains no parts")
 		return
 	}
 
@@ -60,22 +60,12 @@ func (h *HandlerV1) CompleteMultipartV1(w http.ResponseWriter, r *http.Request)
 
 	fileID, err := h.fileService.CompleteMultipartUpload(r.Context(), uuidSession, domainParts)
 	switch {
-	case errors.Is(err, domain.ErrSessionNotFound):
-		http.Error(w, "Session not found", http.StatusForbidden)
-		return
-	case errors.Is(err, domain.ErrMismatchETag), errors.Is(err, domain.ErrMismatchNBParts), errors.Is(err, domain.ErrDuplicatePart):
-		http.Error(w, "invalid part", http.StatusBadRequest)
-		return
-	case errors.Is(err, domain.ErrFileMetadataNotFound):
-		http.Error(w, "file metadata not found", http.StatusNotFound)
-		return
 	case err != nil:
-		h.logger.Error("error completing multipart upload", "error", err)
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, err)
 		return
 	case fileID == nil:
 		h.logger.Error("file id is nil", "error", err)
-		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		problem.Write(w, r, http.StatusServiceUnavailable, problem.CodeServiceUnavailable, "")
 		return
 	default:
 		resp := V1CompleteMultipartResponse{
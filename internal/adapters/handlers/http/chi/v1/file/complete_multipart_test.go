@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"score-play/internal/adapters/handlers/http/chi"
 	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/service/file"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCompleteMultipartV1(t *testing.T) {
@@ -33,7 +35,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&expectedFileID, nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -70,7 +72,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, domain.ErrSessionNotFound)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -80,12 +82,18 @@ func TestCompleteMultipartV1(t *testing.T) {
 		}
 		jsonBody, _ := json.Marshal(requestBody)
 		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart/"+sessionID.String()+"/complete", bytes.NewReader(jsonBody))
+		req.Header.Set("X-Request-ID", "req-1")
 
 		// Act
 		h.ServeHTTP(w, req)
 
 		// Assert
-		assert.Equal(t, http2.StatusForbidden, w.Code)
+		require.Equal(t, http2.StatusNotFound, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		var body problem.Problem
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, problem.CodeSessionNotFound, body.Code)
+		assert.Equal(t, "req-1", body.RequestID)
 		mockService.AssertExpectations(t)
 	})
 
@@ -99,7 +107,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, domain.ErrMismatchETag)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -128,7 +136,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, domain.ErrMismatchNBParts)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -157,7 +165,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, domain.ErrFileMetadataNotFound)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -180,7 +188,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -204,7 +212,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -226,7 +234,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart/"+sessionID.String()+"/complete", bytes.NewReader([]byte("invalid json")))
@@ -248,7 +256,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, errors.New("internal error"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -277,7 +285,7 @@ func TestCompleteMultipartV1(t *testing.T) {
 			Return((*uuid.UUID)(nil), nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1CompleteMultipartRequest{
@@ -0,0 +1,87 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// V1CopyFileRequest is the request to copy a file. The filename defaults to the source filename.
+// A clip copies only length bytes of the source starting at offset.
+type V1CopyFileRequest struct {
+	FileName string          `json:"filename"`
+	Tags     []string        `json:"tags"`
+	Clip     *V1CopyFileClip `json:"clip,omitempty"`
+}
+
+// V1CopyFileClip is the byte range of the source copied by a clip export
+type V1CopyFileClip struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// V1CopyFileResponse is the response to copy a file
+type V1CopyFileResponse struct {
+	FileID       uuid.UUID `json:"file_id"`
+	SourceFileID uuid.UUID `json:"source_file_id"`
+}
+
+// CopyFileV1 is the function that handles CopyFile
+func (h *HandlerV1) CopyFileV1(w http.ResponseWriter, r *http.Request) {
+
+	sourceID, parseErr := uuid.Parse(chi.URLParam(r, "fileID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	var req V1CopyFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("error decoding copy file request", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	if len(req.Tags) == 0 {
+		problem.BadRequest(w, r, "provide at least one tag")
+		return
+	}
+
+	var clip *domain.Clip
+	if req.Clip != nil {
+		clip = &domain.Clip{Offset: req.Clip.Offset, Length: req.Clip.Length}
+	}
+
+	fileID, err := h.fileService.CopyFile(r.Context(), sourceID, req.FileName, req.Tags, clip)
+	switch {
+	case errors.Is(err, domain.ErrFileRestoring):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(V1GetFileRestoringResponse{Status: "restoring"}); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	case fileID == nil:
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, "file id is nil")
+		return
+	default:
+		resp := V1CopyFileResponse{
+			FileID:       *fileID,
+			SourceFileID: sourceID,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
@@ -0,0 +1,149 @@
+package file_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFileV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("created - copy with new tags", func(t *testing.T) {
+		// Arrange
+		sourceID := uuid.New()
+		copyID := uuid.New()
+
+		mockService := file.NewMockFileService()
+		mockService.On("CopyFile", mock.Anything, sourceID, "clip.mp4", []string{"highlights"}, (*domain.Clip)(nil)).Return(&copyID, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"clip.mp4","tags":["highlights"]}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+sourceID.String()+"/copy", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		var response file3.V1CopyFileResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, copyID, response.FileID)
+		assert.Equal(t, sourceID, response.SourceFileID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("created - clip export", func(t *testing.T) {
+		// Arrange
+		sourceID := uuid.New()
+		copyID := uuid.New()
+
+		mockService := file.NewMockFileService()
+		mockService.On("CopyFile", mock.Anything, sourceID, "", []string{"highlights"}, &domain.Clip{Offset: 1024, Length: 2048}).Return(&copyID, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"tags":["highlights"],"clip":{"offset":1024,"length":2048}}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+sourceID.String()+"/copy", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("bad request - clip out of the source", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("CopyFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*uuid.UUID)(nil), domain.ErrInvalidClip)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"tags":["highlights"],"clip":{"offset":0,"length":-1}}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/copy", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+	})
+
+	t.Run("bad request - no tags", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/copy", strings.NewReader(`{"filename":"clip.mp4"}`))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CopyFile")
+	})
+
+	t.Run("not found - unknown source", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("CopyFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*uuid.UUID)(nil), domain.ErrFileMetadataNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/copy", strings.NewReader(`{"tags":["football"]}`))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+	})
+
+	t.Run("conflict - source not ready", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("CopyFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*uuid.UUID)(nil), domain.ErrFileNotReady)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/copy", strings.NewReader(`{"tags":["football"]}`))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusConflict, w.Code)
+	})
+}
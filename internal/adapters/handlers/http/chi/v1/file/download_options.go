@@ -0,0 +1,51 @@
+package file
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"score-play/internal/core/domain"
+	"strconv"
+	"time"
+)
+
+var byteRangeRegexp = regexp.MustCompile(`^(\d+)-(\d*)$`)
+
+// parseDownloadOptions reads the disposition, ttl and range query parameters of a download request
+func parseDownloadOptions(r *http.Request) (domain.DownloadOptions, error) {
+	query := r.URL.Query()
+	opts := domain.DownloadOptions{Disposition: domain.DispositionInline}
+
+	switch disposition := query.Get("disposition"); disposition {
+	case "":
+	case domain.DispositionInline, domain.DispositionAttachment:
+		opts.Disposition = disposition
+	default:
+		return opts, errors.New("disposition must be inline or attachment")
+	}
+
+	if ttl := query.Get("ttl"); ttl != "" {
+		seconds, err := strconv.Atoi(ttl)
+		if err != nil || seconds < 1 {
+			return opts, errors.New("ttl must be a positive number of seconds")
+		}
+		opts.TTL = time.Duration(seconds) * time.Second
+	}
+
+	if byteRange := query.Get("range"); byteRange != "" {
+		matches := byteRangeRegexp.FindStringSubmatch(byteRange)
+		if matches == nil {
+			return opts, errors.New("range must be of the form start-end or start-")
+		}
+		if matches[2] != "" {
+			start, startErr := strconv.ParseInt(matches[1], 10, 64)
+			end, endErr := strconv.ParseInt(matches[2], 10, 64)
+			if startErr != nil || endErr != nil || end < start {
+				return opts, errors.New("range end must not be before range start")
+			}
+		}
+		opts.Range = "bytes=" + byteRange
+	}
+
+	return opts, nil
+}
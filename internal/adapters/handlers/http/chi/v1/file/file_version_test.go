@@ -0,0 +1,260 @@
+package file_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFileVersionV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("created - simple upload", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		version := &domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2}
+		presignedURL := "https://example.com/upload"
+		expiresAt := time.Now().Add(15 * time.Minute)
+
+		mockService := file.NewMockFileService()
+		mockService.On("RequestUploadFileVersion", mock.Anything, fileID, "video.mp4", "video/mp4", int64(1000), "sha").
+			Return(version, &presignedURL, map[string]string{"Content-Type": "video/mp4"}, &expiresAt, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"video.mp4","content_type":"video/mp4","size_bytes":1000,"checksum_sha256":"sha"}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+fileID.String()+"/versions", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		var response file3.V1UploadFileVersionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, version.ID, response.VersionID)
+		assert.Equal(t, 2, response.Version)
+		assert.Equal(t, presignedURL, response.PresignedURL)
+		assert.Nil(t, response.SessionID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("created - multipart upload", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		sessionID := uuid.New()
+		version := &domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 3}
+
+		mockService := file.NewMockFileService()
+		mockService.On("RequestUploadMultipartFileVersion", mock.Anything, fileID, "video.mp4", "video/mp4", int64(50000), "sha").
+			Return(version, &sessionID, 5000, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"video.mp4","content_type":"video/mp4","size_bytes":50000,"checksum_sha256":"sha","multipart":true}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+fileID.String()+"/versions", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		var response file3.V1UploadFileVersionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, sessionID, *response.SessionID)
+		assert.Equal(t, 5000, response.PartSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found - unknown file", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("RequestUploadFileVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*domain.FileVersion)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrFileMetadataNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"video.mp4","content_type":"video/mp4","size_bytes":1000,"checksum_sha256":"sha"}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/versions", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+	})
+
+	t.Run("bad request - type mismatch", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("RequestUploadFileVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*domain.FileVersion)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrFileTypeMismatch)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"photo.jpg","content_type":"image/jpeg","size_bytes":1000,"checksum_sha256":"sha"}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/versions", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+	})
+
+	t.Run("bad request - missing param", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/"+uuid.NewString()+"/versions", strings.NewReader(`{"filename":"video.mp4"}`))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RequestUploadFileVersion")
+	})
+}
+
+func TestListFileVersionsV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("success - newest first", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		versions := []domain.FileVersion{
+			{ID: uuid.New(), FileID: fileID, Version: 2, Filename: "new.mp4", Status: domain.FileStatusUploading},
+			{ID: fileID, FileID: fileID, Version: 1, Filename: "old.mp4", Status: domain.FileStatusCompleted},
+		}
+
+		mockService := file.NewMockFileService()
+		mockService.On("ListFileVersions", mock.Anything, fileID).Return(versions, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/versions", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		var response file3.V1ListFileVersionsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Versions, 2)
+		assert.Equal(t, 2, response.Versions[0].Version)
+		assert.Equal(t, "uploading", response.Versions[0].Status)
+	})
+
+	t.Run("not found - unknown file", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("ListFileVersions", mock.Anything, mock.Anything).Return([]domain.FileVersion(nil), domain.ErrFileMetadataNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+uuid.NewString()+"/versions", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetFileVersionV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		url := "https://example.com/old.mp4"
+		filename := "old.mp4"
+		expiresAt := time.Now().Add(15 * time.Minute)
+
+		mockService := file.NewMockFileService()
+		mockService.On("GetFileVersion", mock.Anything, fileID, 1, mock.Anything).Return(&url, &filename, map[string]string(nil), &expiresAt, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/versions/1", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		var response file3.V1GetFileVersionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, url, response.URL)
+		assert.Equal(t, 1, response.Version)
+	})
+
+	t.Run("not found - unknown version", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("GetFileVersion", mock.Anything, mock.Anything, 9, mock.Anything).
+			Return((*string)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrFileVersionNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+uuid.NewString()+"/versions/9", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+	})
+
+	t.Run("bad request - invalid version", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+uuid.NewString()+"/versions/latest", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 	"time"
 
@@ -13,10 +14,17 @@ import (
 
 // V1GetFileResponse is the response to get file
 type V1GetFileResponse struct {
-	Filename  string    `json:"filename"`
-	URL       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
-	Tags      []string  `json:"tags"`
+	Filename  string            `json:"filename"`
+	URL       string            `json:"url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Tags      []string          `json:"tags"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Checksum  string            `json:"checksum_sha256,omitempty"`
+}
+
+// V1GetFileRestoringResponse is the response to get file when the file is being restored from archive
+type V1GetFileRestoringResponse struct {
+	Status string `json:"status"`
 }
 
 // GetFileV1 is the function that handles GetFile
@@ -24,30 +32,36 @@ func (h *HandlerV1) GetFileV1(w http.ResponseWriter, r *http.Request) {
 
 	fileID := chi.URLParam(r, "fileID")
 	if fileID == "" {
-		http.Error(w, "file id is required", http.StatusBadRequest)
+		problem.BadRequest(w, r, "file id is required")
 		return
 	}
 	uuidFileID, parseErr := uuid.Parse(fileID)
 	if parseErr != nil {
-		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, parseErr.Error())
 		return
 	}
 
-	url, filename, tags, expiresAt, err := h.fileService.GetFile(r.Context(), uuidFileID)
-	switch {
-	case errors.Is(err, domain.ErrFileNotReady):
-		http.Error(w, "file not ready", http.StatusConflict)
+	opts, optsErr := parseDownloadOptions(r)
+	if optsErr != nil {
+		problem.BadRequest(w, r, optsErr.Error())
 		return
-	case errors.Is(err, domain.ErrFileUploadFailed):
-		http.Error(w, "file upload failed", http.StatusConflict)
+	}
+
+	url, filename, tags, headers, expiresAt, checksum, err := h.fileService.GetFile(r.Context(), uuidFileID, opts)
+	switch {
+	case errors.Is(err, domain.ErrFileRestoring):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(V1GetFileRestoringResponse{Status: "restoring"}); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
 		return
 	case err != nil:
-		h.logger.Error("error getting file", "error", err)
-		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, err)
 		return
 	case url == nil || filename == nil || tags == nil || expiresAt == nil:
 		h.logger.Error("response has nil values", "url", url, "filename", filename, "tags", tags)
-		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		problem.Write(w, r, http.StatusServiceUnavailable, problem.CodeServiceUnavailable, "")
 		return
 	default:
 		var respTags []string
@@ -59,6 +73,8 @@ func (h *HandlerV1) GetFileV1(w http.ResponseWriter, r *http.Request) {
 			URL:       *url,
 			ExpiresAt: *expiresAt,
 			Tags:      respTags,
+			Headers:   headers,
+			Checksum:  checksum,
 		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
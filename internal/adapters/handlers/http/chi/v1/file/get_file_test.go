@@ -35,11 +35,11 @@ func TestGetFileV1(t *testing.T) {
 		}
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return(&expectedURL, &expectedFilename, expectedTags, &expectedExpiresAt, nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return(&expectedURL, &expectedFilename, expectedTags, map[string]string(nil), &expectedExpiresAt, "checksum", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -60,6 +60,7 @@ func TestGetFileV1(t *testing.T) {
 		assert.Len(t, response.Tags, 2)
 		assert.Contains(t, response.Tags, "football")
 		assert.Contains(t, response.Tags, "highlights")
+		assert.Equal(t, "checksum", response.Checksum)
 
 		mockService.AssertExpectations(t)
 	})
@@ -69,11 +70,11 @@ func TestGetFileV1(t *testing.T) {
 		fileID := uuid.New()
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, mock.Anything).
-			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), (*time.Time)(nil), domain.ErrFileNotReady)
+		mockService.On("GetFile", mock.Anything, mock.Anything, mock.Anything).
+			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrFileNotReady)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -86,16 +87,38 @@ func TestGetFileV1(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("error - not the owner", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+
+		mockService := file.NewMockFileService()
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrForbidden)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("error - file upload failed", func(t *testing.T) {
 		// Arrange
 		fileID := uuid.New()
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), (*time.Time)(nil), domain.ErrFileUploadFailed)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrFileUploadFailed)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -108,11 +131,38 @@ func TestGetFileV1(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("accepted - file restoring", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+
+		mockService := file.NewMockFileService()
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrFileRestoring)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusAccepted, w.Code)
+
+		var response file3.V1GetFileRestoringResponse
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "restoring", response.Status)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("error - invalid file ID format", func(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/invalid-uuid/", nil)
@@ -128,7 +178,7 @@ func TestGetFileV1(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file//", nil)
@@ -145,11 +195,11 @@ func TestGetFileV1(t *testing.T) {
 		fileID := uuid.New()
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), (*time.Time)(nil), errors.New("database connection lost"))
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", errors.New("database connection lost"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -170,11 +220,11 @@ func TestGetFileV1(t *testing.T) {
 		expectedTags := []domain.Tag{}
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return((*string)(nil), &expectedFilename, expectedTags, &expectedExpiresAt, nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return((*string)(nil), &expectedFilename, expectedTags, map[string]string(nil), &expectedExpiresAt, "", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -195,11 +245,11 @@ func TestGetFileV1(t *testing.T) {
 		expectedTags := []domain.Tag{}
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return(&expectedURL, (*string)(nil), expectedTags, &expectedExpiresAt, nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return(&expectedURL, (*string)(nil), expectedTags, map[string]string(nil), &expectedExpiresAt, "", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -220,11 +270,11 @@ func TestGetFileV1(t *testing.T) {
 		expectedExpiresAt := time.Now().Add(15 * time.Minute)
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return(&expectedURL, &expectedFilename, []domain.Tag(nil), &expectedExpiresAt, nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return(&expectedURL, &expectedFilename, []domain.Tag(nil), map[string]string(nil), &expectedExpiresAt, "", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -245,11 +295,11 @@ func TestGetFileV1(t *testing.T) {
 		expectedTags := []domain.Tag{}
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return(&expectedURL, &expectedFilename, expectedTags, (*time.Time)(nil), nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return(&expectedURL, &expectedFilename, expectedTags, map[string]string(nil), (*time.Time)(nil), "", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -277,11 +327,11 @@ func TestGetFileV1(t *testing.T) {
 		}
 
 		mockService := file.NewMockFileService()
-		mockService.On("GetFile", mock.Anything, fileID).
-			Return(&expectedURL, &expectedFilename, expectedTags, &expectedExpiresAt, nil)
+		mockService.On("GetFile", mock.Anything, fileID, mock.Anything).
+			Return(&expectedURL, &expectedFilename, expectedTags, map[string]string(nil), &expectedExpiresAt, "", nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/", nil)
@@ -304,4 +354,60 @@ func TestGetFileV1(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("success - download options are passed to the service", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		expectedURL := "https://example.com/file.mp4"
+		expectedFilename := "video.mp4"
+		expectedExpiresAt := time.Now().Add(5 * time.Minute)
+		expectedHeaders := map[string]string{"Range": "bytes=0-1023"}
+		expectedOpts := domain.DownloadOptions{
+			Disposition: domain.DispositionAttachment,
+			TTL:         300 * time.Second,
+			Range:       "bytes=0-1023",
+		}
+
+		mockService := file.NewMockFileService()
+		mockService.On("GetFile", mock.Anything, fileID, expectedOpts).
+			Return(&expectedURL, &expectedFilename, []domain.Tag{}, expectedHeaders, &expectedExpiresAt, "", nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileID.String()+"/?disposition=attachment&ttl=300&range=0-1023", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+
+		var response file3.V1GetFileResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, expectedHeaders, response.Headers)
+
+		mockService.AssertExpectations(t)
+	})
+
+	for _, query := range []string{"disposition=download", "ttl=0", "ttl=abc", "range=10-5", "range=-100", "range=bytes"} {
+		t.Run("error - invalid download option "+query, func(t *testing.T) {
+			// Arrange
+			mockService := file.NewMockFileService()
+
+			handler := file3.NewFileHandlerV1(mockService, discardLogger)
+			h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+uuid.NewString()+"/?"+query, nil)
+
+			// Act
+			h.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http2.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "GetFile")
+		})
+	}
 }
@@ -0,0 +1,76 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// V1GetFileVersionResponse is the response to get a file version
+type V1GetFileVersionResponse struct {
+	Version   int               `json:"version"`
+	Filename  string            `json:"filename"`
+	URL       string            `json:"url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// GetFileVersionV1 is the function that handles GetFileVersion
+func (h *HandlerV1) GetFileVersionV1(w http.ResponseWriter, r *http.Request) {
+
+	uuidFileID, parseErr := uuid.Parse(chi.URLParam(r, "fileID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+	version, convErr := strconv.Atoi(chi.URLParam(r, "version"))
+	if convErr != nil || version < 1 {
+		problem.BadRequest(w, r, "version must be a positive integer")
+		return
+	}
+
+	opts, optsErr := parseDownloadOptions(r)
+	if optsErr != nil {
+		problem.BadRequest(w, r, optsErr.Error())
+		return
+	}
+
+	url, filename, headers, expiresAt, err := h.fileService.GetFileVersion(r.Context(), uuidFileID, version, opts)
+	switch {
+	case errors.Is(err, domain.ErrFileRestoring):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(V1GetFileRestoringResponse{Status: "restoring"}); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	case url == nil || filename == nil || expiresAt == nil:
+		h.logger.Error("response has nil values", "url", url, "filename", filename)
+		problem.Write(w, r, http.StatusServiceUnavailable, problem.CodeServiceUnavailable, "")
+		return
+	default:
+		resp := V1GetFileVersionResponse{
+			Version:   version,
+			Filename:  *filename,
+			URL:       *url,
+			ExpiresAt: *expiresAt,
+			Headers:   headers,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
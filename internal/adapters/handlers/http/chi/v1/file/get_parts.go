@@ -2,9 +2,8 @@ package file
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"score-play/internal/core/domain"
+	"score-play/internal/adapters/handlers/http/problem"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -25,12 +24,12 @@ func (h *HandlerV1) GetPartsV1(w http.ResponseWriter, r *http.Request) {
 
 	sessionID := chi.URLParam(r, "sessionID")
 	if sessionID == "" {
-		http.Error(w, "Session ID is required", http.StatusBadRequest)
+		problem.BadRequest(w, r, "session id is required")
 		return
 	}
 	uuidSession, parseErr := uuid.Parse(sessionID)
 	if parseErr != nil {
-		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, parseErr.Error())
 		return
 	}
 
@@ -39,32 +38,27 @@ func (h *HandlerV1) GetPartsV1(w http.ResponseWriter, r *http.Request) {
 
 	nbParts, err := strconv.Atoi(nbPartsStr)
 	if err != nil {
-		http.Error(w, "nb_parts must be an integer", http.StatusBadRequest)
+		problem.BadRequest(w, r, "nb_parts must be an integer")
 		return
 	}
 	if nbParts <= 0 {
-		http.Error(w, "nb_parts must be a positive integer", http.StatusBadRequest)
+		problem.BadRequest(w, r, "nb_parts must be a positive integer")
+		return
 	}
 
 	marker := 0
 	if markerStr != "" {
 		marker, err = strconv.Atoi(markerStr)
 		if err != nil {
-			http.Error(w, "marker must be an integer", http.StatusBadRequest)
+			problem.BadRequest(w, r, "marker must be an integer")
+			return
 		}
 	}
 
 	parts, marker, reqErr := h.fileService.ListParts(r.Context(), uuidSession, nbParts, marker)
 	switch {
-	case errors.Is(reqErr, domain.ErrSessionNotFound):
-		http.Error(w, "Session not found", http.StatusForbidden)
-		return
-	case errors.Is(reqErr, domain.ErrFileMetadataNotFound):
-		http.Error(w, "File metadata not found", http.StatusNotFound)
-		return
 	case reqErr != nil:
-		h.logger.Error("error listing parts", "error", reqErr)
-		http.Error(w, reqErr.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, h.logger, reqErr)
 		return
 	default:
 
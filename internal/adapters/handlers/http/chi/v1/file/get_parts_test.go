@@ -39,7 +39,7 @@ func TestGetPartsV1(t *testing.T) {
 			Return(mockParts, nextMarker, nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=10&marker=10"
@@ -77,7 +77,7 @@ func TestGetPartsV1(t *testing.T) {
 			Return(mockParts, nextMarker, nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=10"
@@ -106,7 +106,7 @@ func TestGetPartsV1(t *testing.T) {
 			Return([]domain.UploadPart{}, 0, domain.ErrSessionNotFound)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=5"
@@ -116,7 +116,7 @@ func TestGetPartsV1(t *testing.T) {
 		h.ServeHTTP(w, req)
 
 		// Assert
-		assert.Equal(t, http2.StatusForbidden, w.Code)
+		assert.Equal(t, http2.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
 
@@ -124,7 +124,7 @@ func TestGetPartsV1(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/not-a-uuid/parts"
@@ -145,7 +145,7 @@ func TestGetPartsV1(t *testing.T) {
 			Return([]domain.UploadPart{}, 0, domain.ErrFileMetadataNotFound)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=5"
@@ -166,7 +166,7 @@ func TestGetPartsV1(t *testing.T) {
 			Return([]domain.UploadPart{}, 0, errors.New("unexpected error"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		route := "/api/v1/file/upload/multipart/" + sessionID.String() + "/parts?nb_parts=5"
@@ -176,6 +176,6 @@ func TestGetPartsV1(t *testing.T) {
 		h.ServeHTTP(w, req)
 
 		// Assert
-		assert.Equal(t, http2.StatusInternalServerError, w.Code)
+		assert.Equal(t, http2.StatusServiceUnavailable, w.Code)
 	})
 }
@@ -0,0 +1,60 @@
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+)
+
+// V1Usage is the storage used by a tenant or a user. Zero limits are unlimited.
+type V1Usage struct {
+	BytesStored   int64 `json:"bytes_stored"`
+	BytesReserved int64 `json:"bytes_reserved"`
+	FileCount     int64 `json:"file_count"`
+	MaxBytes      int64 `json:"max_bytes"`
+	MaxFiles      int64 `json:"max_files"`
+}
+
+// V1GetUsageResponse is the response to get usage. User is only set for authenticated requests.
+type V1GetUsageResponse struct {
+	Tenant string   `json:"tenant"`
+	Usage  V1Usage  `json:"usage"`
+	User   *V1Usage `json:"user,omitempty"`
+}
+
+func toV1Usage(usage *domain.Usage) V1Usage {
+	return V1Usage{
+		BytesStored:   usage.BytesStored,
+		BytesReserved: usage.BytesReserved,
+		FileCount:     usage.FileCount,
+		MaxBytes:      usage.MaxBytes,
+		MaxFiles:      usage.MaxFiles,
+	}
+}
+
+// GetUsageV1 is the function that handles GetUsage
+func (h *HandlerV1) GetUsageV1(w http.ResponseWriter, r *http.Request) {
+
+	tenant, user, err := h.fileService.GetUsage(r.Context())
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		resp := V1GetUsageResponse{
+			Tenant: domain.TenantOrDefault(r.Context()),
+			Usage:  toV1Usage(tenant),
+		}
+		if user != nil {
+			userUsage := toV1Usage(user)
+			resp.User = &userUsage
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
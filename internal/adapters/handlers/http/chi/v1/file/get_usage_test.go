@@ -0,0 +1,108 @@
+package file_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUsageV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("GetUsage", mock.Anything).Return(
+			&domain.Usage{BytesStored: 100, BytesReserved: 50, FileCount: 2, MaxBytes: 1000},
+			&domain.Usage{BytesStored: 10, FileCount: 1, MaxFiles: 5},
+			nil,
+		)
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/usage", nil)
+		req.Header.Set(chi.TenantHeader, "acme")
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http2.StatusOK, w.Code)
+		var resp file3.V1GetUsageResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "acme", resp.Tenant)
+		assert.Equal(t, file3.V1Usage{BytesStored: 100, BytesReserved: 50, FileCount: 2, MaxBytes: 1000}, resp.Usage)
+		require.NotNil(t, resp.User)
+		assert.Equal(t, int64(5), resp.User.MaxFiles)
+	})
+
+	t.Run("error - service unavailable", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("GetUsage", mock.Anything).Return((*domain.Usage)(nil), (*domain.Usage)(nil), fmt.Errorf("db down"))
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ServeHTTP(w, httptest.NewRequest(http2.MethodGet, "/api/v1/usage", nil))
+
+		// Assert
+		assert.Equal(t, http2.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestUploadFileV1_QuotaExceeded(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"storage quota", fmt.Errorf("could not generate simple upload presigned url: %w", domain.ErrQuotaExceeded), http2.StatusRequestEntityTooLarge},
+		{"file count quota", fmt.Errorf("could not generate simple upload presigned url: %w", domain.ErrFileCountQuotaExceeded), http2.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := file.NewMockFileService()
+			mockService.On("RequestUploadFile", mock.Anything, "test.png", "image/png", int64(1024), "sum", []string{"football"}).
+				Return((*uuid.UUID)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), tt.err)
+			handler := file3.NewFileHandlerV1(mockService, discardLogger)
+			h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+			w := httptest.NewRecorder()
+
+			jsonBody, err := json.Marshal(file3.V1UploadFileRequest{
+				FileName:       "test.png",
+				ContentType:    "image/png",
+				SizeBytes:      1024,
+				ChecksumSha256: "sum",
+				Tags:           []string{"football"},
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload", bytes.NewReader(jsonBody))
+
+			// Act
+			h.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -30,7 +30,14 @@ func (h *HandlerV1) Routes() chi.Router {
 	router.Post("/upload/multipart/{sessionID}/parts", h.RetrievePresignedPartsV1)
 	router.Get("/upload/multipart/{sessionID}/parts", h.GetPartsV1)
 	router.Post("/upload/multipart/{sessionID}/complete", h.CompleteMultipartV1)
+	router.Post("/import", h.ImportFileV1)
+	router.Get("/{fileID}", h.GetFileV1)
 	router.Get("/{fileID}/", h.GetFileV1)
+	router.Post("/{fileID}/versions", h.UploadFileVersionV1)
+	router.Get("/{fileID}/versions", h.ListFileVersionsV1)
+	router.Get("/{fileID}/versions/{version}", h.GetFileVersionV1)
+	router.Post("/{fileID}/copy", h.CopyFileV1)
+	router.Get("/{fileID}/import", h.GetFileImportV1)
 
 	return router
 }
@@ -0,0 +1,114 @@
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// V1ImportFileRequest is the request to import a file from a remote url. The checksum is optional.
+type V1ImportFileRequest struct {
+	SourceURL      string   `json:"source_url"`
+	FileName       string   `json:"filename"`
+	ContentType    string   `json:"content_type"`
+	SizeBytes      int64    `json:"size_bytes"`
+	ChecksumSha256 string   `json:"checksum_sha256"`
+	Tags           []string `json:"tags"`
+}
+
+// V1ImportFileResponse is the response to import a file, its progress is at /file/{fileID}/import
+type V1ImportFileResponse struct {
+	FileID uuid.UUID           `json:"file_id"`
+	Status domain.ImportStatus `json:"status"`
+}
+
+// V1GetFileImportResponse is the progress of the import of a file
+type V1GetFileImportResponse struct {
+	FileID        uuid.UUID           `json:"file_id"`
+	SourceURL     string              `json:"source_url"`
+	Status        domain.ImportStatus `json:"status"`
+	FileStatus    domain.FileStatus   `json:"file_status"`
+	SizeBytes     int64               `json:"size_bytes"`
+	BytesReceived int64               `json:"bytes_received"`
+	Attempts      int                 `json:"attempts"`
+	Error         string              `json:"error,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// ImportFileV1 is the function that handles ImportFile, the worker fetches the file asynchronously
+func (h *HandlerV1) ImportFileV1(w http.ResponseWriter, r *http.Request) {
+	var req V1ImportFileRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("error decoding import file request", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	if req.SourceURL == "" || req.FileName == "" || req.ContentType == "" || req.SizeBytes == 0 {
+		problem.BadRequest(w, r, "missing param")
+		return
+	}
+
+	if len(req.Tags) == 0 {
+		problem.BadRequest(w, r, "provide at least one tag")
+		return
+	}
+
+	fileID, err := h.fileService.RequestImportFile(r.Context(), req.SourceURL, req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	case fileID == nil:
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, "file id is nil")
+		return
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(V1ImportFileResponse{FileID: *fileID, Status: domain.ImportStatusPending}); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
+
+// GetFileImportV1 is the function that handles GetFileImport
+func (h *HandlerV1) GetFileImportV1(w http.ResponseWriter, r *http.Request) {
+
+	fileID, parseErr := uuid.Parse(chi.URLParam(r, "fileID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	fileImport, fileStatus, err := h.fileService.GetFileImport(r.Context(), fileID)
+	if err != nil {
+		problem.Error(w, r, h.logger, err)
+		return
+	}
+
+	resp := V1GetFileImportResponse{
+		FileID:        fileImport.FileID,
+		SourceURL:     fileImport.SourceURL,
+		Status:        fileImport.Status,
+		FileStatus:    fileStatus,
+		SizeBytes:     fileImport.SizeBytes,
+		BytesReceived: fileImport.BytesReceived,
+		Attempts:      fileImport.Attempts,
+		Error:         fileImport.Error,
+		CreatedAt:     fileImport.CreatedAt,
+		UpdatedAt:     fileImport.UpdatedAt,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("error encoding response", "error", err)
+	}
+}
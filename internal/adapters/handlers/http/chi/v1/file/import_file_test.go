@@ -0,0 +1,145 @@
+package file_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportFileV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sourceURL := "https://media.example.com/match.mp4"
+
+	t.Run("accepted - import pending", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+		mockService := file.NewMockFileService()
+		mockService.On("RequestImportFile", mock.Anything, sourceURL, "match.mp4", "video/mp4", int64(5000), "", []string{"football"}).
+			Return(&fileID, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"source_url":"` + sourceURL + `","filename":"match.mp4","content_type":"video/mp4","size_bytes":5000,"tags":["football"]}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/import", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusAccepted, w.Code)
+		var response file3.V1ImportFileResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, fileID, response.FileID)
+		assert.Equal(t, domain.ImportStatusPending, response.Status)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("bad request - missing source", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"filename":"match.mp4","content_type":"video/mp4","size_bytes":5000,"tags":["football"]}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/import", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RequestImportFile")
+	})
+
+	t.Run("bad request - source not allowed", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("RequestImportFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return((*uuid.UUID)(nil), domain.ErrSourceNotAllowed)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		body := `{"source_url":"http://169.254.169.254/latest","filename":"match.mp4","content_type":"video/mp4","size_bytes":5000,"tags":["football"]}`
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/import", strings.NewReader(body))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		var response problem.Problem
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, problem.CodeSourceNotAllowed, response.Code)
+	})
+}
+
+func TestGetFileImportV1(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("ok - import running", func(t *testing.T) {
+		// Arrange
+		fileImport := &domain.FileImport{
+			FileID:        uuid.New(),
+			SourceURL:     "https://media.example.com/match.mp4",
+			Status:        domain.ImportStatusRunning,
+			SizeBytes:     5000,
+			BytesReceived: 2000,
+			Attempts:      1,
+		}
+		mockService := file.NewMockFileService()
+		mockService.On("GetFileImport", mock.Anything, fileImport.FileID).Return(fileImport, domain.FileStatusUploading, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+fileImport.FileID.String()+"/import", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		var response file3.V1GetFileImportResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, domain.ImportStatusRunning, response.Status)
+		assert.Equal(t, domain.FileStatusUploading, response.FileStatus)
+		assert.Equal(t, int64(2000), response.BytesReceived)
+		assert.Equal(t, int64(5000), response.SizeBytes)
+	})
+
+	t.Run("not found - not imported", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("GetFileImport", mock.Anything, mock.Anything).Return((*domain.FileImport)(nil), domain.FileStatus(""), domain.ErrImportNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http2.MethodGet, "/api/v1/file/"+uuid.NewString()+"/import", nil)
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// V1FileVersion is a file version
+type V1FileVersion struct {
+	ID             uuid.UUID `json:"id"`
+	Version        int       `json:"version"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"content_type"`
+	SizeBytes      int64     `json:"size_bytes"`
+	ChecksumSha256 string    `json:"checksum_sha256"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// V1ListFileVersionsResponse is the response to list file versions
+type V1ListFileVersionsResponse struct {
+	Versions []V1FileVersion `json:"versions"`
+}
+
+// ListFileVersionsV1 is the function that handles ListFileVersions
+func (h *HandlerV1) ListFileVersionsV1(w http.ResponseWriter, r *http.Request) {
+
+	uuidFileID, parseErr := uuid.Parse(chi.URLParam(r, "fileID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	versions, err := h.fileService.ListFileVersions(r.Context(), uuidFileID)
+	switch {
+	case err != nil:
+		problem.Error(w, r, h.logger, err)
+		return
+	default:
+		resp := V1ListFileVersionsResponse{Versions: make([]V1FileVersion, 0, len(versions))}
+		for _, version := range versions {
+			resp.Versions = append(resp.Versions, V1FileVersion{
+				ID:             version.ID,
+				Version:        version.Version,
+				Filename:       version.Filename,
+				ContentType:    version.MimeType,
+				SizeBytes:      version.SizeBytes,
+				ChecksumSha256: version.Checksum,
+				Status:         string(version.Status),
+				CreatedAt:      version.CreatedAt,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
@@ -0,0 +1,91 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// V1UploadFileVersionRequest is the request to upload a new version of a file
+type V1UploadFileVersionRequest struct {
+	FileName       string `json:"filename"`
+	ContentType    string `json:"content_type"`
+	SizeBytes      int64  `json:"size_bytes"`
+	ChecksumSha256 string `json:"checksum_sha256"`
+	Multipart      bool   `json:"multipart"`
+}
+
+// V1UploadFileVersionResponse is the response to upload a new version of a file.
+// Simple uploads get a presigned url, multipart uploads get a session to use with the multipart endpoints.
+type V1UploadFileVersionResponse struct {
+	VersionID    uuid.UUID         `json:"version_id"`
+	Version      int               `json:"version"`
+	PresignedURL string            `json:"presigned_url,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	ExpiresAt    *time.Time        `json:"expires_at,omitempty"`
+	SessionID    *uuid.UUID        `json:"session_id,omitempty"`
+	PartSize     int               `json:"part_size,omitempty"`
+}
+
+// UploadFileVersionV1 is the function that handles a new file version upload
+func (h *HandlerV1) UploadFileVersionV1(w http.ResponseWriter, r *http.Request) {
+
+	uuidFileID, parseErr := uuid.Parse(chi.URLParam(r, "fileID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	var req V1UploadFileVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("error decoding upload file version request", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	if req.FileName == "" || req.ContentType == "" || req.SizeBytes == 0 || req.ChecksumSha256 == "" {
+		problem.BadRequest(w, r, "missing param")
+		return
+	}
+
+	var resp V1UploadFileVersionResponse
+	var version *domain.FileVersion
+	var requestErr error
+
+	if req.Multipart {
+		version, resp.SessionID, resp.PartSize, requestErr = h.fileService.RequestUploadMultipartFileVersion(r.Context(), uuidFileID, req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256)
+	} else {
+		var presignedURL *string
+		version, presignedURL, resp.Headers, resp.ExpiresAt, requestErr = h.fileService.RequestUploadFileVersion(r.Context(), uuidFileID, req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256)
+		if presignedURL != nil {
+			resp.PresignedURL = *presignedURL
+		}
+	}
+
+	switch {
+	case errors.Is(requestErr, domain.ErrAlreadyExists):
+		problem.Write(w, r, http.StatusConflict, problem.CodeAlreadyExists, "another version is being created")
+		return
+	case requestErr != nil:
+		problem.Error(w, r, h.logger, requestErr)
+		return
+	case version == nil:
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, "file version is nil")
+		return
+	default:
+		resp.VersionID = version.ID
+		resp.Version = version.Version
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("error encoding response", "error", err)
+		}
+		return
+	}
+}
@@ -2,9 +2,8 @@ package file
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"score-play/internal/core/domain"
+	"score-play/internal/adapters/handlers/http/problem"
 
 	"github.com/google/uuid"
 )
@@ -16,12 +15,17 @@ type V1UploadMultipartRequest struct {
 	SizeBytes      int64    `json:"size_bytes"`
 	ChecksumSha256 string   `json:"checksum_sha256"`
 	Tags           []string `json:"tags"`
+	// Deduplicate skips the upload when a completed file with the same content exists
+	Deduplicate bool `json:"deduplicate"`
 }
 
-// V1UploadMultipartResponse is the response to upload a multipart file
+// V1UploadMultipartResponse is the response to upload a multipart file.
+// A deduplicated file is already completed, it has no session.
 type V1UploadMultipartResponse struct {
-	SessionID uuid.UUID `json:"session_id"`
-	PartSize  int       `json:"part_size"`
+	SessionID    uuid.UUID `json:"session_id,omitzero"`
+	PartSize     int       `json:"part_size,omitempty"`
+	FileID       uuid.UUID `json:"file_id,omitzero"`
+	Deduplicated bool      `json:"deduplicated,omitempty"`
 }
 
 func (h *HandlerV1) UploadFileMultipartV1(w http.ResponseWriter, r *http.Request) {
@@ -30,33 +34,44 @@ func (h *HandlerV1) UploadFileMultipartV1(w http.ResponseWriter, r *http.Request
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.logger.Error("error decoding upload multipart file request", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, err.Error())
 		return
 	}
 
 	if req.FileName == "" || req.ContentType == "" || req.SizeBytes == 0 || req.ChecksumSha256 == "" {
-		http.Error(w, "missing param", http.StatusBadRequest)
+		problem.BadRequest(w, r, "missing param")
 		return
 	}
 
 	if req.Tags == nil || len(req.Tags) == 0 {
-		http.Error(w, "provide at least one tag", http.StatusBadRequest)
+		problem.BadRequest(w, r, "provide at least one tag")
 		return
 	}
 
+	if req.Deduplicate {
+		fileID, dedupErr := h.fileService.DeduplicateUpload(r.Context(), req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
+		if dedupErr != nil {
+			problem.Error(w, r, h.logger, dedupErr)
+			return
+		}
+		if fileID != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			if err := json.NewEncoder(w).Encode(V1UploadMultipartResponse{FileID: *fileID, Deduplicated: true}); err != nil {
+				h.logger.Error("error encoding response", "error", err)
+			}
+			return
+		}
+	}
+
 	uploadSession, partSize, requestErr := h.fileService.RequestUploadMultipartFile(r.Context(), req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
 
 	switch {
-	case errors.Is(requestErr, domain.ErrInvalidFileType), errors.Is(requestErr, domain.ErrFileSizeTooSmall), errors.Is(requestErr, domain.ErrFileSizeTooBig), errors.Is(requestErr, domain.ErrTagNotFound):
-		h.logger.Error("invalid request", "error", requestErr)
-		http.Error(w, requestErr.Error(), http.StatusBadRequest)
-		return
 	case requestErr != nil:
-		h.logger.Error("error requesting presigned url", "error", requestErr)
-		http.Error(w, "internal server error", http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, requestErr)
 		return
 	case uploadSession == nil:
-		http.Error(w, "upload session is nil", http.StatusInternalServerError)
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, "upload session is nil")
 		return
 	default:
 
@@ -34,7 +34,7 @@ func TestUploadFileMultipartV1(t *testing.T) {
 			Return(&sessionID, 500, nil)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadMultipartRequest{
@@ -61,6 +61,76 @@ func TestUploadFileMultipartV1(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("success - deduplicated", func(t *testing.T) {
+		// Arrange
+		fileID := uuid.New()
+
+		mockService := file.NewMockFileService()
+		mockService.On("DeduplicateUpload",
+			mock.Anything, "video.mp4", "video/mp4", int64(5000), "sha-hash", tags).
+			Return(&fileID, nil)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadMultipartRequest{
+			FileName:       "video.mp4",
+			ContentType:    "video/mp4",
+			SizeBytes:      5000,
+			ChecksumSha256: "sha-hash",
+			Tags:           tags,
+			Deduplicate:    true,
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart", bytes.NewReader(jsonBody))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		assert.NotContains(t, w.Body.String(), "session_id")
+
+		var resp file3.V1UploadMultipartResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+		assert.Equal(t, fileID, resp.FileID)
+		assert.True(t, resp.Deduplicated)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "RequestUploadMultipartFile")
+	})
+
+	t.Run("error - deduplication fails", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		mockService.On("DeduplicateUpload",
+			mock.Anything, "video.exe", "video/mp4", int64(5000), "sha-hash", tags).
+			Return((*uuid.UUID)(nil), domain.ErrInvalidFileType)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadMultipartRequest{
+			FileName:       "video.exe",
+			ContentType:    "video/mp4",
+			SizeBytes:      5000,
+			ChecksumSha256: "sha-hash",
+			Tags:           tags,
+			Deduplicate:    true,
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart", bytes.NewReader(jsonBody))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RequestUploadMultipartFile")
+	})
+
 	t.Run("error - file too small", func(t *testing.T) {
 		// Arrange
 		requestBody := file3.V1UploadMultipartRequest{
@@ -75,7 +145,7 @@ func TestUploadFileMultipartV1(t *testing.T) {
 			mock.Anything, "small.mp4", requestBody.ContentType, requestBody.SizeBytes, requestBody.ChecksumSha256, tags).
 			Return(&uuid.UUID{}, 500, domain.ErrFileSizeTooSmall)
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		jsonBody, _ := json.Marshal(requestBody)
@@ -103,7 +173,7 @@ func TestUploadFileMultipartV1(t *testing.T) {
 			mock.Anything, "small.mp4", requestBody.ContentType, requestBody.SizeBytes, requestBody.ChecksumSha256, tags).
 			Return(&uuid.UUID{}, 500, domain.ErrFileSizeTooBig)
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		jsonBody, _ := json.Marshal(requestBody)
@@ -124,7 +194,7 @@ func TestUploadFileMultipartV1(t *testing.T) {
 			Return(&uuid.UUID{}, 0, errors.New("db crash"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadMultipartRequest{
@@ -2,9 +2,8 @@ package file
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"score-play/internal/core/domain"
+	"score-play/internal/adapters/handlers/http/problem"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,46 +16,79 @@ type V1UploadFileRequest struct {
 	SizeBytes      int64    `json:"size_bytes"`
 	ChecksumSha256 string   `json:"checksum_sha256"`
 	Tags           []string `json:"tags"`
+	// Deduplicate skips the upload when a completed file with the same content exists
+	Deduplicate bool `json:"deduplicate"`
 }
 
-// V1UploadFileResponse is the response to upload a small file
+// V1UploadFileResponse is the response to upload a small file.
+// Headers are sent with a PUT to the url, Fields are the fields of a form posted to it, before the file.
+// A deduplicated file is already completed, it has no url.
 type V1UploadFileResponse struct {
 	FileID       uuid.UUID         `json:"file_id"`
-	PresignedURL string            `json:"presigned_url"`
-	Headers      map[string]string `json:"headers"`
-	ExpiresAt    *time.Time        `json:"expires_at"`
+	PresignedURL string            `json:"presigned_url,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	ExpiresAt    *time.Time        `json:"expires_at,omitempty"`
+	Deduplicated bool              `json:"deduplicated,omitempty"`
 }
 
+// upload modes of UploadFileV1
+const (
+	UploadModePut  = "put"
+	UploadModePost = "post"
+)
+
 func (h *HandlerV1) UploadFileV1(w http.ResponseWriter, r *http.Request) {
 
+	mode := r.URL.Query().Get("mode")
+	if mode != "" && mode != UploadModePut && mode != UploadModePost {
+		problem.BadRequest(w, r, "mode must be put or post")
+		return
+	}
+
 	var req V1UploadFileRequest
 
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.logger.Error("error decoding upload file request", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, err.Error())
 		return
 	}
 
 	if req.FileName == "" || req.ContentType == "" || req.SizeBytes == 0 || req.ChecksumSha256 == "" {
-		http.Error(w, "missing param", http.StatusBadRequest)
+		problem.BadRequest(w, r, "missing param")
 		return
 	}
 
 	if req.Tags == nil || len(req.Tags) == 0 {
-		http.Error(w, "provide at least one tag", http.StatusBadRequest)
+		problem.BadRequest(w, r, "provide at least one tag")
 		return
 	}
 
-	fileID, presignedURL, headers, expiresAt, requestErr := h.fileService.RequestUploadFile(r.Context(), req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
+	if req.Deduplicate {
+		fileID, dedupErr := h.fileService.DeduplicateUpload(r.Context(), req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
+		if dedupErr != nil {
+			problem.Error(w, r, h.logger, dedupErr)
+			return
+		}
+		if fileID != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			if err := json.NewEncoder(w).Encode(V1UploadFileResponse{FileID: *fileID, Deduplicated: true}); err != nil {
+				h.logger.Error("error encoding response", "error", err)
+			}
+			return
+		}
+	}
+
+	requestUpload := h.fileService.RequestUploadFile
+	if mode == UploadModePost {
+		requestUpload = h.fileService.RequestUploadFilePost
+	}
+	fileID, presignedURL, headers, expiresAt, requestErr := requestUpload(r.Context(), req.FileName, req.ContentType, req.SizeBytes, req.ChecksumSha256, req.Tags)
 	switch {
-	case errors.Is(requestErr, domain.ErrInvalidFileType), errors.Is(requestErr, domain.ErrFileSizeTooBig), errors.Is(requestErr, domain.ErrTagNotFound):
-		h.logger.Error("invalid request", "error", requestErr)
-		http.Error(w, requestErr.Error(), http.StatusBadRequest)
-		return
 	case requestErr != nil:
-		h.logger.Error("error requesting presigned url", "error", err)
-		http.Error(w, "internal server error", http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, requestErr)
 		return
 	default:
 		var finalFileID uuid.UUID
@@ -72,9 +104,13 @@ func (h *HandlerV1) UploadFileV1(w http.ResponseWriter, r *http.Request) {
 		resp := V1UploadFileResponse{
 			FileID:       finalFileID,
 			PresignedURL: finalPresignedURL,
-			Headers:      headers,
 			ExpiresAt:    expiresAt,
 		}
+		if mode == UploadModePost {
+			resp.Fields = headers
+		} else {
+			resp.Headers = headers
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -42,7 +42,7 @@ func TestUploadFileV1_Success(t *testing.T) {
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -92,7 +92,7 @@ func TestUploadFileV1_Success(t *testing.T) {
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -114,6 +114,133 @@ func TestUploadFileV1_Success(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("post mode - form fields", func(t *testing.T) {
+		//Arrange
+		fileID := uuid.New()
+		postURL := "https://s3.amazonaws.com/bucket"
+		fields := map[string]string{"key": "video/match.mp4", "Content-Type": "video/mp4", "policy": "eyJ..."}
+		expiry := time.Now().Add(time.Hour)
+		tags := []string{"soccer"}
+
+		mockService := file.NewMockFileService()
+		mockService.On("RequestUploadFilePost", mock.Anything, "match.mp4", "video/mp4", int64(2048), "video-hash", tags).
+			Return(&fileID, &postURL, fields, &expiry, nil)
+
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadFileRequest{
+			FileName:       "match.mp4",
+			ContentType:    "video/mp4",
+			SizeBytes:      2048,
+			ChecksumSha256: "video-hash",
+			Tags:           tags,
+		}
+		jsonBody, err := json.Marshal(requestBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload?mode=post", bytes.NewReader(jsonBody))
+
+		//Act
+		h.ServeHTTP(w, req)
+
+		//Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "RequestUploadFile")
+		var response file3.V1UploadFileResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, postURL, response.PresignedURL)
+		assert.Equal(t, fields, response.Fields)
+		assert.Nil(t, response.Headers)
+	})
+
+	t.Run("deduplicated - no url", func(t *testing.T) {
+		//Arrange
+		fileID := uuid.New()
+		tags := []string{"soccer"}
+
+		mockService := file.NewMockFileService()
+		mockService.On("DeduplicateUpload", mock.Anything, "match.mp4", "video/mp4", int64(2048), "video-hash", tags).
+			Return(&fileID, nil)
+
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadFileRequest{
+			FileName:       "match.mp4",
+			ContentType:    "video/mp4",
+			SizeBytes:      2048,
+			ChecksumSha256: "video-hash",
+			Tags:           tags,
+			Deduplicate:    true,
+		}
+		jsonBody, err := json.Marshal(requestBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload", bytes.NewReader(jsonBody))
+
+		//Act
+		h.ServeHTTP(w, req)
+
+		//Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "RequestUploadFile")
+		var response file3.V1UploadFileResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, fileID, response.FileID)
+		assert.True(t, response.Deduplicated)
+		assert.Empty(t, response.PresignedURL)
+	})
+
+	t.Run("deduplicate - no duplicate falls back to an upload", func(t *testing.T) {
+		//Arrange
+		fileID := uuid.New()
+		uploadURL := "https://s3.amazonaws.com/bucket/file"
+		expiry := time.Now().Add(time.Hour)
+		tags := []string{"soccer"}
+
+		mockService := file.NewMockFileService()
+		mockService.On("DeduplicateUpload", mock.Anything, "match.mp4", "video/mp4", int64(2048), "video-hash", tags).
+			Return((*uuid.UUID)(nil), nil)
+		mockService.On("RequestUploadFile", mock.Anything, "match.mp4", "video/mp4", int64(2048), "video-hash", tags).
+			Return(&fileID, &uploadURL, map[string]string{}, &expiry, nil)
+
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadFileRequest{
+			FileName:       "match.mp4",
+			ContentType:    "video/mp4",
+			SizeBytes:      2048,
+			ChecksumSha256: "video-hash",
+			Tags:           tags,
+			Deduplicate:    true,
+		}
+		jsonBody, err := json.Marshal(requestBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload", bytes.NewReader(jsonBody))
+
+		//Act
+		h.ServeHTTP(w, req)
+
+		//Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+		var response file3.V1UploadFileResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, uploadURL, response.PresignedURL)
+		assert.False(t, response.Deduplicated)
+	})
+
 }
 
 func TestUploadFileV1_Errors(t *testing.T) {
@@ -125,7 +252,7 @@ func TestUploadFileV1_Errors(t *testing.T) {
 		mockService.On("RequestUploadFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return((*uuid.UUID)(nil), (*string)(nil), (map[string]string)(nil), (*time.Time)(nil), domain.ErrFileSizeTooBig)
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -149,7 +276,7 @@ func TestUploadFileV1_Errors(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{FileName: ""}
@@ -164,11 +291,31 @@ func TestUploadFileV1_Errors(t *testing.T) {
 		mockService.AssertNotCalled(t, "RequestUploadFile")
 	})
 
+	t.Run("error - unknown mode", func(t *testing.T) {
+		// Arrange
+		mockService := file.NewMockFileService()
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1UploadFileRequest{FileName: "match.mp4", ContentType: "video/mp4", SizeBytes: 2048, ChecksumSha256: "video-hash", Tags: []string{"soccer"}}
+		jsonBody, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload?mode=form", bytes.NewReader(jsonBody))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RequestUploadFile")
+		mockService.AssertNotCalled(t, "RequestUploadFilePost")
+	})
+
 	t.Run("error - missing tags", func(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -193,7 +340,7 @@ func TestUploadFileV1_Errors(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -221,7 +368,7 @@ func TestUploadFileV1_Errors(t *testing.T) {
 			Return((*uuid.UUID)(nil), (*string)(nil), (map[string]string)(nil), (*time.Time)(nil), domain.ErrTagNotFound)
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -249,7 +396,7 @@ func TestUploadFileV1_Errors(t *testing.T) {
 			Return((*uuid.UUID)(nil), (*string)(nil), (map[string]string)(nil), (*time.Time)(nil), errors.New("s3 connection failed"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1UploadFileRequest{
@@ -2,9 +2,9 @@ package file
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 	"time"
 
@@ -39,12 +39,12 @@ func (h *HandlerV1) RetrievePresignedPartsV1(w http.ResponseWriter, r *http.Requ
 
 	sessionID := chi.URLParam(r, "sessionID")
 	if sessionID == "" {
-		http.Error(w, "Session ID is required", http.StatusBadRequest)
+		problem.BadRequest(w, r, "session id is required")
 		return
 	}
 	uuidSession, parseErr := uuid.Parse(sessionID)
 	if parseErr != nil {
-		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, parseErr.Error())
 		return
 	}
 
@@ -52,12 +52,12 @@ func (h *HandlerV1) RetrievePresignedPartsV1(w http.ResponseWriter, r *http.Requ
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.logger.Error("error decoding retrieve presigned parts request", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, err.Error())
 		return
 	}
 
 	if req.Parts == nil || len(req.Parts) == 0 {
-		http.Error(w, "Request contains no parts", http.StatusBadRequest)
+		problem.BadRequest(w, r, "request contains no parts")
 		return
 	}
 
@@ -65,15 +65,15 @@ func (h *HandlerV1) RetrievePresignedPartsV1(w http.ResponseWriter, r *http.Requ
 
 	for _, part := range req.Parts {
 		if part.PartNumber <= 0 {
-			http.Error(w, fmt.Sprintf("part %d : invalid part number", part.PartNumber), http.StatusBadRequest)
+			problem.BadRequest(w, r, fmt.Sprintf("part %d : invalid part number", part.PartNumber))
 			return
 		}
 		if part.ContentLength <= 0 {
-			http.Error(w, fmt.Sprintf("part %d : invalid content length", part.PartNumber), http.StatusBadRequest)
+			problem.BadRequest(w, r, fmt.Sprintf("part %d : invalid content length", part.PartNumber))
 			return
 		}
 		if part.Checksum == "" {
-			http.Error(w, fmt.Sprintf("part %d : invalid checksum", part.PartNumber), http.StatusBadRequest)
+			problem.BadRequest(w, r, fmt.Sprintf("part %d : invalid checksum", part.PartNumber))
 			return
 		}
 
@@ -86,15 +86,7 @@ func (h *HandlerV1) RetrievePresignedPartsV1(w http.ResponseWriter, r *http.Requ
 
 	presignedParts, err := h.fileService.GetPresignedParts(r.Context(), uuidSession, domainParts)
 	if err != nil {
-		if errors.Is(err, domain.ErrSessionNotFound) {
-			http.Error(w, "Session not found", http.StatusForbidden)
-		}
-		if errors.Is(err, domain.ErrFileMetadataNotFound) {
-			http.Error(w, "no session found", http.StatusNotFound)
-			return
-		}
-		h.logger.Error("error getting presigned parts", "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, h.logger, err)
 		return
 	}
 
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"score-play/internal/adapters/handlers/http/chi"
 	file3 "score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/service/file"
 	"testing"
@@ -63,7 +64,7 @@ func TestRetrievePresignedPartsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -135,7 +136,7 @@ func TestRetrievePresignedPartsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -175,7 +176,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -197,7 +198,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		// Arrange
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -222,7 +223,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -245,7 +246,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		jsonBody, _ := json.Marshal(map[string]interface{}{"parts": nil})
@@ -265,7 +266,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -290,7 +291,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -315,7 +316,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -340,7 +341,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -365,7 +366,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -390,7 +391,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart/"+sessionID.String()+"/parts", bytes.NewReader([]byte("invalid json")))
@@ -404,6 +405,39 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("error - session not found is answered once", func(t *testing.T) {
+		// Arrange
+		sessionID := uuid.New()
+		mockService := file.NewMockFileService()
+
+		mockService.On("GetPresignedParts", mock.Anything, sessionID, mock.Anything).
+			Return(([]domain.UploadPart)(nil), domain.ErrSessionNotFound)
+
+		handler := file3.NewFileHandlerV1(mockService, discardLogger)
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
+		w := httptest.NewRecorder()
+
+		requestBody := file3.V1RetrievePresignedPartsRequest{
+			Parts: []file3.RequestParts{
+				{PartNumber: 1, Checksum: "hash", ContentLength: 1024},
+			},
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(http2.MethodPost, "/api/v1/file/upload/multipart/"+sessionID.String()+"/parts", bytes.NewReader(jsonBody))
+
+		// Act
+		h.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http2.StatusNotFound, w.Code)
+		var body problem.Problem
+		decoder := json.NewDecoder(w.Body)
+		require.NoError(t, decoder.Decode(&body))
+		assert.Equal(t, problem.CodeSessionNotFound, body.Code)
+		assert.False(t, decoder.More())
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("error - service internal failure", func(t *testing.T) {
 		// Arrange
 		sessionID := uuid.New()
@@ -413,7 +447,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 			Return(([]domain.UploadPart)(nil), errors.New("database connection failed"))
 
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
@@ -429,7 +463,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		h.ServeHTTP(w, req)
 
 		// Assert
-		assert.Equal(t, http2.StatusInternalServerError, w.Code)
+		assert.Equal(t, http2.StatusServiceUnavailable, w.Code)
 		mockService.AssertExpectations(t)
 	})
 
@@ -438,7 +472,7 @@ func TestRetrievePresignedPartsV1_Errors(t *testing.T) {
 		sessionID := uuid.New()
 		mockService := file.NewMockFileService()
 		handler := file3.NewFileHandlerV1(mockService, discardLogger)
-		h := chi.NewRouter(discardLogger, nil, handler, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, File: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := file3.V1RetrievePresignedPartsRequest{
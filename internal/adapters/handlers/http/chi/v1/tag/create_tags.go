@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
 	"score-play/internal/core/domain"
 	"unicode"
 )
@@ -22,24 +23,24 @@ func (h *HandlerV1) CreateTagsV1(w http.ResponseWriter, r *http.Request) {
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.logger.Error("error decoding create tags request", "error", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		problem.BadRequest(w, r, "invalid request")
 		return
 	}
 
 	if req.Tags == nil || len(req.Tags) == 0 {
-		http.Error(w, "tags required", http.StatusBadRequest)
+		problem.BadRequest(w, r, "tags required")
 		return
 	}
 
 	for _, tag := range req.Tags {
 		if tag == "" {
-			http.Error(w, "tag cannot be empty", http.StatusBadRequest)
+			problem.BadRequest(w, r, "tag cannot be empty")
 			return
 		}
 
 		for _, char := range tag {
 			if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
-				http.Error(w, fmt.Sprintf("tag :%s contains invalid characters", tag), http.StatusBadRequest)
+				problem.BadRequest(w, r, fmt.Sprintf("tag :%s contains invalid characters", tag))
 				return
 			}
 		}
@@ -49,11 +50,9 @@ func (h *HandlerV1) CreateTagsV1(w http.ResponseWriter, r *http.Request) {
 	err = h.tagService.CreateTags(r.Context(), req.Tags)
 	switch {
 	case errors.Is(err, domain.ErrAlreadyExists):
-		h.logger.Error("all tags already exist", "error", err)
-		http.Error(w, "all tags already exist", http.StatusConflict)
+		problem.Write(w, r, http.StatusConflict, problem.CodeAlreadyExists, "all tags already exist")
 	case err != nil:
-		h.logger.Error("error creating tags", "error", err)
-		http.Error(w, "internal server error", http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, err)
 		return
 	default:
 		w.WriteHeader(http.StatusCreated)
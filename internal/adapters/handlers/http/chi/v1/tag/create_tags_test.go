@@ -30,7 +30,7 @@ func TestCreateTagsV1_Success(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -57,7 +57,7 @@ func TestCreateTagsV1_Success(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -85,7 +85,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodPost, "/api/v1/tag/", nil)
@@ -108,7 +108,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -134,7 +134,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -160,7 +160,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -188,7 +188,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -216,7 +216,7 @@ func TestCreateTagsV1_Error(t *testing.T) {
 
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
 
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		requestBody := tag2.V1CreateTagsRequest{Tags: expectedTags}
@@ -3,13 +3,22 @@ package tag
 import (
 	"encoding/json"
 	"net/http"
-	"score-play/internal/core/domain"
+	"score-play/internal/adapters/handlers/http/problem"
 	"strconv"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+type V1Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type V1ListTagsResponse struct {
-	Tags       []domain.Tag `json:"tags"`
-	NextMarker *string      `json:"nextMarker,omitempty"`
+	Tags       []V1Tag `json:"tags"`
+	NextMarker *string `json:"nextMarker,omitempty"`
 }
 
 func (h *HandlerV1) ListTagsV1(w http.ResponseWriter, r *http.Request) {
@@ -18,12 +27,12 @@ func (h *HandlerV1) ListTagsV1(w http.ResponseWriter, r *http.Request) {
 
 	limitInt, err := strconv.Atoi(limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.BadRequest(w, r, err.Error())
 		return
 	}
 
 	if limitInt <= 0 {
-		http.Error(w, "limit must be greater than zero", http.StatusBadRequest)
+		problem.BadRequest(w, r, "limit must be greater than zero")
 		return
 	}
 
@@ -34,14 +43,16 @@ func (h *HandlerV1) ListTagsV1(w http.ResponseWriter, r *http.Request) {
 	tags, nextMarker, err := h.tagService.ListTags(r.Context(), limitInt, markerPtr)
 	switch {
 	case err != nil:
-		h.logger.Error("error listing tags", "error", err)
-		http.Error(w, "internal server error", http.StatusServiceUnavailable)
+		problem.Error(w, r, h.logger, err)
 		return
 	default:
 		resp := V1ListTagsResponse{
-			Tags:       tags,
+			Tags:       make([]V1Tag, 0, len(tags)),
 			NextMarker: nextMarker,
 		}
+		for _, t := range tags {
+			resp.Tags = append(resp.Tags, V1Tag{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt})
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -36,7 +36,7 @@ func TestListTagsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=3", nil)
@@ -77,7 +77,7 @@ func TestListTagsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=2&marker=rust", nil)
@@ -114,7 +114,7 @@ func TestListTagsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=10&marker=vue", nil)
@@ -145,7 +145,7 @@ func TestListTagsV1_Success(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=20", nil)
@@ -174,7 +174,7 @@ func TestListTagsV1_Error(t *testing.T) {
 		mockTagService := &tagservice.MockTagService{}
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag", nil)
@@ -192,7 +192,7 @@ func TestListTagsV1_Error(t *testing.T) {
 		mockTagService := &tagservice.MockTagService{}
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=abc", nil)
@@ -210,7 +210,7 @@ func TestListTagsV1_Error(t *testing.T) {
 		mockTagService := &tagservice.MockTagService{}
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=-5", nil)
@@ -232,7 +232,7 @@ func TestListTagsV1_Error(t *testing.T) {
 
 		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 		handler := tag2.NewTagHandlerV1(mockTagService, discardLogger)
-		h := chi.NewRouter(discardLogger, handler, nil, "")
+		h := chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tag: handler})
 		w := httptest.NewRecorder()
 
 		req := httptest.NewRequest(httpgo.MethodGet, "/api/v1/tag?limit=10", nil)
@@ -0,0 +1,55 @@
+package tus
+
+import (
+	"cmp"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"strconv"
+	"strings"
+)
+
+// CreateUploadV1 is the handler for the creation of an upload.
+// Upload-Metadata carries the filename (or name), filetype (or type) and comma separated tags, the keys of the common tus clients,
+// and optionally the checksum (base64 SHA-256 of the whole file). Without it, the api computes the checksum of the data it receives.
+func (h *HandlerV1) CreateUploadV1(w http.ResponseWriter, r *http.Request) {
+	size, err := parseOffset(r, HeaderUploadLength)
+	if err != nil {
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+	if size > h.tusService.MaxSize() {
+		problem.Write(w, r, http.StatusRequestEntityTooLarge, problem.CodeFileTooLarge, HeaderUploadLength+" exceeds "+strconv.FormatInt(h.tusService.MaxSize(), 10))
+		return
+	}
+
+	metadata, err := parseMetadata(r.Header.Get(HeaderUploadMetadata))
+	if err != nil {
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+	filename := cmp.Or(metadata["filename"], metadata["name"])
+	filetype := cmp.Or(metadata["filetype"], metadata["type"])
+	if filename == "" || filetype == "" {
+		problem.BadRequest(w, r, "metadata filename and filetype are required")
+		return
+	}
+	var tags []string
+	for _, tag := range strings.Split(metadata["tags"], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		problem.BadRequest(w, r, "provide at least one tag")
+		return
+	}
+
+	id, err := h.tusService.Create(r.Context(), filename, filetype, size, metadata["checksum"], tags)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+id.String())
+	w.WriteHeader(http.StatusCreated)
+}
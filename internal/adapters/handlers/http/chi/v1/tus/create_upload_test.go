@@ -0,0 +1,120 @@
+package tus_test
+
+import (
+	"encoding/base64"
+	http2 "net/http"
+	"net/http/httptest"
+	tus2 "score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/tus"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// metadata encodes an Upload-Metadata header from pairs of keys and values
+func metadata(pairs ...string) string {
+	header := ""
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if header != "" {
+			header += ","
+		}
+		header += pairs[i] + " " + base64.StdEncoding.EncodeToString([]byte(pairs[i+1]))
+	}
+	return header
+}
+
+func TestCreateUploadV1(t *testing.T) {
+
+	t.Run("success - location of the upload", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("MaxSize").Return(int64(1 << 30))
+		mockService.On("Create", mock.Anything, "match.mp4", "video/mp4", int64(1024), "abc=", []string{"football", "highlights"}).Return(&id, nil)
+		req := newRequest(http2.MethodPost, "/api/v1/tus", nil)
+		req.Header.Set(tus2.HeaderUploadLength, "1024")
+		req.Header.Set(tus2.HeaderUploadMetadata, metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", "football, highlights"))
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		assert.Equal(t, "/api/v1/tus/"+id.String(), w.Header().Get("Location"))
+		assert.Equal(t, tus2.Version, w.Header().Get(tus2.HeaderResumable))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success - standard keys without checksum", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("MaxSize").Return(int64(1 << 30))
+		mockService.On("Create", mock.Anything, "match.mp4", "video/mp4", int64(1024), "", []string{"football"}).Return(&id, nil)
+		req := newRequest(http2.MethodPost, "/api/v1/tus", nil)
+		req.Header.Set(tus2.HeaderUploadLength, "1024")
+		req.Header.Set(tus2.HeaderUploadMetadata, metadata("name", "match.mp4", "type", "video/mp4", "tags", "football"))
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error - quota exceeded", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		mockService.On("MaxSize").Return(int64(1 << 30))
+		mockService.On("Create", mock.Anything, "match.mp4", "video/mp4", int64(1024), "abc=", []string{"football"}).Return((*uuid.UUID)(nil), domain.ErrQuotaExceeded)
+		req := newRequest(http2.MethodPost, "/api/v1/tus", nil)
+		req.Header.Set(tus2.HeaderUploadLength, "1024")
+		req.Header.Set(tus2.HeaderUploadMetadata, metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", "football"))
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusRequestEntityTooLarge, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name           string
+		length         string
+		metadata       string
+		expectedStatus int
+	}{
+		{name: "missing length", metadata: metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", "football"), expectedStatus: http2.StatusBadRequest},
+		{name: "negative length", length: "-1", metadata: metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", "football"), expectedStatus: http2.StatusBadRequest},
+		{name: "length over max size", length: "2048", metadata: metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", "football"), expectedStatus: http2.StatusRequestEntityTooLarge},
+		{name: "invalid metadata", length: "1024", metadata: "filename not-base64!", expectedStatus: http2.StatusBadRequest},
+		{name: "missing filetype", length: "1024", metadata: metadata("filename", "match.mp4", "checksum", "abc=", "tags", "football"), expectedStatus: http2.StatusBadRequest},
+		{name: "missing tags", length: "1024", metadata: metadata("filename", "match.mp4", "filetype", "video/mp4", "checksum", "abc=", "tags", " , "), expectedStatus: http2.StatusBadRequest},
+	}
+	for _, tt := range invalid {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &tus.MockTusService{}
+			mockService.On("MaxSize").Return(int64(1024))
+			req := newRequest(http2.MethodPost, "/api/v1/tus", nil)
+			req.Header.Set(tus2.HeaderUploadLength, tt.length)
+			req.Header.Set(tus2.HeaderUploadMetadata, tt.metadata)
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertNotCalled(t, "Create")
+		})
+	}
+}
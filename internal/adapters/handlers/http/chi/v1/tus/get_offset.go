@@ -0,0 +1,30 @@
+package tus
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// GetOffsetV1 is the handler for the offset an upload resumes from
+func (h *HandlerV1) GetOffsetV1(w http.ResponseWriter, r *http.Request) {
+	id, parseErr := uuid.Parse(chi.URLParam(r, "uploadID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	offset, size, err := h.tusService.Offset(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set(HeaderUploadOffset, strconv.FormatInt(offset, 10))
+	w.Header().Set(HeaderUploadLength, strconv.FormatInt(size, 10))
+	w.WriteHeader(http.StatusOK)
+}
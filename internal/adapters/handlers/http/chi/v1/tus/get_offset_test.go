@@ -0,0 +1,66 @@
+package tus_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	tus2 "score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/tus"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOffsetV1(t *testing.T) {
+
+	t.Run("success - offset and length", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Offset", mock.Anything, id).Return(int64(512), int64(1024), nil)
+		req := newRequest(http2.MethodHead, "/api/v1/tus/"+id.String(), nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusOK, w.Code)
+		assert.Equal(t, "512", w.Header().Get(tus2.HeaderUploadOffset))
+		assert.Equal(t, "1024", w.Header().Get(tus2.HeaderUploadLength))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error - upload not found", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Offset", mock.Anything, id).Return(int64(0), int64(0), domain.ErrSessionNotFound)
+		req := newRequest(http2.MethodHead, "/api/v1/tus/"+id.String(), nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid upload id", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		req := newRequest(http2.MethodHead, "/api/v1/tus/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Offset")
+	})
+}
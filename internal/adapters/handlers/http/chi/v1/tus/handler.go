@@ -0,0 +1,156 @@
+package tus
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Version is the version of the tus protocol served, see https://tus.io/protocols/resumable-upload
+const Version = "1.0.0"
+
+// Extensions are the tus extensions supported
+const Extensions = "creation,termination,checksum"
+
+// ChecksumAlgorithm is the only algorithm of the checksum extension supported
+const ChecksumAlgorithm = "sha256"
+
+// OffsetContentType is the content type of PATCH requests
+const OffsetContentType = "application/offset+octet-stream"
+
+// StatusChecksumMismatch answers data not matching its Upload-Checksum, as the checksum extension defines
+const StatusChecksumMismatch = 460
+
+// tus headers
+const (
+	HeaderResumable         = "Tus-Resumable"
+	HeaderVersion           = "Tus-Version"
+	HeaderExtension         = "Tus-Extension"
+	HeaderMaxSize           = "Tus-Max-Size"
+	HeaderChecksumAlgorithm = "Tus-Checksum-Algorithm"
+	HeaderUploadOffset      = "Upload-Offset"
+	HeaderUploadLength      = "Upload-Length"
+	HeaderUploadMetadata    = "Upload-Metadata"
+	HeaderUploadChecksum    = "Upload-Checksum"
+	HeaderMethodOverride    = "X-HTTP-Method-Override"
+)
+
+// RequestHeaders are the tus headers clients send, to allow in cross origin requests
+var RequestHeaders = []string{HeaderResumable, HeaderUploadLength, HeaderUploadOffset, HeaderUploadMetadata, HeaderUploadChecksum, HeaderMethodOverride}
+
+// ResponseHeaders are the tus headers of the responses, to expose to cross origin requests
+var ResponseHeaders = []string{HeaderResumable, HeaderVersion, HeaderExtension, HeaderMaxSize, HeaderChecksumAlgorithm, HeaderUploadOffset, HeaderUploadLength}
+
+// HandlerV1 is the handler for v1 tus routes
+type HandlerV1 struct {
+	tusService    port.TusService
+	maxConcurrent int
+	logger        *slog.Logger
+}
+
+// NewTusHandlerV1 creates HandlerV1, serving up to maxConcurrent requests at a time, 0 for no limit
+func NewTusHandlerV1(service port.TusService, maxConcurrent int, logger *slog.Logger) *HandlerV1 {
+	return &HandlerV1{
+		tusService:    service,
+		maxConcurrent: maxConcurrent,
+		logger:        logger,
+	}
+}
+
+// MaxConcurrent is the number of requests served at a time, 0 for no limit
+func (h *HandlerV1) MaxConcurrent() int {
+	return h.maxConcurrent
+}
+
+// Routes exposes handler routes
+func (h *HandlerV1) Routes() chi.Router {
+	router := chi.NewRouter()
+	router.Use(protocol)
+
+	router.Options("/", h.OptionsV1)
+	router.Post("/", h.CreateUploadV1)
+	router.Head("/{uploadID}", h.GetOffsetV1)
+	router.Patch("/{uploadID}", h.WriteUploadV1)
+	router.Delete("/{uploadID}", h.TerminateUploadV1)
+
+	return router
+}
+
+// protocol adds the version of the protocol to the responses and rejects the requests of other versions.
+// Clients limited to GET and POST set the method in X-HTTP-Method-Override.
+func protocol(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderResumable, Version)
+		if method := r.Header.Get(HeaderMethodOverride); method != "" {
+			r.Method = strings.ToUpper(method)
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				rctx.RouteMethod = r.Method
+			}
+		}
+		if r.Method != http.MethodOptions && r.Header.Get(HeaderResumable) != Version {
+			w.Header().Set(HeaderVersion, Version)
+			problem.Write(w, r, http.StatusPreconditionFailed, problem.CodeInvalidRequest, "unsupported "+HeaderResumable+" version")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// OptionsV1 is the handler for the discovery of the tus server
+func (h *HandlerV1) OptionsV1(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(HeaderVersion, Version)
+	w.Header().Set(HeaderExtension, Extensions)
+	w.Header().Set(HeaderMaxSize, strconv.FormatInt(h.tusService.MaxSize(), 10))
+	w.Header().Set(HeaderChecksumAlgorithm, ChecksumAlgorithm)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError answers err, checksum mismatches with the status of the checksum extension
+func (h *HandlerV1) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrMismatchChecksum) {
+		problem.Write(w, r, StatusChecksumMismatch, problem.CodeChecksumMismatch, err.Error())
+		return
+	}
+	problem.Error(w, r, h.logger, err)
+}
+
+// parseMetadata parses an Upload-Metadata header: comma separated keys, each followed by its base64 value if any
+func parseMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			metadata[fields[0]] = ""
+		case 2:
+			value, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of metadata %s: %w", fields[0], err)
+			}
+			metadata[fields[0]] = string(value)
+		default:
+			return nil, fmt.Errorf("invalid metadata %q", pair)
+		}
+	}
+	return metadata, nil
+}
+
+// parseOffset parses a non negative size or offset header
+func parseOffset(r *http.Request, header string) (int64, error) {
+	value, err := strconv.ParseInt(r.Header.Get(header), 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non negative integer", header)
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package tus_test
+
+import (
+	"io"
+	"log/slog"
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/chi"
+	tus2 "score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/core/service/tus"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newRouter(mockService *tus.MockTusService) http2.Handler {
+	handler := tus2.NewTusHandlerV1(mockService, 0, discardLogger)
+	return chi.NewRouter(chi.RouterConfig{Logger: discardLogger, Tus: handler})
+}
+
+// newRequest is a request of the supported version of the protocol
+func newRequest(method string, target string, body io.Reader) *http2.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set(tus2.HeaderResumable, tus2.Version)
+	return req
+}
+
+func TestOptionsV1(t *testing.T) {
+	// Arrange
+	mockService := &tus.MockTusService{}
+	mockService.On("MaxSize").Return(int64(5 << 30))
+	req := httptest.NewRequest(http2.MethodOptions, "/api/v1/tus", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	newRouter(mockService).ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http2.StatusNoContent, w.Code)
+	assert.Equal(t, tus2.Version, w.Header().Get(tus2.HeaderResumable))
+	assert.Equal(t, tus2.Version, w.Header().Get(tus2.HeaderVersion))
+	assert.Equal(t, tus2.Extensions, w.Header().Get(tus2.HeaderExtension))
+	assert.Equal(t, "5368709120", w.Header().Get(tus2.HeaderMaxSize))
+	assert.Equal(t, "sha256", w.Header().Get(tus2.HeaderChecksumAlgorithm))
+	mockService.AssertExpectations(t)
+}
+
+func TestProtocol(t *testing.T) {
+
+	t.Run("error - unsupported version", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		req := httptest.NewRequest(http2.MethodHead, "/api/v1/tus/"+uuid.NewString(), nil)
+		req.Header.Set(tus2.HeaderResumable, "0.2.0")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, tus2.Version, w.Header().Get(tus2.HeaderVersion))
+		mockService.AssertNotCalled(t, "Offset")
+	})
+
+	t.Run("success - method override", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Terminate", mock.Anything, id).Return(nil)
+		req := newRequest(http2.MethodPost, "/api/v1/tus/"+id.String(), nil)
+		req.Header.Set(tus2.HeaderMethodOverride, "delete")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,24 @@
+package tus
+
+import (
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// TerminateUploadV1 is the handler for the termination of an upload
+func (h *HandlerV1) TerminateUploadV1(w http.ResponseWriter, r *http.Request) {
+	id, parseErr := uuid.Parse(chi.URLParam(r, "uploadID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+
+	if err := h.tusService.Terminate(r.Context(), id); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
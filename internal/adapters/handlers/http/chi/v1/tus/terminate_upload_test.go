@@ -0,0 +1,48 @@
+package tus_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/tus"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTerminateUploadV1(t *testing.T) {
+
+	t.Run("success - upload terminated", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Terminate", mock.Anything, id).Return(nil)
+		req := newRequest(http2.MethodDelete, "/api/v1/tus/"+id.String(), nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error - upload of another user", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Terminate", mock.Anything, id).Return(domain.ErrForbidden)
+		req := newRequest(http2.MethodDelete, "/api/v1/tus/"+id.String(), nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusForbidden, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
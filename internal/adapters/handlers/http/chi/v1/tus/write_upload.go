@@ -0,0 +1,49 @@
+package tus
+
+import (
+	"mime"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// WriteUploadV1 is the handler for the data of an upload, appended at Upload-Offset
+func (h *HandlerV1) WriteUploadV1(w http.ResponseWriter, r *http.Request) {
+	id, parseErr := uuid.Parse(chi.URLParam(r, "uploadID"))
+	if parseErr != nil {
+		problem.BadRequest(w, r, parseErr.Error())
+		return
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != OffsetContentType {
+		problem.Write(w, r, http.StatusUnsupportedMediaType, problem.CodeInvalidRequest, "Content-Type must be "+OffsetContentType)
+		return
+	}
+	offset, err := parseOffset(r, HeaderUploadOffset)
+	if err != nil {
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	checksum := ""
+	if header := r.Header.Get(HeaderUploadChecksum); header != "" {
+		algorithm, value, ok := strings.Cut(header, " ")
+		if !ok || algorithm != ChecksumAlgorithm {
+			problem.BadRequest(w, r, HeaderUploadChecksum+" must be "+ChecksumAlgorithm+" followed by the base64 checksum")
+			return
+		}
+		checksum = value
+	}
+
+	newOffset, err := h.tusService.Write(r.Context(), id, offset, r.Body, checksum)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(HeaderUploadOffset, strconv.FormatInt(newOffset, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package tus_test
+
+import (
+	http2 "net/http"
+	"net/http/httptest"
+	tus2 "score-play/internal/adapters/handlers/http/chi/v1/tus"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/tus"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newWrite is a PATCH of data at offset
+func newWrite(id uuid.UUID, offset string, data string) *http2.Request {
+	req := newRequest(http2.MethodPatch, "/api/v1/tus/"+id.String(), strings.NewReader(data))
+	req.Header.Set("Content-Type", tus2.OffsetContentType)
+	req.Header.Set(tus2.HeaderUploadOffset, offset)
+	return req
+}
+
+func TestWriteUploadV1(t *testing.T) {
+
+	t.Run("success - new offset", func(t *testing.T) {
+		// Arrange
+		mockService := &tus.MockTusService{}
+		id := uuid.New()
+		mockService.On("Write", mock.Anything, id, int64(512), []byte("data"), "YWJj").Return(int64(516), nil)
+		req := newWrite(id, "512", "data")
+		req.Header.Set(tus2.HeaderUploadChecksum, "sha256 YWJj")
+		w := httptest.NewRecorder()
+
+		// Act
+		newRouter(mockService).ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http2.StatusNoContent, w.Code)
+		assert.Equal(t, "516", w.Header().Get(tus2.HeaderUploadOffset))
+		mockService.AssertExpectations(t)
+	})
+
+	failures := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "offset mismatch", err: domain.ErrOffsetMismatch, expectedStatus: http2.StatusConflict},
+		{name: "checksum mismatch", err: domain.ErrMismatchChecksum, expectedStatus: tus2.StatusChecksumMismatch},
+		{name: "upload locked", err: domain.ErrUploadLocked, expectedStatus: http2.StatusLocked},
+		{name: "data past the length", err: domain.ErrSizeMismatch, expectedStatus: http2.StatusBadRequest},
+	}
+	for _, tt := range failures {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &tus.MockTusService{}
+			id := uuid.New()
+			mockService.On("Write", mock.Anything, id, int64(0), []byte("data"), "").Return(int64(0), tt.err)
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, newWrite(id, "0", "data"))
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	invalid := []struct {
+		name           string
+		contentType    string
+		offset         string
+		checksum       string
+		expectedStatus int
+	}{
+		{name: "wrong content type", contentType: "application/octet-stream", offset: "0", expectedStatus: http2.StatusUnsupportedMediaType},
+		{name: "missing offset", contentType: tus2.OffsetContentType, expectedStatus: http2.StatusBadRequest},
+		{name: "unsupported checksum algorithm", contentType: tus2.OffsetContentType, offset: "0", checksum: "md5 YWJj", expectedStatus: http2.StatusBadRequest},
+	}
+	for _, tt := range invalid {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &tus.MockTusService{}
+			req := newWrite(uuid.New(), tt.offset, "data")
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.checksum != "" {
+				req.Header.Set(tus2.HeaderUploadChecksum, tt.checksum)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			newRouter(mockService).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertNotCalled(t, "Write")
+		})
+	}
+}
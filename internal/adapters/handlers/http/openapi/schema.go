@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the subset of json schema the spec uses
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Nullable             bool               `yaml:"nullable"`
+	Enum                 []any              `yaml:"enum"`
+	Minimum              *float64           `yaml:"minimum"`
+	MaxLength            *int               `yaml:"maxLength"`
+	Required             []string           `yaml:"required"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	AdditionalProperties *Additional        `yaml:"additionalProperties"`
+	Items                *Schema            `yaml:"items"`
+	AllOf                []*Schema          `yaml:"allOf"`
+}
+
+// Additional is an additionalProperties keyword, either a boolean or a schema
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// UnmarshalYAML decodes both forms of additionalProperties
+func (a *Additional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Allowed)
+	}
+	a.Allowed = true
+	return node.Decode(&a.Schema)
+}
+
+// ValidationError is a value not matching its schema, at a json pointer like path
+type ValidationError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Reason
+	}
+	return e.Path + ": " + e.Reason
+}
+
+// validate checks a json decoded value, numbers being json.Number.
+// strict rejects properties not declared by object schemas without additionalProperties.
+func (s *Spec) validate(schema *Schema, value any, path string, strict bool) error {
+	schema, err := s.resolve(schema)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return &ValidationError{path, "must not be null"}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return &ValidationError{path, fmt.Sprintf("must be one of %v", schema.Enum)}
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return &ValidationError{path, "must be an object"}
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return &ValidationError{path + "/" + name, "is required"}
+			}
+		}
+		for name, v := range obj {
+			prop, declared := schema.Properties[name]
+			switch {
+			case declared:
+			case schema.AdditionalProperties != nil && !schema.AdditionalProperties.Allowed:
+				return &ValidationError{path + "/" + name, "is not allowed"}
+			case schema.AdditionalProperties != nil:
+				prop = schema.AdditionalProperties.Schema
+			case strict:
+				return &ValidationError{path + "/" + name, "is not declared"}
+			}
+			if err := s.validate(prop, v, path+"/"+name, strict); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return &ValidationError{path, "must be an array"}
+		}
+		for i, v := range arr {
+			if err := s.validate(schema.Items, v, fmt.Sprintf("%s/%d", path, i), strict); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return &ValidationError{path, "must be a string"}
+		}
+		if schema.MaxLength != nil && utf8.RuneCountInString(str) > *schema.MaxLength {
+			return &ValidationError{path, fmt.Sprintf("must be at most %d characters", *schema.MaxLength)}
+		}
+		if err := checkFormat(schema.Format, str); err != nil {
+			return &ValidationError{path, err.Error()}
+		}
+	case "integer", "number":
+		reason := "must be a number"
+		if schema.Type == "integer" {
+			reason = "must be an integer"
+		}
+		num, ok := value.(json.Number)
+		if !ok {
+			return &ValidationError{path, reason}
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return &ValidationError{path, reason}
+		}
+		if _, err := num.Int64(); err != nil && schema.Type == "integer" {
+			return &ValidationError{path, reason}
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			return &ValidationError{path, fmt.Sprintf("must be at least %v", *schema.Minimum)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return &ValidationError{path, "must be a boolean"}
+		}
+	}
+
+	return nil
+}
+
+// resolve follows $ref and merges allOf into a single schema
+func (s *Spec) resolve(schema *Schema) (*Schema, error) {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+		target := s.Components.Schemas[name]
+		if !ok || target == nil || depth > 32 {
+			return nil, fmt.Errorf("unresolved schema reference %q", schema.Ref)
+		}
+		schema = target
+	}
+	if schema == nil || len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+
+	merged := *schema
+	merged.AllOf = nil
+	merged.Required = append([]string(nil), schema.Required...)
+	merged.Properties = make(map[string]*Schema)
+	for name, prop := range schema.Properties {
+		merged.Properties[name] = prop
+	}
+	for _, sub := range schema.AllOf {
+		sub, err := s.resolve(sub)
+		if err != nil {
+			return nil, err
+		}
+		if merged.Type == "" {
+			merged.Type = sub.Type
+		}
+		merged.Required = append(merged.Required, sub.Required...)
+		for name, prop := range sub.Properties {
+			merged.Properties[name] = prop
+		}
+	}
+	return &merged, nil
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFormat(format string, value string) error {
+	switch format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return fmt.Errorf("must be a uuid")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("must be a RFC 3339 date-time")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrRouteNotFound    = errors.New("no operation matches the path")
+	ErrMethodNotAllowed = errors.New("no operation matches the method")
+)
+
+// Spec is the subset of an openapi 3.0 document the api is validated against
+type Spec struct {
+	Servers    []Server             `yaml:"servers"`
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components"`
+
+	// Strict rejects response properties the schemas do not declare, to catch drift in tests
+	Strict bool `yaml:"-"`
+
+	routes []Route
+}
+
+// Server is the base url of paths, only its path is used
+type Server struct {
+	URL string `yaml:"url"`
+}
+
+// Components holds the reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+// PathItem holds the operations of a path, its servers overriding the ones of the spec
+type PathItem struct {
+	Servers []Server   `yaml:"servers"`
+	Get     *Operation `yaml:"get"`
+	Post    *Operation `yaml:"post"`
+	Put     *Operation `yaml:"put"`
+	Patch   *Operation `yaml:"patch"`
+	Delete  *Operation `yaml:"delete"`
+	Head    *Operation `yaml:"head"`
+	Options *Operation `yaml:"options"`
+}
+
+// Operation is a method on a path
+type Operation struct {
+	OperationID string               `yaml:"operationId"`
+	Parameters  []Parameter          `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// RequestBody is the body of an operation by media type
+type RequestBody struct {
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response is a response of an operation by media type
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Route is an operation with the full path template it is served at, e.g. /api/v1/file/{fileID}
+type Route struct {
+	Method    string
+	Path      string
+	Operation *Operation
+
+	segments []string
+}
+
+// Load parses an openapi document and resolves its routes
+func Load(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+
+	for path, item := range spec.Paths {
+		base := serverPath(spec.Servers)
+		if len(item.Servers) > 0 {
+			base = serverPath(item.Servers)
+		}
+		full := strings.TrimSuffix(base+path, "/")
+		for method, op := range item.operations() {
+			spec.routes = append(spec.routes, Route{
+				Method:    method,
+				Path:      full,
+				Operation: op,
+				segments:  strings.Split(full, "/"),
+			})
+		}
+	}
+
+	// static segments win over parameters, e.g. /file/upload over /file/{fileID}
+	sort.Slice(spec.routes, func(i, j int) bool {
+		a, b := spec.routes[i], spec.routes[j]
+		for k := 0; k < len(a.segments) && k < len(b.segments); k++ {
+			if pa, pb := isParam(a.segments[k]), isParam(b.segments[k]); pa != pb {
+				return pb
+			}
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	return &spec, nil
+}
+
+// Routes lists the operations of the spec
+func (s *Spec) Routes() []Route {
+	return s.routes
+}
+
+// FindRoute finds the operation serving a request path, trailing slashes being ignored, and its path parameters
+func (s *Spec) FindRoute(method string, path string) (*Route, map[string]string, error) {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	err := ErrRouteNotFound
+	for i := range s.routes {
+		route := &s.routes[i]
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		if route.Method != method {
+			err = ErrMethodNotAllowed
+			continue
+		}
+		return route, params, nil
+	}
+	return nil, nil, err
+}
+
+func (r *Route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, segment := range r.segments {
+		if isParam(segment) {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (p *PathItem) operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{
+		http.MethodGet:     p.Get,
+		http.MethodPost:    p.Post,
+		http.MethodPut:     p.Put,
+		http.MethodPatch:   p.Patch,
+		http.MethodDelete:  p.Delete,
+		http.MethodHead:    p.Head,
+		http.MethodOptions: p.Options,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+// serverPath keeps the path of the first server, e.g. /api/v1 for http://localhost:8080/api/v1
+func serverPath(servers []Server) string {
+	if len(servers) == 0 {
+		return ""
+	}
+	url := servers[0].URL
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+		if j := strings.Index(url, "/"); j >= 0 {
+			url = url[j:]
+		} else {
+			url = ""
+		}
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,252 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/openapi"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.0
+servers:
+  - url: http://localhost:8080/api/v1
+components:
+  schemas:
+    Problem:
+      type: object
+      properties:
+        code:
+          type: string
+    Item:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+paths:
+  /item:
+    post:
+      operationId: createItem
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, count]
+              properties:
+                name:
+                  type: string
+                  maxLength: 5
+                count:
+                  type: integer
+                  minimum: 1
+                kind:
+                  type: string
+                  enum: [a, b]
+                labels:
+                  type: object
+                  additionalProperties:
+                    type: string
+      responses:
+        '201':
+          description: Created.
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - $ref: '#/components/schemas/Item'
+                  - type: object
+                    properties:
+                      created_at:
+                        type: string
+                        format: date-time
+        '400':
+          description: Invalid request.
+  /item/latest:
+    get:
+      operationId: getLatestItem
+      responses:
+        '200':
+          description: Item.
+  /item/{itemID}/:
+    get:
+      operationId: getItem
+      parameters:
+        - in: path
+          name: itemID
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - in: query
+          name: limit
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+      responses:
+        '200':
+          description: Item.
+  /health:
+    servers:
+      - url: /
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: Healthy.
+`
+
+const itemID = "0b6c2a4e-6f0e-4f43-a1d5-0d9c7c3c1d11"
+
+func TestFindRoute(t *testing.T) {
+	spec, err := openapi.Load([]byte(testSpec))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		method            string
+		path              string
+		expectedOperation string
+		expectedParams    map[string]string
+		expectedErr       error
+	}{
+		{"static path", http.MethodPost, "/api/v1/item", "createItem", map[string]string{}, nil},
+		{"static segment wins over parameter", http.MethodGet, "/api/v1/item/latest", "getLatestItem", map[string]string{}, nil},
+		{"path parameter", http.MethodGet, "/api/v1/item/" + itemID, "getItem", map[string]string{"itemID": itemID}, nil},
+		{"trailing slash is ignored", http.MethodGet, "/api/v1/item/" + itemID + "/", "getItem", map[string]string{"itemID": itemID}, nil},
+		{"path server", http.MethodGet, "/health", "health", map[string]string{}, nil},
+		{"unknown path", http.MethodGet, "/api/v1/other", "", nil, openapi.ErrRouteNotFound},
+		{"path without server", http.MethodPost, "/item", "", nil, openapi.ErrRouteNotFound},
+		{"unknown method", http.MethodDelete, "/api/v1/item", "", nil, openapi.ErrMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			route, params, err := spec.FindRoute(tt.method, tt.path)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOperation, route.Operation.OperationID)
+			assert.Equal(t, tt.expectedParams, params)
+		})
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec, err := openapi.Load([]byte(testSpec))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		body          string
+		expectedError string
+	}{
+		{"valid body", http.MethodPost, "/api/v1/item", `{"name":"ball","count":2,"kind":"a","labels":{"x":"y"},"extra":true}`, ""},
+		{"missing body", http.MethodPost, "/api/v1/item", "", "body: is required"},
+		{"invalid json", http.MethodPost, "/api/v1/item", `{"name":`, "body: must be valid json"},
+		{"missing property", http.MethodPost, "/api/v1/item", `{"name":"ball"}`, "body/count: is required"},
+		{"wrong type", http.MethodPost, "/api/v1/item", `{"name":"ball","count":"2"}`, "body/count: must be an integer"},
+		{"not an integer", http.MethodPost, "/api/v1/item", `{"name":"ball","count":1.5}`, "body/count: must be an integer"},
+		{"below minimum", http.MethodPost, "/api/v1/item", `{"name":"ball","count":0}`, "body/count: must be at least 1"},
+		{"too long", http.MethodPost, "/api/v1/item", `{"name":"basketball","count":1}`, "body/name: must be at most 5 characters"},
+		{"not in enum", http.MethodPost, "/api/v1/item", `{"name":"ball","count":1,"kind":"c"}`, "body/kind: must be one of [a b]"},
+		{"invalid additional property", http.MethodPost, "/api/v1/item", `{"name":"ball","count":1,"labels":{"x":1}}`, "body/labels/x: must be a string"},
+		{"valid parameters", http.MethodGet, "/api/v1/item/" + itemID + "?limit=3", "", ""},
+		{"invalid path parameter", http.MethodGet, "/api/v1/item/abc?limit=3", "", "path parameter itemID: must be a uuid"},
+		{"missing query parameter", http.MethodGet, "/api/v1/item/" + itemID, "", "query parameter limit: is required"},
+		{"invalid query parameter", http.MethodGet, "/api/v1/item/" + itemID + "?limit=abc", "", "query parameter limit: must be an integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			route, params, err := spec.FindRoute(req.Method, req.URL.Path)
+			require.NoError(t, err)
+
+			// Act
+			err = spec.ValidateRequest(req, route, params)
+
+			// Assert
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+
+	t.Run("body can still be read", func(t *testing.T) {
+		// Arrange
+		body := `{"name":"ball","count":2}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/item", strings.NewReader(body))
+		route, params, err := spec.FindRoute(req.Method, req.URL.Path)
+		require.NoError(t, err)
+
+		// Act
+		err = spec.ValidateRequest(req, route, params)
+
+		// Assert
+		require.NoError(t, err)
+		data, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(data))
+	})
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec, err := openapi.Load([]byte(testSpec))
+	require.NoError(t, err)
+	route, _, err := spec.FindRoute(http.MethodPost, "/api/v1/item")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		strict        bool
+		status        int
+		contentType   string
+		body          string
+		expectedError string
+	}{
+		{"valid body", false, http.StatusCreated, "application/json", `{"id":"` + itemID + `","created_at":"2026-01-02T15:04:05Z"}`, ""},
+		{"undeclared status", false, http.StatusConflict, "application/json", `{}`, "status 409 is not declared"},
+		{"missing required property from ref", false, http.StatusCreated, "application/json", `{"created_at":"2026-01-02T15:04:05Z"}`, "body/id: is required"},
+		{"invalid date-time", false, http.StatusCreated, "application/json", `{"id":"` + itemID + `","created_at":"yesterday"}`, "body/created_at: must be a RFC 3339 date-time"},
+		{"undeclared property", false, http.StatusCreated, "application/json", `{"id":"` + itemID + `","name":"ball"}`, ""},
+		{"undeclared property in strict mode", true, http.StatusCreated, "application/json", `{"id":"` + itemID + `","name":"ball"}`, "body/name: is not declared"},
+		{"undeclared content type", false, http.StatusCreated, "text/plain", `ok`, `content type "text/plain" is not declared for status 201`},
+		{"problem", false, http.StatusBadRequest, "application/problem+json", `{"code":"invalid_request"}`, ""},
+		{"invalid problem", false, http.StatusBadRequest, "application/problem+json", `{"code":1}`, "body/code: must be a string"},
+		{"undeclared body in strict mode", true, http.StatusBadRequest, "application/json", `{}`, "body is not declared for status 400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			spec.Strict = tt.strict
+
+			// Act
+			err := spec.ValidateResponse(route, tt.status, tt.contentType, []byte(tt.body))
+
+			// Assert
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"score-play/internal/adapters/handlers/http/problem"
+	"strconv"
+	"strings"
+)
+
+// problemSchema is the component problem responses are checked against when a response does not declare them
+const problemSchema = "Problem"
+
+// ValidateRequest checks the path and query parameters and the json body of a request against its route.
+// The body is read and replaced, handlers can still decode it.
+func (s *Spec) ValidateRequest(r *http.Request, route *Route, pathParams map[string]string) error {
+	query := r.URL.Query()
+	for _, param := range route.Operation.Parameters {
+		var (
+			raw     string
+			present bool
+		)
+		switch param.In {
+		case "path":
+			raw, present = pathParams[param.Name]
+		case "query":
+			present = query.Has(param.Name)
+			raw = query.Get(param.Name)
+		default:
+			continue
+		}
+		if !present || raw == "" {
+			if param.Required {
+				return &ValidationError{param.In + " parameter " + param.Name, "is required"}
+			}
+			continue
+		}
+		if err := s.validate(param.Schema, s.coerce(param.Schema, raw), param.In+" parameter "+param.Name, false); err != nil {
+			return err
+		}
+	}
+
+	if route.Operation.RequestBody == nil {
+		return nil
+	}
+	media, ok := route.Operation.RequestBody.Content["application/json"]
+	if !ok {
+		return nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return &ValidationError{"body", "could not be read"}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if route.Operation.RequestBody.Required {
+			return &ValidationError{"body", "is required"}
+		}
+		return nil
+	}
+	value, err := decode(body)
+	if err != nil {
+		return &ValidationError{"body", "must be valid json"}
+	}
+	return s.validate(media.Schema, value, "body", false)
+}
+
+// ValidateResponse checks that a response status is declared by its route and that a json body matches its schema.
+// Problem bodies are checked against the Problem component when the response does not declare them.
+func (s *Spec) ValidateResponse(route *Route, status int, contentType string, body []byte) error {
+	responses := route.Operation.Responses
+	resp, ok := responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = responses[fmt.Sprintf("%dXX", status/100)]
+	}
+	if !ok {
+		resp, ok = responses["default"]
+	}
+	if !ok || resp == nil {
+		return fmt.Errorf("status %d is not declared", status)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var schema *Schema
+	media, declared := resp.Content[mediaType]
+	switch {
+	case declared:
+		schema = media.Schema
+	case mediaType == problem.ContentType && s.Components.Schemas[problemSchema] != nil:
+		schema = s.Components.Schemas[problemSchema]
+	case len(resp.Content) > 0:
+		return fmt.Errorf("content type %q is not declared for status %d", mediaType, status)
+	case s.Strict:
+		return fmt.Errorf("body is not declared for status %d", status)
+	default:
+		return nil
+	}
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	value, err := decode(body)
+	if err != nil {
+		return &ValidationError{"body", "must be valid json"}
+	}
+	return s.validate(schema, value, "body", s.Strict)
+}
+
+// coerce converts a parameter to the json value its schema expects, invalid values are kept as strings to be reported
+func (s *Spec) coerce(schema *Schema, raw string) any {
+	schema, err := s.resolve(schema)
+	if err != nil || schema == nil {
+		return raw
+	}
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+func decode(body []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,140 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"score-play/internal/core/domain"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ContentType is the media type of problem responses
+const ContentType = "application/problem+json"
+
+// typePrefix prefixes the code of a problem to build its type uri
+const typePrefix = "urn:score-play:problem:"
+
+// Stable machine readable codes of problems. Clients should switch on them rather than on titles or details.
+const (
+	CodeInvalidRequest         = "invalid_request"
+	CodeUnauthenticated        = "unauthenticated"
+	CodeForbidden              = "forbidden"
+	CodeInsufficientScope      = "insufficient_scope"
+	CodeInvalidTenant          = "invalid_tenant"
+	CodeRateLimited            = "rate_limited"
+	CodeAlreadyExists          = "already_exists"
+	CodeSessionNotFound        = "session_not_found"
+	CodeTagNotFound            = "tag_not_found"
+	CodeFileNotFound           = "file_not_found"
+	CodeFileVersionNotFound    = "file_version_not_found"
+	CodeAPIKeyNotFound         = "api_key_not_found"
+	CodeInvalidFileType        = "invalid_file_type"
+	CodeFileTypeMismatch       = "file_type_mismatch"
+	CodeFileTooLarge           = "file_too_large"
+	CodeFileTooSmall           = "file_too_small"
+	CodeETagMismatch           = "etag_mismatch"
+	CodePartCountMismatch      = "part_count_mismatch"
+	CodeDuplicatePart          = "duplicate_part"
+	CodeChecksumMismatch       = "checksum_mismatch"
+	CodeSizeMismatch           = "size_mismatch"
+	CodeContentTypeMismatch    = "content_type_mismatch"
+	CodeFileNotReady           = "file_not_ready"
+	CodeFileUploadFailed       = "file_upload_failed"
+	CodeInvalidScope           = "invalid_scope"
+	CodeFileCountQuotaExceeded = "file_count_quota_exceeded"
+	CodeStorageQuotaExceeded   = "storage_quota_exceeded"
+	CodeOffsetMismatch         = "offset_mismatch"
+	CodeUploadLocked           = "upload_locked"
+	CodeImportNotFound         = "import_not_found"
+	CodeSourceNotAllowed       = "source_not_allowed"
+	CodeInvalidClip            = "invalid_clip"
+	CodeInternal               = "internal_error"
+	CodeServiceUnavailable     = "service_unavailable"
+)
+
+// Problem is a RFC 7807 problem details body, extended with a stable code and the request id
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// mapping is the problem a domain error is answered with
+type mapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// mappings is checked in order, wrapping errors must come before the ones they wrap
+var mappings = []mapping{
+	{domain.ErrUnauthenticated, http.StatusUnauthorized, CodeUnauthenticated},
+	{domain.ErrForbidden, http.StatusForbidden, CodeForbidden},
+	{domain.ErrAlreadyExists, http.StatusConflict, CodeAlreadyExists},
+	{domain.ErrSessionNotFound, http.StatusNotFound, CodeSessionNotFound},
+	{domain.ErrTagNotFound, http.StatusBadRequest, CodeTagNotFound},
+	{domain.ErrFileMetadataNotFound, http.StatusNotFound, CodeFileNotFound},
+	{domain.ErrFileVersionNotFound, http.StatusNotFound, CodeFileVersionNotFound},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, CodeAPIKeyNotFound},
+	{domain.ErrInvalidFileType, http.StatusBadRequest, CodeInvalidFileType},
+	{domain.ErrFileTypeMismatch, http.StatusBadRequest, CodeFileTypeMismatch},
+	{domain.ErrFileSizeTooBig, http.StatusBadRequest, CodeFileTooLarge},
+	{domain.ErrFileSizeTooSmall, http.StatusBadRequest, CodeFileTooSmall},
+	{domain.ErrMismatchETag, http.StatusBadRequest, CodeETagMismatch},
+	{domain.ErrMismatchNBParts, http.StatusBadRequest, CodePartCountMismatch},
+	{domain.ErrDuplicatePart, http.StatusBadRequest, CodeDuplicatePart},
+	{domain.ErrMismatchChecksum, http.StatusBadRequest, CodeChecksumMismatch},
+	{domain.ErrSizeMismatch, http.StatusBadRequest, CodeSizeMismatch},
+	{domain.ErrContentTypeMismatch, http.StatusBadRequest, CodeContentTypeMismatch},
+	{domain.ErrFileNotReady, http.StatusConflict, CodeFileNotReady},
+	{domain.ErrFileUploadFailed, http.StatusConflict, CodeFileUploadFailed},
+	{domain.ErrInvalidScope, http.StatusBadRequest, CodeInvalidScope},
+	{domain.ErrFileCountQuotaExceeded, http.StatusTooManyRequests, CodeFileCountQuotaExceeded},
+	{domain.ErrQuotaExceeded, http.StatusRequestEntityTooLarge, CodeStorageQuotaExceeded},
+	{domain.ErrOffsetMismatch, http.StatusConflict, CodeOffsetMismatch},
+	{domain.ErrUploadLocked, http.StatusLocked, CodeUploadLocked},
+	{domain.ErrImportNotFound, http.StatusNotFound, CodeImportNotFound},
+	{domain.ErrSourceNotAllowed, http.StatusBadRequest, CodeSourceNotAllowed},
+	{domain.ErrInvalidClip, http.StatusBadRequest, CodeInvalidClip},
+}
+
+// Write writes a problem response with the given status, code and detail
+func Write(w http.ResponseWriter, r *http.Request, status int, code string, detail string) {
+	p := Problem{
+		Type:      typePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Code:      code,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// BadRequest writes an invalid_request problem, for requests rejected before reaching the services
+func BadRequest(w http.ResponseWriter, r *http.Request, detail string) {
+	Write(w, r, http.StatusBadRequest, CodeInvalidRequest, detail)
+}
+
+// Error writes the problem mapped from a domain error, its message being the detail.
+// Other errors are logged and answered with 503 without detail, they may leak internals.
+func Error(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error) {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			Write(w, r, m.status, m.code, err.Error())
+			return
+		}
+	}
+	logger.Error("unexpected error", "error", err, "method", r.Method, "path", r.URL.Path, "request_id", middleware.GetReqID(r.Context()))
+	Write(w, r, http.StatusServiceUnavailable, CodeServiceUnavailable, "")
+}
@@ -0,0 +1,96 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/handlers/http/problem"
+	"score-play/internal/core/domain"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) problem.Problem {
+	t.Helper()
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var p problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	return p
+}
+
+func TestError(t *testing.T) {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: domain.ErrSessionNotFound, status: http.StatusNotFound, code: problem.CodeSessionNotFound},
+		{err: fmt.Errorf("tag foo: %w", domain.ErrTagNotFound), status: http.StatusBadRequest, code: problem.CodeTagNotFound},
+		{err: domain.ErrMismatchChecksum, status: http.StatusBadRequest, code: problem.CodeChecksumMismatch},
+		{err: domain.ErrForbidden, status: http.StatusForbidden, code: problem.CodeForbidden},
+		{err: domain.ErrFileCountQuotaExceeded, status: http.StatusTooManyRequests, code: problem.CodeFileCountQuotaExceeded},
+		{err: domain.ErrQuotaExceeded, status: http.StatusRequestEntityTooLarge, code: problem.CodeStorageQuotaExceeded},
+		{err: domain.ErrOffsetMismatch, status: http.StatusConflict, code: problem.CodeOffsetMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			// Arrange
+			ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/file/123/", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			// Act
+			problem.Error(w, req, discardLogger, tt.err)
+
+			// Assert
+			require.Equal(t, tt.status, w.Code)
+			p := decode(t, w)
+			assert.Equal(t, tt.code, p.Code)
+			assert.Equal(t, "urn:score-play:problem:"+tt.code, p.Type)
+			assert.Equal(t, tt.status, p.Status)
+			assert.Equal(t, http.StatusText(tt.status), p.Title)
+			assert.Equal(t, tt.err.Error(), p.Detail)
+			assert.Equal(t, "/api/v1/file/123/", p.Instance)
+			assert.Equal(t, "req-1", p.RequestID)
+		})
+	}
+
+	t.Run("unexpected errors are not detailed", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tag", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		problem.Error(w, req, discardLogger, fmt.Errorf("dial tcp 10.0.0.3:5432: %w", assert.AnError))
+
+		// Assert
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		p := decode(t, w)
+		assert.Equal(t, problem.CodeServiceUnavailable, p.Code)
+		assert.Empty(t, p.Detail)
+	})
+}
+
+func TestBadRequest(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tag", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	problem.BadRequest(w, req, "tags required")
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	p := decode(t, w)
+	assert.Equal(t, problem.CodeInvalidRequest, p.Code)
+	assert.Equal(t, "tags required", p.Detail)
+}
@@ -0,0 +1,147 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Statuses of the checks and of the service
+const (
+	StatusOK   = "ok"
+	StatusDown = "down"
+)
+
+// Check probes a dependency. details are reported as is in the readiness output.
+type Check func(ctx context.Context) (details map[string]any, err error)
+
+// Result is the outcome of a check
+type Result struct {
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	DurationMs int64          `json:"duration_ms"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// Report is the readiness output
+type Report struct {
+	Status    string            `json:"status"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Checker runs the checks of the dependencies of a service concurrently, each one bounded by timeout.
+// Reports are cached for ttl so that probes of several orchestrators do not hammer the dependencies.
+type Checker struct {
+	timeout time.Duration
+	ttl     time.Duration
+	names   []string
+	checks  map[string]Check
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// NewChecker creates a Checker without checks
+func NewChecker(timeout time.Duration, ttl time.Duration) *Checker {
+	return &Checker{timeout: timeout, ttl: ttl, checks: make(map[string]Check)}
+}
+
+// Add registers a check, to be called before serving
+func (c *Checker) Add(name string, check Check) {
+	c.names = append(c.names, name)
+	c.checks[name] = check
+	sort.Strings(c.names)
+}
+
+// Report returns the cached report, running the checks when it is older than ttl
+func (c *Checker) Report(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.report != nil && time.Since(c.report.CheckedAt) < c.ttl {
+		return *c.report
+	}
+
+	// the report is shared, a probe giving up must not cache failures for the others
+	ctx = context.WithoutCancel(ctx)
+	report := Report{Status: StatusOK, CheckedAt: time.Now(), Checks: make(map[string]Result, len(c.names))}
+	results := make([]Result, len(c.names))
+	var wg sync.WaitGroup
+	for i, name := range c.names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, c.checks[name])
+		}()
+	}
+	wg.Wait()
+
+	for i, name := range c.names {
+		report.Checks[name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusDown
+		}
+	}
+	c.report = &report
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		details, err := check(ctx)
+		result := Result{Status: StatusOK, Details: details}
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = err.Error()
+		}
+		done <- result
+	}()
+
+	// checks ignoring their context must not hold the probe
+	var result Result
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = Result{Status: StatusDown, Error: "timed out after " + c.timeout.String()}
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// Live answers 200 as long as the process serves requests
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+// Ready answers 200 when every dependency is up, 503 otherwise, with the result of each check
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	report := c.Report(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+// Handler serves /health/live and /health/ready, for services without an api router
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health/live", c.Live)
+	mux.HandleFunc("GET /health/ready", c.Ready)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
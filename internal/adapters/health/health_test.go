@@ -0,0 +1,156 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/health"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(ctx context.Context) (map[string]any, error) {
+	return map[string]any{"pending": 3}, nil
+}
+
+func down(ctx context.Context) (map[string]any, error) {
+	return nil, errors.New("connection refused")
+}
+
+func hanging(ctx context.Context) (map[string]any, error) {
+	time.Sleep(200 * time.Millisecond)
+	return nil, nil
+}
+
+func TestChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		checks         map[string]health.Check
+		expectedStatus int
+		expectedReport map[string]health.Result
+	}{
+		{
+			name:           "every dependency up",
+			checks:         map[string]health.Check{"postgres": up, "nats": up},
+			expectedStatus: http.StatusOK,
+			expectedReport: map[string]health.Result{
+				"postgres": {Status: health.StatusOK, Details: map[string]any{"pending": float64(3)}},
+				"nats":     {Status: health.StatusOK, Details: map[string]any{"pending": float64(3)}},
+			},
+		},
+		{
+			name:           "one dependency down",
+			checks:         map[string]health.Check{"postgres": up, "minio": down},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: map[string]health.Result{
+				"postgres": {Status: health.StatusOK, Details: map[string]any{"pending": float64(3)}},
+				"minio":    {Status: health.StatusDown, Error: "connection refused"},
+			},
+		},
+		{
+			name:           "check timing out",
+			checks:         map[string]health.Check{"nats": hanging},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: map[string]health.Result{
+				"nats": {Status: health.StatusDown, Error: "timed out after 20ms"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			checker := health.NewChecker(20*time.Millisecond, time.Minute)
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			var report health.Report
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+			for name := range report.Checks {
+				result := report.Checks[name]
+				result.DurationMs = 0
+				report.Checks[name] = result
+			}
+			assert.Equal(t, tt.expectedReport, report.Checks)
+		})
+	}
+}
+
+func TestChecker_Report(t *testing.T) {
+	t.Run("results are cached", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		checker := health.NewChecker(time.Second, time.Minute)
+		checker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+			calls++
+			return nil, nil
+		})
+
+		// Act
+		first := checker.Report(context.Background())
+		second := checker.Report(context.Background())
+
+		// Assert
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, first.CheckedAt, second.CheckedAt)
+	})
+
+	t.Run("results expire", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		checker := health.NewChecker(time.Second, 0)
+		checker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+			calls++
+			return nil, nil
+		})
+
+		// Act
+		checker.Report(context.Background())
+		checker.Report(context.Background())
+
+		// Assert
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("cancelled probe does not fail the checks", func(t *testing.T) {
+		// Arrange
+		checker := health.NewChecker(time.Second, time.Minute)
+		checker.Add("postgres", func(ctx context.Context) (map[string]any, error) {
+			return nil, ctx.Err()
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		report := checker.Report(ctx)
+
+		// Assert
+		assert.Equal(t, health.StatusOK, report.Status)
+	})
+}
+
+func TestChecker_Live(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(time.Second, time.Minute)
+	checker.Add("postgres", down)
+	w := httptest.NewRecorder()
+
+	// Act
+	checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type cleanupService struct {
+	next port.CleanupService
+	m    *Metrics
+}
+
+// NewCleanupService counts the runs of the cleanups of next
+func NewCleanupService(next port.CleanupService, m *Metrics) port.CleanupService {
+	return &cleanupService{next: next, m: m}
+}
+
+// CleanupExpiredFiles counts the run and the files cleaned, even when the run stopped on an error
+func (s *cleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	run, err := s.next.CleanupExpiredFiles(ctx, now)
+	s.observe(domain.CleanupJobExpiredFiles, s.m.FilesReclaimed, run, err)
+	return run, err
+}
+
+// CleanupExpiredSessions counts the run and the reclaimed sessions, even when the run stopped on an error
+func (s *cleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	run, err := s.next.CleanupExpiredSessions(ctx, now)
+	s.observe(domain.CleanupJobExpiredSessions, s.m.SessionsReclaimed, run, err)
+	return run, err
+}
+
+func (s *cleanupService) observe(job string, cleaned prometheus.Counter, run *domain.CleanupRun, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	s.m.CleanupRuns.WithLabelValues(job, result).Inc()
+	if run != nil {
+		cleaned.Add(float64(run.Cleaned))
+	}
+}
@@ -0,0 +1,177 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"score-play/internal/adapters/metrics"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(m *metrics.Metrics) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return w
+}
+
+func TestFileService(t *testing.T) {
+	t.Run("counts initiations by type", func(t *testing.T) {
+		// Arrange
+		m := metrics.New()
+		next := new(file.MockFileService)
+		id := uuid.New()
+		url := "http://minio/upload"
+		expiresAt := time.Now()
+		next.On("RequestUploadFile", mock.Anything, "a.jpg", "image/jpeg", int64(10), "sum", []string(nil)).Return(&id, &url, map[string]string{}, &expiresAt, nil)
+		next.On("RequestUploadMultipartFile", mock.Anything, "b.mp4", "video/mp4", int64(10), "sum", []string(nil)).Return(&id, 5, nil)
+		next.On("RequestUploadMultipartFile", mock.Anything, "c.mp4", "video/mp4", int64(10), "sum", []string(nil)).Return((*uuid.UUID)(nil), 0, domain.ErrFileSizeTooSmall)
+		next.On("RequestResumableUpload", mock.Anything, "d.mp4", "video/mp4", int64(10), "sum", []string(nil)).Return(&id, 5, nil)
+		s := metrics.NewFileService(next, m)
+
+		// Act
+		_, _, _, _, err1 := s.RequestUploadFile(context.Background(), "a.jpg", "image/jpeg", 10, "sum", nil)
+		_, _, err2 := s.RequestUploadMultipartFile(context.Background(), "b.mp4", "video/mp4", 10, "sum", nil)
+		_, _, err3 := s.RequestUploadMultipartFile(context.Background(), "c.mp4", "video/mp4", 10, "sum", nil)
+		_, _, err4 := s.RequestResumableUpload(context.Background(), "d.mp4", "video/mp4", 10, "sum", nil)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.ErrorIs(t, err3, domain.ErrFileSizeTooSmall)
+		assert.NoError(t, err4)
+		body := scrape(m).Body.String()
+		assert.Contains(t, body, `scoreplay_uploads_initiated_total{type="simple"} 1`)
+		assert.Contains(t, body, `scoreplay_uploads_initiated_total{type="multipart"} 2`)
+		next.AssertExpectations(t)
+	})
+
+	t.Run("counts deduplicated uploads", func(t *testing.T) {
+		// Arrange
+		m := metrics.New()
+		next := new(file.MockFileService)
+		id := uuid.New()
+		next.On("DeduplicateUpload", mock.Anything, "a.mp4", "video/mp4", int64(10), "sum", []string(nil)).Return(&id, nil)
+		next.On("DeduplicateUpload", mock.Anything, "b.mp4", "video/mp4", int64(10), "sum", []string(nil)).Return((*uuid.UUID)(nil), nil)
+		s := metrics.NewFileService(next, m)
+
+		// Act
+		_, err1 := s.DeduplicateUpload(context.Background(), "a.mp4", "video/mp4", 10, "sum", nil)
+		_, err2 := s.DeduplicateUpload(context.Background(), "b.mp4", "video/mp4", 10, "sum", nil)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		body := scrape(m).Body.String()
+		assert.Contains(t, body, `scoreplay_uploads_deduplicated_total 1`)
+		next.AssertExpectations(t)
+	})
+
+	t.Run("counts completions, failures and bytes", func(t *testing.T) {
+		// Arrange
+		m := metrics.New()
+		next := new(file.MockFileService)
+		metadata := domain.FileMetadata{ID: uuid.New(), SizeBytes: 100}
+		version := domain.FileVersion{ID: uuid.New(), SizeBytes: 30}
+		next.On("FinalizeUpload", mock.Anything, metadata, nil, domain.EventTypeMultipartUploadComplete).Return(nil)
+		next.On("FinalizeUpload", mock.Anything, metadata, domain.ErrMismatchChecksum, domain.EventTypeSimpleUploadComplete).Return(nil)
+		next.On("FinalizeVersionUpload", mock.Anything, version, nil, domain.EventTypeSimpleUploadComplete).Return(nil)
+		s := metrics.NewFileService(next, m)
+
+		// Act
+		err1 := s.FinalizeUpload(context.Background(), metadata, nil, domain.EventTypeMultipartUploadComplete)
+		err2 := s.FinalizeUpload(context.Background(), metadata, domain.ErrMismatchChecksum, domain.EventTypeSimpleUploadComplete)
+		err3 := s.FinalizeVersionUpload(context.Background(), version, nil, domain.EventTypeSimpleUploadComplete)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NoError(t, err3)
+		body := scrape(m).Body.String()
+		assert.Contains(t, body, `scoreplay_uploads_completed_total{type="multipart"} 1`)
+		assert.Contains(t, body, `scoreplay_uploads_completed_total{type="simple"} 1`)
+		assert.Contains(t, body, `scoreplay_uploads_failed_total{type="simple"} 1`)
+		assert.Contains(t, body, `scoreplay_upload_bytes_accepted_total{type="multipart"} 100`)
+		assert.Contains(t, body, `scoreplay_upload_bytes_accepted_total{type="simple"} 30`)
+		next.AssertExpectations(t)
+	})
+}
+
+type stubCleanupService struct {
+	cleaned int
+	err     error
+}
+
+func (s stubCleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	return &domain.CleanupRun{Cleaned: s.cleaned}, s.err
+}
+
+func (s stubCleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	return &domain.CleanupRun{Cleaned: s.cleaned}, s.err
+}
+
+func TestCleanupService(t *testing.T) {
+	// Arrange
+	m := metrics.New()
+	succeeding := metrics.NewCleanupService(stubCleanupService{cleaned: 2}, m)
+	failing := metrics.NewCleanupService(stubCleanupService{cleaned: 1, err: errors.New("db down")}, m)
+
+	// Act
+	_, err1 := succeeding.CleanupExpiredSessions(context.Background(), time.Now())
+	_, err2 := failing.CleanupExpiredSessions(context.Background(), time.Now())
+	_, err3 := succeeding.CleanupExpiredFiles(context.Background(), time.Now())
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.Error(t, err2)
+	assert.NoError(t, err3)
+	body := scrape(m).Body.String()
+	assert.Contains(t, body, `scoreplay_cleanup_runs_total{job="cleanup_expired_sessions",result="success"} 1`)
+	assert.Contains(t, body, `scoreplay_cleanup_runs_total{job="cleanup_expired_sessions",result="error"} 1`)
+	assert.Contains(t, body, `scoreplay_cleanup_runs_total{job="cleanup_expired_files",result="success"} 1`)
+	assert.Contains(t, body, "scoreplay_cleanup_sessions_reclaimed_total 3")
+	assert.Contains(t, body, "scoreplay_cleanup_files_reclaimed_total 2")
+}
+
+type stubMessageService struct {
+	err error
+}
+
+func (s stubMessageService) HandleMessage(ctx context.Context, data []byte) error {
+	return s.err
+}
+
+func TestMessageService(t *testing.T) {
+	// Arrange
+	m := metrics.New()
+	succeeding := metrics.NewMessageService(stubMessageService{}, m)
+	failing := metrics.NewMessageService(stubMessageService{err: errors.New("not found")}, m)
+	observer, ok := succeeding.(port.DeliveryObserver)
+	require.True(t, ok)
+
+	// Act
+	err1 := succeeding.HandleMessage(context.Background(), nil)
+	err2 := failing.HandleMessage(context.Background(), nil)
+	observer.ObserveDelivery(1, true)
+	observer.ObserveDelivery(1, false)
+	observer.ObserveDelivery(2, true)
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.Error(t, err2)
+	body := scrape(m).Body.String()
+	assert.Contains(t, body, `scoreplay_nats_message_duration_seconds_count{result="success"} 1`)
+	assert.Contains(t, body, `scoreplay_nats_message_duration_seconds_count{result="error"} 1`)
+	assert.Contains(t, body, "scoreplay_nats_messages_acked_total 2")
+	assert.Contains(t, body, "scoreplay_nats_messages_naked_total 1")
+	assert.Contains(t, body, "scoreplay_nats_messages_redelivered_total 1")
+}
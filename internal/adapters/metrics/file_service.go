@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fileService struct {
+	port.FileService
+	m *Metrics
+}
+
+// NewFileService counts the uploads handled by next
+func NewFileService(next port.FileService, m *Metrics) port.FileService {
+	return &fileService{FileService: next, m: m}
+}
+
+// RequestUploadFile counts a simple upload initiation
+func (s *fileService) RequestUploadFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
+	id, url, headers, expiresAt, err := s.FileService.RequestUploadFile(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeSimple).Inc()
+	}
+	return id, url, headers, expiresAt, err
+}
+
+// RequestUploadFilePost counts a simple upload initiation
+func (s *fileService) RequestUploadFilePost(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
+	id, url, fields, expiresAt, err := s.FileService.RequestUploadFilePost(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeSimple).Inc()
+	}
+	return id, url, fields, expiresAt, err
+}
+
+// RequestUploadMultipartFile counts a multipart upload initiation
+func (s *fileService) RequestUploadMultipartFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error) {
+	sessionID, partSize, err := s.FileService.RequestUploadMultipartFile(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeMultipart).Inc()
+	}
+	return sessionID, partSize, err
+}
+
+// DeduplicateUpload counts an upload skipped by sharing an object
+func (s *fileService) DeduplicateUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	fileID, err := s.FileService.DeduplicateUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil && fileID != nil {
+		s.m.UploadsDeduped.Inc()
+	}
+	return fileID, err
+}
+
+// RequestResumableUpload counts a multipart upload initiation
+func (s *fileService) RequestResumableUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error) {
+	sessionID, partSize, err := s.FileService.RequestResumableUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeMultipart).Inc()
+	}
+	return sessionID, partSize, err
+}
+
+// RequestImportFile counts a multipart upload initiation, the worker fetching the content
+func (s *fileService) RequestImportFile(ctx context.Context, sourceURL string, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	fileID, err := s.FileService.RequestImportFile(ctx, sourceURL, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeMultipart).Inc()
+	}
+	return fileID, err
+}
+
+// RequestUploadFileVersion counts a simple upload initiation
+func (s *fileService) RequestUploadFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *string, map[string]string, *time.Time, error) {
+	version, url, headers, expiresAt, err := s.FileService.RequestUploadFileVersion(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeSimple).Inc()
+	}
+	return version, url, headers, expiresAt, err
+}
+
+// RequestUploadMultipartFileVersion counts a multipart upload initiation
+func (s *fileService) RequestUploadMultipartFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *uuid.UUID, int, error) {
+	version, sessionID, partSize, err := s.FileService.RequestUploadMultipartFileVersion(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	if err == nil {
+		s.m.UploadsInitiated.WithLabelValues(UploadTypeMultipart).Inc()
+	}
+	return version, sessionID, partSize, err
+}
+
+// FinalizeUpload counts a completed or failed upload
+func (s *fileService) FinalizeUpload(ctx context.Context, metadata domain.FileMetadata, uploadErr error, eventType domain.EventType) error {
+	err := s.FileService.FinalizeUpload(ctx, metadata, uploadErr, eventType)
+	s.observeFinalize(uploadErr, err, eventType, metadata.SizeBytes)
+	return err
+}
+
+// FinalizeVersionUpload counts a completed or failed upload
+func (s *fileService) FinalizeVersionUpload(ctx context.Context, version domain.FileVersion, uploadErr error, eventType domain.EventType) error {
+	err := s.FileService.FinalizeVersionUpload(ctx, version, uploadErr, eventType)
+	s.observeFinalize(uploadErr, err, eventType, version.SizeBytes)
+	return err
+}
+
+func (s *fileService) observeFinalize(uploadErr error, err error, eventType domain.EventType, sizeBytes int64) {
+	uploadType := UploadTypeSimple
+	if eventType == domain.EventTypeMultipartUploadComplete {
+		uploadType = UploadTypeMultipart
+	}
+	switch {
+	case uploadErr != nil:
+		s.m.UploadsFailed.WithLabelValues(uploadType).Inc()
+	case err == nil:
+		s.m.UploadsCompleted.WithLabelValues(uploadType).Inc()
+		s.m.UploadBytes.WithLabelValues(uploadType).Add(float64(sizeBytes))
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"score-play/internal/core/port"
+	"time"
+)
+
+type messageService struct {
+	next port.MessageService
+	m    *Metrics
+}
+
+// NewMessageService measures the messages handled by next and observes their acknowledgements
+func NewMessageService(next port.MessageService, m *Metrics) port.MessageService {
+	return &messageService{next: next, m: m}
+}
+
+// HandleMessage measures the handling of a message
+func (s *messageService) HandleMessage(ctx context.Context, data []byte) error {
+	start := time.Now()
+	err := s.next.HandleMessage(ctx, data)
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	s.m.MessageDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// ObserveDelivery counts acks, naks and redeliveries
+func (s *messageService) ObserveDelivery(numDelivered uint64, acked bool) {
+	if numDelivered > 1 {
+		s.m.MessageRedelivered.Inc()
+	}
+	if acked {
+		s.m.MessageAcks.Inc()
+		return
+	}
+	s.m.MessageNaks.Inc()
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"score-play/internal/core/port"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Upload types of the upload metrics
+const (
+	UploadTypeSimple    = "simple"
+	UploadTypeMultipart = "multipart"
+)
+
+// Metrics are the series exported by the api and the worker, each only fills the ones it is concerned by
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	HTTPRequestDuration *prometheus.HistogramVec
+
+	UploadsInitiated  *prometheus.CounterVec
+	UploadsCompleted  *prometheus.CounterVec
+	UploadsFailed     *prometheus.CounterVec
+	UploadBytes       *prometheus.CounterVec
+	UploadsDeduped    prometheus.Counter
+	CleanupRuns       *prometheus.CounterVec
+	SessionsReclaimed prometheus.Counter
+	FilesReclaimed    prometheus.Counter
+
+	MessageDuration    *prometheus.HistogramVec
+	MessageAcks        prometheus.Counter
+	MessageNaks        prometheus.Counter
+	MessageRedelivered prometheus.Counter
+}
+
+// New registers the series in a new registry
+func New() *Metrics {
+	r := prometheus.NewRegistry()
+	factory := promauto.With(r)
+	return &Metrics{
+		Registry: r,
+
+		HTTPRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{Name: "scoreplay_http_request_duration_seconds", Help: "Latency of the api requests by route pattern and status.", Buckets: prometheus.DefBuckets}, []string{"method", "route", "status"}),
+
+		UploadsInitiated:  factory.NewCounterVec(prometheus.CounterOpts{Name: "scoreplay_uploads_initiated_total", Help: "Uploads of files and versions accepted by the api, by upload type."}, []string{"type"}),
+		UploadsCompleted:  factory.NewCounterVec(prometheus.CounterOpts{Name: "scoreplay_uploads_completed_total", Help: "Uploads validated by the worker, by upload type."}, []string{"type"}),
+		UploadsFailed:     factory.NewCounterVec(prometheus.CounterOpts{Name: "scoreplay_uploads_failed_total", Help: "Uploads rejected by the worker (checksum, size...), by upload type."}, []string{"type"}),
+		UploadBytes:       factory.NewCounterVec(prometheus.CounterOpts{Name: "scoreplay_upload_bytes_accepted_total", Help: "Bytes of the uploads validated by the worker, by upload type."}, []string{"type"}),
+		UploadsDeduped:    factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_uploads_deduplicated_total", Help: "Uploads skipped by sharing the object of a file with the same content."}),
+		CleanupRuns:       factory.NewCounterVec(prometheus.CounterOpts{Name: "scoreplay_cleanup_runs_total", Help: "Runs of the cleanup jobs, by job and result."}, []string{"job", "result"}),
+		SessionsReclaimed: factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_cleanup_sessions_reclaimed_total", Help: "Expired upload sessions aborted by the cleanup."}),
+		FilesReclaimed:    factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_cleanup_files_reclaimed_total", Help: "Stale uploads failed by the cleanup."}),
+
+		MessageDuration:    factory.NewHistogramVec(prometheus.HistogramOpts{Name: "scoreplay_nats_message_duration_seconds", Help: "Time spent handling a storage event, by result.", Buckets: prometheus.DefBuckets}, []string{"result"}),
+		MessageAcks:        factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_nats_messages_acked_total", Help: "Storage events acknowledged."}),
+		MessageNaks:        factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_nats_messages_naked_total", Help: "Storage events negatively acknowledged, to be redelivered."}),
+		MessageRedelivered: factory.NewCounter(prometheus.CounterOpts{Name: "scoreplay_nats_messages_redelivered_total", Help: "Storage events received more than once."}),
+	}
+}
+
+// WatchDB reads the pool stats of db on each scrape
+func (m *Metrics) WatchDB(db *sql.DB) {
+	factory := promauto.With(m.Registry)
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_open_connections", Help: "Established connections to the database, in use or idle."}, func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_in_use_connections", Help: "Connections currently in use."}, func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_idle_connections", Help: "Idle connections."}, func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_max_open_connections", Help: "Maximum number of open connections."}, func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_wait_count", Help: "Connections waited for since start."}, func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_wait_duration_seconds", Help: "Time blocked waiting for a connection since start."}, func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_max_idle_closed", Help: "Connections closed because of the idle limit since start."}, func() float64 {
+		return float64(db.Stats().MaxIdleClosed)
+	})
+	factory.NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_db_max_lifetime_closed", Help: "Connections closed because of their lifetime since start."}, func() float64 {
+		return float64(db.Stats().MaxLifetimeClosed)
+	})
+}
+
+// WatchSessions counts the open upload sessions of every tenant on each scrape, keeping the last count on errors
+func (m *Metrics) WatchSessions(repo port.UploadSessionRepository) {
+	var last atomic.Int64
+	promauto.With(m.Registry).NewGaugeFunc(prometheus.GaugeOpts{Name: "scoreplay_upload_sessions_open", Help: "Multipart upload sessions still open, expired ones not cleaned up yet included."}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if count, err := repo.CountOpen(ctx); err == nil {
+			last.Store(count)
+		}
+		return float64(last.Load())
+	})
+}
+
+// Handler serves the registry in the Prometheus exposition formats
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe serves the registry at /metrics on addr until ctx is done
+func (m *Metrics) ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate is a token bucket refilling Limit tokens every Period, holding at most Limit tokens
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRate parses "<requests>/<period>", e.g. "60/1m"
+func ParseRate(value string) (Rate, error) {
+	limit, period, found := strings.Cut(value, "/")
+	if !found {
+		return Rate{}, fmt.Errorf("invalid rate %q, expected <requests>/<period>", value)
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q: requests must be a positive integer", value)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q: period must be a positive duration", value)
+	}
+	return Rate{Limit: n, Period: d}, nil
+}
+
+// ParseRules parses the rate of every route group
+func ParseRules(rules map[string]string) (map[string]Rate, error) {
+	rates := make(map[string]Rate, len(rules))
+	for group, value := range rules {
+		rate, err := ParseRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("route group %s: %w", group, err)
+		}
+		rates[group] = rate
+	}
+	return rates, nil
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time until the next token when the request was not allowed
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+}
+
+// Store takes tokens from the bucket of a key
+type Store interface {
+	Take(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+// take refills a bucket holding tokens since last and takes one token from it
+func take(tokens float64, last time.Time, now time.Time, rate Rate) (float64, Result) {
+	perToken := rate.Period / time.Duration(rate.Limit)
+	if elapsed := now.Sub(last); elapsed > 0 {
+		tokens = math.Min(float64(rate.Limit), tokens+float64(elapsed)/float64(perToken))
+	}
+
+	result := Result{Limit: rate.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	result.Remaining = int(tokens)
+	result.Reset = time.Duration((float64(rate.Limit) - tokens) * float64(perToken))
+	return tokens, result
+}
@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Rate
+		wantErr  bool
+	}{
+		{"60/1m", Rate{Limit: 60, Period: time.Minute}, false},
+		{"5/10s", Rate{Limit: 5, Period: 10 * time.Second}, false},
+		{"60", Rate{}, true},
+		{"0/1m", Rate{}, true},
+		{"a/1m", Rate{}, true},
+		{"60/never", Rate{}, true},
+		{"60/-1m", Rate{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rate, err := ParseRate(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rate)
+		})
+	}
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	rate := Rate{Limit: 2, Period: time.Minute}
+
+	t.Run("bucket is drained then refilled", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC)
+		store := NewMemoryStore()
+		store.now = func() time.Time { return now }
+
+		// Act
+		first, _ := store.Take(ctx, "ip:1", rate)
+		second, _ := store.Take(ctx, "ip:1", rate)
+		third, _ := store.Take(ctx, "ip:1", rate)
+		now = now.Add(30 * time.Second)
+		refilled, _ := store.Take(ctx, "ip:1", rate)
+
+		// Assert
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, 30*time.Second, third.RetryAfter)
+		assert.Equal(t, time.Minute, third.Reset)
+		assert.True(t, refilled.Allowed)
+	})
+
+	t.Run("keys have their own bucket", func(t *testing.T) {
+		// Arrange
+		store := NewMemoryStore()
+		_, _ = store.Take(ctx, "ip:1", Rate{Limit: 1, Period: time.Minute})
+
+		// Act
+		result, err := store.Take(ctx, "ip:2", Rate{Limit: 1, Period: time.Minute})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("refilled buckets are swept", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC)
+		store := NewMemoryStore()
+		store.now = func() time.Time { return now }
+		_, _ = store.Take(ctx, "ip:1", rate)
+
+		// Act
+		now = now.Add(2 * time.Minute)
+		_, _ = store.Take(ctx, "ip:2", rate)
+
+		// Assert
+		assert.NotContains(t, store.buckets, "ip:1")
+		assert.Contains(t, store.buckets, "ip:2")
+	})
+}
+
+// fakeScripter emulates the token bucket script with the same state and reply layout
+type fakeScripter struct {
+	state map[string][2]int64
+	keys  []string
+}
+
+func (f *fakeScripter) EvalInts(_ context.Context, script string, keys []string, args ...any) ([]int64, error) {
+	f.keys = append(f.keys, keys...)
+	limit, perToken, now := int64(args[0].(int)), args[1].(int64), args[2].(int64)
+
+	// tokens are kept in microseconds of refill to stay in integers
+	state, ok := f.state[keys[0]]
+	if !ok {
+		state = [2]int64{limit * perToken, now}
+	}
+	tokens := min(limit*perToken, state[0]+now-state[1])
+
+	var allowed, retryAfter int64
+	if tokens >= perToken {
+		tokens -= perToken
+		allowed = 1
+	} else {
+		retryAfter = perToken - tokens
+	}
+	f.state[keys[0]] = [2]int64{tokens, now}
+	return []int64{allowed, tokens / perToken, retryAfter, limit*perToken - tokens}, nil
+}
+
+func TestRedisStore_Take(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC)
+	fake := &fakeScripter{state: map[string][2]int64{}}
+	store := NewRedisStore(fake, "ratelimit:")
+	store.now = func() time.Time { return now }
+	rate := Rate{Limit: 1, Period: time.Minute}
+
+	// Act
+	first, firstErr := store.Take(ctx, "sub:user-1", rate)
+	second, secondErr := store.Take(ctx, "sub:user-1", rate)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.True(t, first.Allowed)
+	assert.False(t, second.Allowed)
+	assert.Equal(t, time.Minute, second.RetryAfter)
+	assert.Equal(t, 0, second.Remaining)
+	assert.Equal(t, []string{"ratelimit:sub:user-1", "ratelimit:sub:user-1"}, fake.keys)
+}
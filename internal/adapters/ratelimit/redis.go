@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Scripter runs a Lua script on a Redis compatible server and returns its integer array reply.
+// A go-redis client fits with client.Eval(ctx, script, keys, args...).Int64Slice().
+type Scripter interface {
+	EvalInts(ctx context.Context, script string, keys []string, args ...any) ([]int64, error)
+}
+
+// tokenBucketScript mirrors take. Times are in microseconds, the bucket expires once it is full again.
+// It returns allowed, remaining, retry after and reset.
+const tokenBucketScript = `
+local limit = tonumber(ARGV[1])
+local per_token = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1]) or limit
+local last = tonumber(state[2]) or now
+if now > last then
+  tokens = math.min(limit, tokens + (now - last) / per_token)
+end
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_after = math.ceil((1 - tokens) * per_token)
+end
+local reset = math.ceil((limit - tokens) * per_token)
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', now)
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil(reset / 1000)))
+return {allowed, math.floor(tokens), retry_after, reset}
+`
+
+// RedisStore keeps the buckets in Redis so that every api instance shares them
+type RedisStore struct {
+	client Scripter
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisStore creates a RedisStore, keys are prefixed with prefix
+func NewRedisStore(client Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, now: time.Now}
+}
+
+// Take takes a token from the bucket of key atomically
+func (s *RedisStore) Take(ctx context.Context, key string, rate Rate) (Result, error) {
+	perToken := rate.Period / time.Duration(rate.Limit)
+	reply, err := s.client.EvalInts(ctx, tokenBucketScript, []string{s.prefix + key},
+		rate.Limit, perToken.Microseconds(), s.now().UnixMicro())
+	if err != nil {
+		return Result{}, fmt.Errorf("error taking rate limit token: %w", err)
+	}
+	if len(reply) != 4 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	return Result{
+		Allowed:    reply[0] == 1,
+		Limit:      rate.Limit,
+		Remaining:  int(reply[1]),
+		RetryAfter: time.Duration(reply[2]) * time.Microsecond,
+		Reset:      time.Duration(reply[3]) * time.Microsecond,
+	}, nil
+}
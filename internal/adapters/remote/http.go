@@ -0,0 +1,99 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"syscall"
+	"time"
+)
+
+// maxRedirects is the number of redirects followed before giving up
+const maxRedirects = 5
+
+// sharedAddressSpace is the carrier grade nat range, not covered by netip.Addr.IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+type httpSource struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+// NewHTTPSource creates a source fetching http and https urls of the allowed hosts of cfg.
+// Every connection, redirects included, is checked once resolved, so hosts resolving to private, loopback or
+// link-local addresses are refused unless cfg.AllowPrivateNetworks. Proxies are never used.
+func NewHTTPSource(cfg config.ImportConfig) port.RemoteSource {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = refusePrivateAddresses
+	}
+	s := &httpSource{allowedHosts: cfg.AllowedHosts}
+	s.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 30 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: s.checkRedirect,
+	}
+	return s
+}
+
+// Open sends a GET request to rawURL. Responses other than 200 fail with domain.ErrSourceUnavailable.
+func (s *httpSource) Open(ctx context.Context, rawURL string) (io.ReadCloser, int64, error) {
+	u, err := domain.CheckImportURL(rawURL, s.allowedHosts)
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrSourceNotAllowed) {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("%w: %w", domain.ErrSourceUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, 0, fmt.Errorf("%w: %s answered %s", domain.ErrSourceUnavailable, u.Redacted(), resp.Status)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// checkRedirect only follows redirects to allowed hosts
+func (s *httpSource) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", domain.ErrSourceUnavailable, maxRedirects)
+	}
+	_, err := domain.CheckImportURL(req.URL.String(), s.allowedHosts)
+	return err
+}
+
+// refusePrivateAddresses is a dialer control refusing addresses that are not publicly routable.
+// It runs on the resolved address, so dns answers cannot point an allowed host at the internal network.
+func refusePrivateAddresses(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrSourceNotAllowed, err)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s is not a public address", domain.ErrSourceNotAllowed, addr)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package remote_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"score-play/internal/adapters/remote"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves content at /match.mp4, redirects /redirect to target and loops on /loop
+func newServer(t *testing.T, content string, target string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/match.mp4", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, content)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target, http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPSource_Open(t *testing.T) {
+	server := newServer(t, "content", "")
+	host := hostOf(t, server.URL)
+	local := config.ImportConfig{AllowedHosts: []string{host}, AllowPrivateNetworks: true}
+
+	t.Run("streams the content with its size", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(local)
+
+		// Act
+		body, size, err := source.Open(context.Background(), server.URL+"/match.mp4")
+
+		// Assert
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(len("content")), size)
+	})
+
+	t.Run("refuses private addresses", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(config.ImportConfig{AllowedHosts: []string{host}})
+
+		// Act
+		_, _, err := source.Open(context.Background(), server.URL+"/match.mp4")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrSourceNotAllowed)
+	})
+
+	t.Run("refuses hosts not allowed", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(config.ImportConfig{AllowedHosts: []string{"media.example.com"}, AllowPrivateNetworks: true})
+
+		// Act
+		_, _, err := source.Open(context.Background(), server.URL+"/match.mp4")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrSourceNotAllowed)
+	})
+
+	t.Run("fails on error statuses", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(local)
+
+		// Act
+		_, _, err := source.Open(context.Background(), server.URL+"/missing.mp4")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrSourceUnavailable)
+	})
+
+	t.Run("stops redirect loops", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(local)
+
+		// Act
+		_, _, err := source.Open(context.Background(), server.URL+"/loop")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrSourceUnavailable)
+	})
+}
+
+func TestHTTPSource_Open_Redirect(t *testing.T) {
+	target := newServer(t, "redirected", "")
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+	// the target is reached as localhost, a host the redirecting server is not reached as
+	redirectTarget := "http://localhost:" + targetURL.Port() + "/match.mp4"
+	server := newServer(t, "", redirectTarget)
+
+	t.Run("follows redirects to allowed hosts", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(config.ImportConfig{AllowedHosts: []string{"127.0.0.1", "localhost"}, AllowPrivateNetworks: true})
+
+		// Act
+		body, _, err := source.Open(context.Background(), server.URL+"/redirect")
+
+		// Assert
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "redirected", string(data))
+	})
+
+	t.Run("refuses redirects to other hosts", func(t *testing.T) {
+		// Arrange
+		source := remote.NewHTTPSource(config.ImportConfig{AllowedHosts: []string{"127.0.0.1"}, AllowPrivateNetworks: true})
+
+		// Act
+		_, _, err := source.Open(context.Background(), server.URL+"/redirect")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrSourceNotAllowed)
+	})
+}
+
+// hostOf is the host name of rawURL, without its port
+func hostOf(t *testing.T, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u.Hostname()
+}
@@ -56,8 +56,18 @@ func NewMockFileRepository() *MockFileRepository {
 	return &MockFileRepository{}
 }
 
-func (m *MockFileRepository) Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, storageKey string) error {
-	args := m.Called(ctx, id, fileName, mimeType, mediaType, size, status, checksum, storageKey)
+func (m *MockFileRepository) Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, bucket string, storageKey string, ownerID *string) error {
+	args := m.Called(ctx, id, fileName, mimeType, mediaType, size, status, checksum, bucket, storageKey, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) CreateCopy(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, bucket string, storageKey string, ownerID *string) error {
+	args := m.Called(ctx, id, source, fileName, bucket, storageKey, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) CreateShared(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, ownerID *string) error {
+	args := m.Called(ctx, id, source, fileName, ownerID)
 	return args.Error(0)
 }
 
@@ -71,16 +81,51 @@ func (m *MockFileRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 	return args.Error(0)
 }
 
+func (m *MockFileRepository) UpdateStorageClass(ctx context.Context, id uuid.UUID, storageClass string) error {
+	args := m.Called(ctx, id, storageClass)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) UpdateChecksum(ctx context.Context, id uuid.UUID, checksum string) error {
+	args := m.Called(ctx, id, checksum)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) SetCurrentVersion(ctx context.Context, id uuid.UUID, version domain.FileVersion) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
 func (m *MockFileRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockFileRepository) FindExpired(ctx context.Context, expirationTime time.Time) ([]domain.FileMetadata, error) {
-	args := m.Called(ctx, expirationTime)
+func (m *MockFileRepository) FindExpired(ctx context.Context, expirationTime time.Time, after uuid.UUID, limit int) ([]domain.FileMetadata, error) {
+	args := m.Called(ctx, expirationTime, after, limit)
+	return args.Get(0).([]domain.FileMetadata), args.Error(1)
+}
+
+func (m *MockFileRepository) FindByStatus(ctx context.Context, status domain.FileStatus, after uuid.UUID, limit int) ([]domain.FileMetadata, error) {
+	args := m.Called(ctx, status, after, limit)
+	return args.Get(0).([]domain.FileMetadata), args.Error(1)
+}
+
+func (m *MockFileRepository) ExistsByStorageKey(ctx context.Context, bucket string, storageKey string) (bool, error) {
+	args := m.Called(ctx, bucket, storageKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFileRepository) FindDuplicates(ctx context.Context, checksum string, sizeBytes int64, mimeType string, ownerID *string, limit int) ([]domain.FileMetadata, error) {
+	args := m.Called(ctx, checksum, sizeBytes, mimeType, ownerID, limit)
 	return args.Get(0).([]domain.FileMetadata), args.Error(1)
 }
 
+func (m *MockFileRepository) CountReferences(ctx context.Context, bucket string, storageKey string, excludeID uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucket, storageKey, excludeID)
+	return args.Int(0), args.Error(1)
+}
+
 type MockUploadSessionRepository struct {
 	mock.Mock
 }
@@ -99,6 +144,21 @@ func (m *MockUploadSessionRepository) UpdateExpiresAt(ctx context.Context, id uu
 	return args.Error(0)
 }
 
+func (m *MockUploadSessionRepository) UpdateOffset(ctx context.Context, id uuid.UUID, offset int64, checksumState []byte) error {
+	args := m.Called(ctx, id, offset, checksumState)
+	return args.Error(0)
+}
+
+func (m *MockUploadSessionRepository) AcquireLease(ctx context.Context, id uuid.UUID, holder uuid.UUID, now time.Time, until time.Time) error {
+	args := m.Called(ctx, id, holder, now, until)
+	return args.Error(0)
+}
+
+func (m *MockUploadSessionRepository) ReleaseLease(ctx context.Context, id uuid.UUID, holder uuid.UUID) error {
+	args := m.Called(ctx, id, holder)
+	return args.Error(0)
+}
+
 func (m *MockUploadSessionRepository) OpenSessionExists(ctx context.Context, id uuid.UUID) (bool, error) {
 	args := m.Called(ctx, id)
 	return args.Bool(0), args.Error(1)
@@ -134,11 +194,31 @@ func (m *MockUploadSessionRepository) FindByFileID(ctx context.Context, fileID u
 	return args.Get(0).(*domain.UploadSession), args.Error(1)
 }
 
-func (m *MockUploadSessionRepository) FindAllExpired(ctx context.Context, now time.Time) ([]domain.UploadSession, error) {
-	args := m.Called(ctx, now)
+func (m *MockUploadSessionRepository) FindByVersionID(ctx context.Context, versionID uuid.UUID) (*domain.UploadSession, error) {
+	args := m.Called(ctx, versionID)
+	return args.Get(0).(*domain.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) FindByProviderUploadID(ctx context.Context, providerUploadID string) (*domain.UploadSession, error) {
+	args := m.Called(ctx, providerUploadID)
+	return args.Get(0).(*domain.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) FindAllExpired(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]domain.UploadSession, error) {
+	args := m.Called(ctx, now, after, limit)
 	return args.Get(0).([]domain.UploadSession), args.Error(1)
 }
 
+func (m *MockUploadSessionRepository) ClaimExpired(ctx context.Context, id uuid.UUID, now time.Time) (*domain.UploadSession, error) {
+	args := m.Called(ctx, id, now)
+	return args.Get(0).(*domain.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) CountOpen(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadSessionStatus) error {
 	args := m.Called(ctx, id, status)
 	return args.Error(0)
@@ -168,12 +248,162 @@ func (m *MockFileTagRepository) CreateMany(ctx context.Context, fileID uuid.UUID
 	return args.Int(0), args.Error(1)
 }
 
+type MockFileVersionRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileVersionRepository) Create(ctx context.Context, version domain.FileVersion) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *MockFileVersionRepository) NextVersion(ctx context.Context, fileID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fileID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFileVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.FileVersion, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.FileVersion), args.Error(1)
+}
+
+func (m *MockFileVersionRepository) FindByFileIDAndVersion(ctx context.Context, fileID uuid.UUID, version int) (*domain.FileVersion, error) {
+	args := m.Called(ctx, fileID, version)
+	return args.Get(0).(*domain.FileVersion), args.Error(1)
+}
+
+func (m *MockFileVersionRepository) ListByFileID(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error) {
+	args := m.Called(ctx, fileID)
+	return args.Get(0).([]domain.FileVersion), args.Error(1)
+}
+
+func (m *MockFileVersionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockUsageRepository) Lock(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockUsageRepository) Get(ctx context.Context, ownerID *string) (*domain.Usage, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).(*domain.Usage), args.Error(1)
+}
+
+type MockCleanupRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockCleanupRunRepository) Create(ctx context.Context, run domain.CleanupRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+type MockFileImportRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileImportRepository) Create(ctx context.Context, fileImport domain.FileImport) error {
+	args := m.Called(ctx, fileImport)
+	return args.Error(0)
+}
+
+func (m *MockFileImportRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, error) {
+	args := m.Called(ctx, fileID)
+	return args.Get(0).(*domain.FileImport), args.Error(1)
+}
+
+func (m *MockFileImportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.FileImport, error) {
+	args := m.Called(ctx, staleBefore)
+	return args.Get(0).(*domain.FileImport), args.Error(1)
+}
+
+func (m *MockFileImportRepository) UpdateProgress(ctx context.Context, fileID uuid.UUID, bytesReceived int64) error {
+	args := m.Called(ctx, fileID, bytesReceived)
+	return args.Error(0)
+}
+
+func (m *MockFileImportRepository) UpdateStatus(ctx context.Context, fileID uuid.UUID, status domain.ImportStatus, errMsg string) error {
+	args := m.Called(ctx, fileID, status, errMsg)
+	return args.Error(0)
+}
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key domain.APIKey, hash string) error {
+	args := m.Called(ctx, key, hash)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, hash)
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Expire(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+type MockJobLock struct {
+	mock.Mock
+}
+
+func NewMockJobLock() *MockJobLock {
+	return &MockJobLock{}
+}
+
+// TryRun runs fn when the expectation reports the lock as taken, and returns its error
+func (m *MockJobLock) TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error) {
+	args := m.Called(ctx, job)
+	if !args.Bool(0) || args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return true, fn(ctx)
+}
+
 type MockUnitOfWork struct {
 	mock.Mock
 	tagRepo           *MockTagRepository
 	fileRepo          *MockFileRepository
 	uploadSessionRepo *MockUploadSessionRepository
 	fileTagRepository *MockFileTagRepository
+	fileVersionRepo   *MockFileVersionRepository
+	usageRepo         *MockUsageRepository
+	cleanupRunRepo    *MockCleanupRunRepository
+	fileImportRepo    *MockFileImportRepository
 }
 
 func NewMockUnitOfWork() *MockUnitOfWork {
@@ -182,6 +412,10 @@ func NewMockUnitOfWork() *MockUnitOfWork {
 		fileRepo:          &MockFileRepository{},
 		uploadSessionRepo: &MockUploadSessionRepository{},
 		fileTagRepository: &MockFileTagRepository{},
+		fileVersionRepo:   &MockFileVersionRepository{},
+		usageRepo:         &MockUsageRepository{},
+		cleanupRunRepo:    &MockCleanupRunRepository{},
+		fileImportRepo:    &MockFileImportRepository{},
 	}
 }
 
@@ -201,6 +435,22 @@ func (m *MockUnitOfWork) UploadSessionRepo() port.UploadSessionRepository {
 	return m.uploadSessionRepo
 }
 
+func (m *MockUnitOfWork) FileVersionRepo() port.FileVersionRepository {
+	return m.fileVersionRepo
+}
+
+func (m *MockUnitOfWork) UsageRepo() port.UsageRepository {
+	return m.usageRepo
+}
+
+func (m *MockUnitOfWork) CleanupRunRepo() port.CleanupRunRepository {
+	return m.cleanupRunRepo
+}
+
+func (m *MockUnitOfWork) FileImportRepo() port.FileImportRepository {
+	return m.fileImportRepo
+}
+
 func (m *MockUnitOfWork) Execute(ctx context.Context, fn func(uow port.UnitOfWork) error) error {
 	args := m.Called(ctx, fn)
 
@@ -226,3 +476,19 @@ func (m *MockUnitOfWork) GetUploadSessionRepoMock() *MockUploadSessionRepository
 func (m *MockUnitOfWork) GetFileTagRepoMock() *MockFileTagRepository {
 	return m.fileTagRepository
 }
+
+func (m *MockUnitOfWork) GetFileVersionRepoMock() *MockFileVersionRepository {
+	return m.fileVersionRepo
+}
+
+func (m *MockUnitOfWork) GetUsageRepoMock() *MockUsageRepository {
+	return m.usageRepo
+}
+
+func (m *MockUnitOfWork) GetCleanupRunRepoMock() *MockCleanupRunRepository {
+	return m.cleanupRunRepo
+}
+
+func (m *MockUnitOfWork) GetFileImportRepoMock() *MockFileImportRepository {
+	return m.fileImportRepo
+}
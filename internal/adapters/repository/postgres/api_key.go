@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const apiKeyColumns = `id, subject_id, tenant_id, name, prefix, scopes, created_by, expires_at, last_used_at, revoked_at, created_at`
+
+type sqlAPIKeyRepository struct {
+	db SQLQuerier
+}
+
+// NewSqlAPIKeyRepository creates sqlAPIKeyRepository that implements port.APIKeyRepository
+func NewSqlAPIKeyRepository(db SQLQuerier) port.APIKeyRepository {
+	return &sqlAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Create stores a new api key with the hash of its secret
+func (s *sqlAPIKeyRepository) Create(ctx context.Context, key domain.APIKey, hash string) error {
+	query := `
+		INSERT INTO api_key (id, subject_id, tenant_id, name, prefix, hash, scopes, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		key.ID,
+		key.SubjectID,
+		domain.TenantOrDefault(ctx),
+		key.Name,
+		key.Prefix,
+		hash,
+		pq.Array(key.Scopes),
+		key.CreatedBy,
+		key.ExpiresAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("api key %s : %w", key.ID, domain.ErrAlreadyExists)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByID finds an api key of the tenant by id
+func (s *sqlAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+	return s.findOne(ctx, query, id, tenantArg(ctx))
+}
+
+// FindByHash finds an api key by the hash of its secret, whatever its tenant when ctx is not scoped
+func (s *sqlAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE hash = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+	return s.findOne(ctx, query, hash, tenantArg(ctx))
+}
+
+func (s *sqlAPIKeyRepository) findOne(ctx context.Context, query string, args ...any) (*domain.APIKey, error) {
+	var keyDB dbAPIKey
+	err := keyDB.scan(s.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return keyDB.ToDomain(), nil
+}
+
+// List lists the api keys of the tenant, newest first
+func (s *sqlAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE ($1::text IS NULL OR tenant_id = $1) ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, tenantArg(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error querying api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		var keyDB dbAPIKey
+		if err := keyDB.scan(rows); err != nil {
+			return nil, fmt.Errorf("error scanning api key: %w", err)
+		}
+		keys = append(keys, *keyDB.ToDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes an api key at the given time, keys already revoked keep their revocation time
+func (s *sqlAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE api_key SET revoked_at = COALESCE(revoked_at, $3) WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+	return s.update(ctx, query, id, tenantArg(ctx), at)
+}
+
+// Expire moves the expiry of an api key to the given time unless it expires earlier
+func (s *sqlAPIKeyRepository) Expire(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE api_key SET expires_at = LEAST(expires_at, $3) WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+	return s.update(ctx, query, id, tenantArg(ctx), at)
+}
+
+// TouchLastUsed records the last time an api key authenticated a request
+func (s *sqlAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE api_key SET last_used_at = $3 WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+	return s.update(ctx, query, id, tenantArg(ctx), at)
+}
+
+func (s *sqlAPIKeyRepository) update(ctx context.Context, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// dbAPIKey represents an api key in DB
+type dbAPIKey struct {
+	ID         uuid.UUID      `db:"id"`
+	SubjectID  uuid.UUID      `db:"subject_id"`
+	TenantID   string         `db:"tenant_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	Scopes     pq.StringArray `db:"scopes"`
+	CreatedBy  sql.NullString `db:"created_by"`
+	ExpiresAt  sql.NullTime   `db:"expires_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (k *dbAPIKey) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(
+		&k.ID,
+		&k.SubjectID,
+		&k.TenantID,
+		&k.Name,
+		&k.Prefix,
+		&k.Scopes,
+		&k.CreatedBy,
+		&k.ExpiresAt,
+		&k.LastUsedAt,
+		&k.RevokedAt,
+		&k.CreatedAt,
+	)
+}
+
+// ToDomain converts to domain.APIKey
+func (k *dbAPIKey) ToDomain() *domain.APIKey {
+	key := &domain.APIKey{
+		ID:        k.ID,
+		SubjectID: k.SubjectID,
+		TenantID:  k.TenantID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt,
+	}
+	if k.CreatedBy.Valid {
+		key.CreatedBy = &k.CreatedBy.String
+	}
+	if k.ExpiresAt.Valid {
+		key.ExpiresAt = &k.ExpiresAt.Time
+	}
+	if k.LastUsedAt.Valid {
+		key.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		key.RevokedAt = &k.RevokedAt.Time
+	}
+	return key
+}
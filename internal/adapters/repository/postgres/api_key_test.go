@@ -0,0 +1,142 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlAPIKeyRepository(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	repo := postgres.NewSqlAPIKeyRepository(dbConnection)
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+
+	newKey := func() domain.APIKey {
+		createdBy := "user-1"
+		id := uuid.New()
+		return domain.APIKey{
+			ID:        id,
+			SubjectID: id,
+			Name:      "camera-1",
+			Prefix:    "sk_abcdefgh",
+			Scopes:    []string{domain.ScopeUpload, domain.ScopeRead},
+			CreatedBy: &createdBy,
+		}
+	}
+
+	t.Run("Create and FindByHash", func(t *testing.T) {
+		// Arrange
+		truncate()
+		key := newKey()
+
+		// Act
+		err := repo.Create(acme, key, "hash-1")
+		found, findErr := repo.FindByHash(context.Background(), "hash-1")
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, findErr)
+		assert.Equal(t, key.ID, found.ID)
+		assert.Equal(t, key.SubjectID, found.SubjectID)
+		assert.Equal(t, "acme", found.TenantID)
+		assert.Equal(t, key.Scopes, found.Scopes)
+		assert.Equal(t, "user-1", *found.CreatedBy)
+		assert.Nil(t, found.ExpiresAt)
+	})
+
+	t.Run("Create - duplicate hash", func(t *testing.T) {
+		// Arrange
+		truncate()
+		require.NoError(t, repo.Create(acme, newKey(), "hash-1"))
+
+		// Act
+		err := repo.Create(acme, newKey(), "hash-1")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	})
+
+	t.Run("tenant cannot see another tenant's keys", func(t *testing.T) {
+		// Arrange
+		truncate()
+		key := newKey()
+		require.NoError(t, repo.Create(acme, key, "hash-1"))
+
+		// Act
+		_, findErr := repo.FindByID(globex, key.ID)
+		list, listErr := repo.List(globex)
+		revokeErr := repo.Revoke(globex, key.ID, time.Now())
+
+		// Assert
+		assert.ErrorIs(t, findErr, domain.ErrAPIKeyNotFound)
+		require.NoError(t, listErr)
+		assert.Empty(t, list)
+		assert.ErrorIs(t, revokeErr, domain.ErrAPIKeyNotFound)
+	})
+
+	t.Run("Revoke keeps the first revocation time", func(t *testing.T) {
+		// Arrange
+		truncate()
+		key := newKey()
+		require.NoError(t, repo.Create(acme, key, "hash-1"))
+		first := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+
+		// Act
+		require.NoError(t, repo.Revoke(acme, key.ID, first))
+		require.NoError(t, repo.Revoke(acme, key.ID, time.Now()))
+		found, err := repo.FindByID(acme, key.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, found.RevokedAt.Equal(first))
+	})
+
+	t.Run("Expire never extends the expiry", func(t *testing.T) {
+		// Arrange
+		truncate()
+		soon := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		later := soon.Add(time.Hour)
+		withExpiry := newKey()
+		withExpiry.ExpiresAt = &soon
+		withoutExpiry := newKey()
+		require.NoError(t, repo.Create(acme, withExpiry, "hash-1"))
+		require.NoError(t, repo.Create(acme, withoutExpiry, "hash-2"))
+
+		// Act
+		require.NoError(t, repo.Expire(acme, withExpiry.ID, later))
+		require.NoError(t, repo.Expire(acme, withoutExpiry.ID, later))
+		first, err := repo.FindByID(acme, withExpiry.ID)
+		require.NoError(t, err)
+		second, err := repo.FindByID(acme, withoutExpiry.ID)
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, first.ExpiresAt.Equal(soon))
+		assert.True(t, second.ExpiresAt.Equal(later))
+	})
+
+	t.Run("TouchLastUsed", func(t *testing.T) {
+		// Arrange
+		truncate()
+		key := newKey()
+		require.NoError(t, repo.Create(acme, key, "hash-1"))
+		now := time.Now().Truncate(time.Microsecond)
+
+		// Act
+		err := repo.TouchLastUsed(context.Background(), key.ID, now)
+		found, findErr := repo.FindByHash(context.Background(), "hash-1")
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, findErr)
+		assert.True(t, found.LastUsedAt.Equal(now))
+	})
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+)
+
+type sqlCleanupRunRepository struct {
+	db SQLQuerier
+}
+
+// NewSqlCleanupRunRepository creates sqlCleanupRunRepository that implements port.CleanupRunRepository
+func NewSqlCleanupRunRepository(db SQLQuerier) port.CleanupRunRepository {
+	return &sqlCleanupRunRepository{
+		db: db,
+	}
+}
+
+// Create stores the summary of a cleanup run
+func (s *sqlCleanupRunRepository) Create(ctx context.Context, run domain.CleanupRun) error {
+	query := `
+		INSERT INTO cleanup_run (id, job, started_at, finished_at, found, cleaned, failed, budget_exceeded, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		run.ID,
+		run.Job,
+		run.StartedAt,
+		run.FinishedAt,
+		run.Found,
+		run.Cleaned,
+		run.Failed,
+		run.BudgetExceeded,
+		run.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving cleanup run: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlCleanupRunRepository(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	repo := postgres.NewSqlCleanupRunRepository(dbConnection)
+
+	t.Run("Create - Stores the summary of the run", func(t *testing.T) {
+		// Arrange
+		truncate()
+		message := "database error"
+		startedAt := time.Now().Add(-time.Minute).Round(time.Microsecond)
+		run := domain.CleanupRun{
+			ID:             uuid.New(),
+			Job:            domain.CleanupJobExpiredFiles,
+			StartedAt:      startedAt,
+			FinishedAt:     startedAt.Add(time.Minute),
+			Found:          10,
+			Cleaned:        7,
+			Failed:         2,
+			BudgetExceeded: true,
+			Error:          &message,
+		}
+
+		// Act
+		err := repo.Create(ctx, run)
+
+		// Assert
+		require.NoError(t, err)
+		var stored domain.CleanupRun
+		err = dbConnection.QueryRowContext(ctx, `
+			SELECT id, job, started_at, finished_at, found, cleaned, failed, budget_exceeded, error
+			FROM cleanup_run WHERE id = $1`, run.ID).Scan(
+			&stored.ID, &stored.Job, &stored.StartedAt, &stored.FinishedAt,
+			&stored.Found, &stored.Cleaned, &stored.Failed, &stored.BudgetExceeded, &stored.Error,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, run.Job, stored.Job)
+		assert.True(t, run.StartedAt.Equal(stored.StartedAt))
+		assert.Equal(t, 10, stored.Found)
+		assert.Equal(t, 7, stored.Cleaned)
+		assert.Equal(t, 2, stored.Failed)
+		assert.True(t, stored.BudgetExceeded)
+		require.NotNil(t, stored.Error)
+		assert.Equal(t, message, *stored.Error)
+	})
+}
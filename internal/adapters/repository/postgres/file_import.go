@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const fileImportColumns = `file_id, session_id, owner_id, source_url, status, size_bytes, bytes_received, attempts, error, created_at, updated_at`
+
+type sqlFileImportRepository struct {
+	db SQLQuerier
+}
+
+// NewSqlFileImportRepository creates sqlFileImportRepository that implements port.FileImportRepository
+func NewSqlFileImportRepository(db SQLQuerier) port.FileImportRepository {
+	return &sqlFileImportRepository{db: db}
+}
+
+// Create stores an import in the tenant of ctx
+func (s *sqlFileImportRepository) Create(ctx context.Context, fileImport domain.FileImport) error {
+	query := `
+		INSERT INTO file_import (file_id, session_id, tenant_id, owner_id, source_url, status, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		fileImport.FileID,
+		fileImport.SessionID,
+		domain.TenantOrDefault(ctx),
+		fileImport.OwnerID,
+		fileImport.SourceURL,
+		fileImport.Status,
+		fileImport.SizeBytes,
+	)
+	return err
+}
+
+// FindByFileID returns the import of the file fileID
+func (s *sqlFileImportRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, error) {
+	query := `SELECT ` + fileImportColumns + ` FROM file_import WHERE file_id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+
+	return scanFileImport(s.db.QueryRowContext(ctx, query, fileID, tenantArg(ctx)))
+}
+
+// ClaimNext starts a new attempt of the oldest pending import, or of a running one its worker stopped updating.
+// Imports locked by another worker are skipped.
+func (s *sqlFileImportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.FileImport, error) {
+	query := `
+		UPDATE file_import
+		SET status = 'running', attempts = attempts + 1, bytes_received = 0, error = NULL, updated_at = now()
+		WHERE file_id = (
+			SELECT file_id FROM file_import
+			WHERE status = 'pending' OR (status = 'running' AND updated_at < $1)
+			ORDER BY updated_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + fileImportColumns
+
+	return scanFileImport(s.db.QueryRowContext(ctx, query, staleBefore))
+}
+
+// UpdateProgress records the bytes a running import has received
+func (s *sqlFileImportRepository) UpdateProgress(ctx context.Context, fileID uuid.UUID, bytesReceived int64) error {
+	query := `UPDATE file_import SET bytes_received = $1, updated_at = now() WHERE file_id = $2 AND status = 'running'`
+
+	return s.exec(ctx, query, bytesReceived, fileID)
+}
+
+// UpdateStatus updates the status of an import, errMsg is stored when not empty
+func (s *sqlFileImportRepository) UpdateStatus(ctx context.Context, fileID uuid.UUID, status domain.ImportStatus, errMsg string) error {
+	query := `UPDATE file_import SET status = $1, error = NULLIF($2, ''), updated_at = now() WHERE file_id = $3`
+
+	return s.exec(ctx, query, status, errMsg, fileID)
+}
+
+// exec runs an update of a single import, domain.ErrImportNotFound if it matched none
+func (s *sqlFileImportRepository) exec(ctx context.Context, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrImportNotFound
+	}
+
+	return nil
+}
+
+func scanFileImport(row *sql.Row) (*domain.FileImport, error) {
+	var fileImport domain.FileImport
+	var errMsg sql.NullString
+	err := row.Scan(
+		&fileImport.FileID,
+		&fileImport.SessionID,
+		&fileImport.OwnerID,
+		&fileImport.SourceURL,
+		&fileImport.Status,
+		&fileImport.SizeBytes,
+		&fileImport.BytesReceived,
+		&fileImport.Attempts,
+		&errMsg,
+		&fileImport.CreatedAt,
+		&fileImport.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrImportNotFound
+		}
+		return nil, err
+	}
+	fileImport.Error = errMsg.String
+	return &fileImport, nil
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlFileImportRepository(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	repo := postgres.NewSqlFileImportRepository(dbConnection)
+
+	newImport := func() domain.FileImport {
+		owner := "user-1"
+		return domain.FileImport{
+			FileID:    uuid.New(),
+			SessionID: uuid.New(),
+			OwnerID:   &owner,
+			SourceURL: "https://media.example.com/match.mp4",
+			Status:    domain.ImportStatusPending,
+			SizeBytes: 1024,
+		}
+	}
+
+	t.Run("Create - Stores a pending import", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileImport := newImport()
+
+		// Act
+		err := repo.Create(ctx, fileImport)
+
+		// Assert
+		require.NoError(t, err)
+		stored, err := repo.FindByFileID(ctx, fileImport.FileID)
+		require.NoError(t, err)
+		assert.Equal(t, fileImport.SessionID, stored.SessionID)
+		assert.Equal(t, "user-1", *stored.OwnerID)
+		assert.Equal(t, fileImport.SourceURL, stored.SourceURL)
+		assert.Equal(t, domain.ImportStatusPending, stored.Status)
+		assert.Equal(t, int64(1024), stored.SizeBytes)
+		assert.Zero(t, stored.Attempts)
+	})
+
+	t.Run("FindByFileID - Scoped to the tenant", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileImport := newImport()
+		require.NoError(t, repo.Create(domain.ContextWithTenant(ctx, "tenant-a"), fileImport))
+
+		// Act
+		_, err := repo.FindByFileID(domain.ContextWithTenant(ctx, "tenant-b"), fileImport.FileID)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrImportNotFound)
+	})
+
+	t.Run("ClaimNext - Starts an attempt of the pending import", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileImport := newImport()
+		require.NoError(t, repo.Create(ctx, fileImport))
+
+		// Act
+		claimed, err := repo.ClaimNext(ctx, time.Now().Add(-time.Minute))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, fileImport.FileID, claimed.FileID)
+		assert.Equal(t, domain.ImportStatusRunning, claimed.Status)
+		assert.Equal(t, 1, claimed.Attempts)
+		_, err = repo.ClaimNext(ctx, time.Now().Add(-time.Minute))
+		assert.ErrorIs(t, err, domain.ErrImportNotFound)
+	})
+
+	t.Run("ClaimNext - Retries a stale running import", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileImport := newImport()
+		require.NoError(t, repo.Create(ctx, fileImport))
+		_, err := repo.ClaimNext(ctx, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateProgress(ctx, fileImport.FileID, 512))
+
+		// Act
+		claimed, err := repo.ClaimNext(ctx, time.Now().Add(time.Minute))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, claimed.Attempts)
+		assert.Zero(t, claimed.BytesReceived)
+	})
+
+	t.Run("UpdateStatus - Records the failure", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileImport := newImport()
+		require.NoError(t, repo.Create(ctx, fileImport))
+
+		// Act
+		err := repo.UpdateStatus(ctx, fileImport.FileID, domain.ImportStatusFailed, "source unavailable")
+
+		// Assert
+		require.NoError(t, err)
+		stored, err := repo.FindByFileID(ctx, fileImport.FileID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportStatusFailed, stored.Status)
+		assert.Equal(t, "source unavailable", stored.Error)
+		assert.ErrorIs(t, repo.UpdateProgress(ctx, fileImport.FileID, 10), domain.ErrImportNotFound)
+	})
+}
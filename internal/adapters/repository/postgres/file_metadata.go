@@ -24,24 +24,53 @@ func NewSqlFileRepository(db SQLQuerier) port.FileRepository {
 }
 
 // Create creates new file entry
-func (s *sqlFileRepository) Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, storageKey string) error {
-	query := `INSERT INTO file_metadata (id, filename, mime_type, file_type, size_bytes, status, checksum, storage_key) 
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+func (s *sqlFileRepository) Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, bucket string, storageKey string, ownerID *string) error {
+	query := `INSERT INTO file_metadata (id, filename, mime_type, file_type, size_bytes, status, checksum, bucket, storage_key, owner_id, tenant_id) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
-	_, err := s.db.ExecContext(ctx, query, id, fileName, mimeType, mediaType, size, status, checksum, storageKey)
+	_, err := s.db.ExecContext(ctx, query, id, fileName, mimeType, mediaType, size, status, checksum, bucket, storageKey, ownerID,
+		domain.TenantOrDefault(ctx))
 	if err != nil {
 		return fmt.Errorf("error inserting file metadata: %w", err)
 	}
 	return nil
 }
 
+// CreateCopy creates a completed file entry copied from a source file
+func (s *sqlFileRepository) CreateCopy(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, bucket string, storageKey string, ownerID *string) error {
+	query := `INSERT INTO file_metadata (id, filename, mime_type, file_type, size_bytes, status, checksum, bucket, storage_key, source_file_id, owner_id, tenant_id) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := s.db.ExecContext(ctx, query, id, fileName, source.MimeType, source.MediaType, source.SizeBytes,
+		domain.FileStatusCompleted, source.Checksum, bucket, storageKey, source.ID, ownerID, domain.TenantOrDefault(ctx))
+	if err != nil {
+		return fmt.Errorf("error inserting file copy metadata: %w", err)
+	}
+	return nil
+}
+
+// CreateShared creates a completed file entry stored in the object of a source file with the same content.
+// The files only share the bucket and storage key: source_file_id is the lineage of copies, the source may belong to another user.
+func (s *sqlFileRepository) CreateShared(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, ownerID *string) error {
+	query := `INSERT INTO file_metadata (id, filename, mime_type, file_type, size_bytes, status, checksum, bucket, storage_key, storage_class, owner_id, tenant_id) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := s.db.ExecContext(ctx, query, id, fileName, source.MimeType, source.MediaType, source.SizeBytes,
+		domain.FileStatusCompleted, source.Checksum, source.Bucket, source.StorageKey, source.StorageClass, ownerID,
+		domain.TenantOrDefault(ctx))
+	if err != nil {
+		return fmt.Errorf("error inserting shared file metadata: %w", err)
+	}
+	return nil
+}
+
 // UpdateStatus updates status
 func (s *sqlFileRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) error {
 	query := `UPDATE file_metadata 
               SET status = $1, updated_at = now()
-              WHERE id = $2`
+              WHERE id = $2 AND ($3::text IS NULL OR tenant_id = $3)`
 
-	result, err := s.db.ExecContext(ctx, query, status, id)
+	result, err := s.db.ExecContext(ctx, query, status, id, tenantArg(ctx))
 	if err != nil {
 		return fmt.Errorf("error updating file metadata: %w", err)
 	}
@@ -58,12 +87,84 @@ func (s *sqlFileRepository) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 	return nil
 }
 
+// UpdateStorageClass updates the storage class of the file object, on every file sharing it
+func (s *sqlFileRepository) UpdateStorageClass(ctx context.Context, id uuid.UUID, storageClass string) error {
+	query := `UPDATE file_metadata 
+              SET storage_class = $1, updated_at = now()
+              WHERE deleted_at IS NULL AND ($3::text IS NULL OR tenant_id = $3)
+                AND (id = $2 OR (bucket, storage_key) = (SELECT bucket, storage_key FROM file_metadata WHERE id = $2))`
+
+	result, err := s.db.ExecContext(ctx, query, storageClass, id, tenantArg(ctx))
+	if err != nil {
+		return fmt.Errorf("error updating file storage class: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrFileMetadataNotFound
+	}
+
+	return nil
+}
+
+// UpdateChecksum sets the checksum of a file that has none
+func (s *sqlFileRepository) UpdateChecksum(ctx context.Context, id uuid.UUID, checksum string) error {
+	query := `UPDATE file_metadata 
+              SET checksum = $1, updated_at = now()
+              WHERE id = $2 AND checksum = '' AND deleted_at IS NULL AND ($3::text IS NULL OR tenant_id = $3)`
+
+	result, err := s.db.ExecContext(ctx, query, checksum, id, tenantArg(ctx))
+	if err != nil {
+		return fmt.Errorf("error updating file checksum: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrFileMetadataNotFound
+	}
+
+	return nil
+}
+
+// SetCurrentVersion points the file to a validated version
+func (s *sqlFileRepository) SetCurrentVersion(ctx context.Context, id uuid.UUID, version domain.FileVersion) error {
+	query := `UPDATE file_metadata 
+              SET current_version = $1, filename = $2, mime_type = $3, size_bytes = $4, bucket = $5,
+                  storage_key = $6, checksum = $7, storage_class = $8, updated_at = now()
+              WHERE id = $9 AND deleted_at IS NULL AND ($10::text IS NULL OR tenant_id = $10)`
+
+	result, err := s.db.ExecContext(ctx, query, version.Version, version.Filename, version.MimeType, version.SizeBytes,
+		version.Bucket, version.StorageKey, version.Checksum, domain.StorageClassStandard, id, tenantArg(ctx))
+	if err != nil {
+		return fmt.Errorf("error updating file current version: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrFileMetadataNotFound
+	}
+
+	return nil
+}
+
 // Delete soft deletes
 func (s *sqlFileRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
-	query := `UPDATE file_metadata SET deleted_at = now() WHERE id = $1`
+	query := `UPDATE file_metadata SET deleted_at = now() WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
 
-	result, err := s.db.ExecContext(ctx, query, id)
+	result, err := s.db.ExecContext(ctx, query, id, tenantArg(ctx))
 	if err != nil {
 		return fmt.Errorf("error updating file metadata: %w", err)
 	}
@@ -79,21 +180,27 @@ func (s *sqlFileRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // FindById finds by id
 func (s *sqlFileRepository) FindById(ctx context.Context, id uuid.UUID) (*domain.FileMetadata, error) {
-	query := `SELECT id, filename, mime_type, file_type, size_bytes, storage_key, 
-                     checksum, status, created_at, updated_at, deleted_at
+	query := `SELECT id, filename, mime_type, file_type, size_bytes, bucket, storage_key, 
+                     checksum, status, storage_class, current_version, source_file_id, owner_id, tenant_id, created_at, updated_at, deleted_at
               FROM file_metadata
-              WHERE id = $1 AND deleted_at IS NULL`
+              WHERE id = $1 AND deleted_at IS NULL AND ($2::text IS NULL OR tenant_id = $2)`
 
 	var dbFile dbFileMetadata
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := s.db.QueryRowContext(ctx, query, id, tenantArg(ctx)).Scan(
 		&dbFile.ID,
 		&dbFile.Name,
 		&dbFile.MimeType,
 		&dbFile.MediaType,
 		&dbFile.Size,
+		&dbFile.Bucket,
 		&dbFile.StorageKey,
 		&dbFile.Checksum,
 		&dbFile.Status,
+		&dbFile.StorageClass,
+		&dbFile.CurrentVersion,
+		&dbFile.SourceFileID,
+		&dbFile.OwnerID,
+		&dbFile.TenantID,
 		&dbFile.CreatedAt,
 		&dbFile.UpdatedAt,
 		&dbFile.DeletedAt,
@@ -109,20 +216,110 @@ func (s *sqlFileRepository) FindById(ctx context.Context, id uuid.UUID) (*domain
 	return dbFile.ToDomain(), nil
 }
 
-// FindExpired finds expired uploads
-func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time.Time) ([]domain.FileMetadata, error) {
+// FindExpired finds a page of the uploads not updated since expirationTime, ordered by id after the given one
+func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time.Time, after uuid.UUID, limit int) ([]domain.FileMetadata, error) {
 	query := `
-		SELECT id, filename, mime_type, file_type, size_bytes, storage_key, 
-		       checksum, status, created_at, updated_at, deleted_at
+		SELECT id, filename, mime_type, file_type, size_bytes, bucket, storage_key, 
+		       checksum, status, storage_class, current_version, source_file_id, owner_id, tenant_id, created_at, updated_at, deleted_at
 		FROM file_metadata
 		WHERE status = 'uploading' 
 		  AND updated_at < $1 
-		  AND deleted_at IS NULL`
+		  AND deleted_at IS NULL
+		  AND ($2::text IS NULL OR tenant_id = $2)
+		  AND id > $3
+		ORDER BY id
+		LIMIT $4`
 
-	rows, err := s.db.QueryContext(ctx, query, expirationTime)
+	rows, err := s.db.QueryContext(ctx, query, expirationTime, tenantArg(ctx), after, limit)
 	if err != nil {
 		return nil, fmt.Errorf("error querying expired files: %w", err)
 	}
+	return scanFiles(rows)
+}
+
+// FindByStatus finds a page of the files with status, deleted ones included, ordered by id after the given one
+func (s *sqlFileRepository) FindByStatus(ctx context.Context, status domain.FileStatus, after uuid.UUID, limit int) ([]domain.FileMetadata, error) {
+	query := `
+		SELECT id, filename, mime_type, file_type, size_bytes, bucket, storage_key, 
+		       checksum, status, storage_class, current_version, source_file_id, owner_id, tenant_id, created_at, updated_at, deleted_at
+		FROM file_metadata
+		WHERE status = $1
+		  AND ($2::text IS NULL OR tenant_id = $2)
+		  AND id > $3
+		ORDER BY id
+		LIMIT $4`
+
+	rows, err := s.db.QueryContext(ctx, query, status, tenantArg(ctx), after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying files by status: %w", err)
+	}
+	return scanFiles(rows)
+}
+
+// ExistsByStorageKey reports whether a file or one of its versions, deleted or not, is stored at storageKey.
+// Rows without bucket are in the default bucket, so they match any bucket.
+func (s *sqlFileRepository) ExistsByStorageKey(ctx context.Context, bucket string, storageKey string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM file_metadata WHERE storage_key = $2 AND (bucket = $1 OR bucket = '') AND ($3::text IS NULL OR tenant_id = $3)
+		) OR EXISTS (
+			SELECT 1 FROM file_version v JOIN file_metadata f ON f.id = v.file_id
+			WHERE v.storage_key = $2 AND (v.bucket = $1 OR v.bucket = '') AND ($3::text IS NULL OR f.tenant_id = $3)
+		)`
+
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, query, bucket, storageKey, tenantArg(ctx)).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error checking file storage key: %w", err)
+	}
+	return exists, nil
+}
+
+// FindDuplicates finds the completed files with the same content, the ones of ownerID first
+func (s *sqlFileRepository) FindDuplicates(ctx context.Context, checksum string, sizeBytes int64, mimeType string, ownerID *string, limit int) ([]domain.FileMetadata, error) {
+	query := `
+		SELECT id, filename, mime_type, file_type, size_bytes, bucket, storage_key, 
+		       checksum, status, storage_class, current_version, source_file_id, owner_id, tenant_id, created_at, updated_at, deleted_at
+		FROM file_metadata
+		WHERE checksum = $1
+		  AND size_bytes = $2
+		  AND mime_type = $3
+		  AND status = 'completed'
+		  AND deleted_at IS NULL
+		  AND ($4::text IS NULL OR tenant_id = $4)
+		ORDER BY owner_id IS NOT DISTINCT FROM $5 DESC, created_at
+		LIMIT $6`
+
+	rows, err := s.db.QueryContext(ctx, query, checksum, sizeBytes, mimeType, tenantArg(ctx), ownerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying duplicate files: %w", err)
+	}
+	return scanFiles(rows)
+}
+
+// CountReferences counts the live files other than excludeID whose current object or a completed version is stored at storageKey.
+// Rows without bucket are in the default bucket, so they match any bucket.
+func (s *sqlFileRepository) CountReferences(ctx context.Context, bucket string, storageKey string, excludeID uuid.UUID) (int, error) {
+	query := `
+		SELECT count(*) FROM file_metadata f
+		WHERE f.id <> $3
+		  AND f.deleted_at IS NULL
+		  AND f.status <> 'failed'
+		  AND ($4::text IS NULL OR f.tenant_id = $4)
+		  AND ((f.storage_key = $2 AND (f.bucket = $1 OR f.bucket = ''))
+		    OR EXISTS (
+		        SELECT 1 FROM file_version v
+		        WHERE v.file_id = f.id AND v.status = 'completed' AND v.storage_key = $2 AND (v.bucket = $1 OR v.bucket = '')
+		    ))`
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, query, bucket, storageKey, excludeID, tenantArg(ctx)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting storage key references: %w", err)
+	}
+	return count, nil
+}
+
+// scanFiles reads the file_metadata rows selected by FindExpired, FindByStatus and FindDuplicates
+func scanFiles(rows *sql.Rows) ([]domain.FileMetadata, error) {
 	defer rows.Close()
 
 	var files []domain.FileMetadata
@@ -130,6 +327,8 @@ func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time
 		var f domain.FileMetadata
 		var deletedAt sql.NullTime
 		var checksum sql.NullString
+		var sourceFileID uuid.NullUUID
+		var ownerID sql.NullString
 
 		err := rows.Scan(
 			&f.ID,
@@ -137,9 +336,15 @@ func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time
 			&f.MimeType,
 			&f.MediaType,
 			&f.SizeBytes,
+			&f.Bucket,
 			&f.StorageKey,
 			&checksum,
 			&f.Status,
+			&f.StorageClass,
+			&f.CurrentVersion,
+			&sourceFileID,
+			&ownerID,
+			&f.TenantID,
 			&f.CreatedAt,
 			&f.UpdatedAt,
 			&deletedAt,
@@ -154,6 +359,12 @@ func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time
 		if deletedAt.Valid {
 			f.DeletedAt = &deletedAt.Time
 		}
+		if sourceFileID.Valid {
+			f.SourceFileID = &sourceFileID.UUID
+		}
+		if ownerID.Valid {
+			f.OwnerID = &ownerID.String
+		}
 
 		files = append(files, f)
 	}
@@ -167,32 +378,52 @@ func (s *sqlFileRepository) FindExpired(ctx context.Context, expirationTime time
 
 // dbFileMetadata represents file metadata in DB
 type dbFileMetadata struct {
-	ID         uuid.UUID  `db:"id"`
-	Name       string     `db:"filename"`
-	MimeType   string     `db:"mime_type"`
-	MediaType  string     `db:"file_type"`
-	Size       int64      `db:"size_bytes"`
-	StorageKey string     `db:"storage_key"`
-	Checksum   string     `db:"checksum"`
-	Status     string     `db:"status"`
-	CreatedAt  time.Time  `db:"created_at"`
-	UpdatedAt  time.Time  `db:"updated_at"`
-	DeletedAt  *time.Time `db:"deleted_at"`
+	ID             uuid.UUID      `db:"id"`
+	Name           string         `db:"filename"`
+	MimeType       string         `db:"mime_type"`
+	MediaType      string         `db:"file_type"`
+	Size           int64          `db:"size_bytes"`
+	Bucket         string         `db:"bucket"`
+	StorageKey     string         `db:"storage_key"`
+	Checksum       string         `db:"checksum"`
+	Status         string         `db:"status"`
+	StorageClass   string         `db:"storage_class"`
+	CurrentVersion int            `db:"current_version"`
+	SourceFileID   uuid.NullUUID  `db:"source_file_id"`
+	OwnerID        sql.NullString `db:"owner_id"`
+	TenantID       string         `db:"tenant_id"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+	DeletedAt      *time.Time     `db:"deleted_at"`
 }
 
 // ToDomain converts to domain.FileStatus
 func (f *dbFileMetadata) ToDomain() *domain.FileMetadata {
+	var sourceFileID *uuid.UUID
+	if f.SourceFileID.Valid {
+		sourceFileID = &f.SourceFileID.UUID
+	}
+	var ownerID *string
+	if f.OwnerID.Valid {
+		ownerID = &f.OwnerID.String
+	}
 	return &domain.FileMetadata{
-		ID:         f.ID,
-		Filename:   f.Name,
-		MimeType:   f.MimeType,
-		MediaType:  f.MediaType,
-		SizeBytes:  f.Size,
-		StorageKey: f.StorageKey,
-		Checksum:   f.Checksum,
-		Status:     domain.FileStatus(f.Status),
-		CreatedAt:  f.CreatedAt,
-		UpdatedAt:  f.UpdatedAt,
-		DeletedAt:  f.DeletedAt,
+		ID:             f.ID,
+		Filename:       f.Name,
+		MimeType:       f.MimeType,
+		MediaType:      f.MediaType,
+		SizeBytes:      f.Size,
+		Bucket:         f.Bucket,
+		StorageKey:     f.StorageKey,
+		Checksum:       f.Checksum,
+		Status:         domain.FileStatus(f.Status),
+		StorageClass:   f.StorageClass,
+		CurrentVersion: f.CurrentVersion,
+		SourceFileID:   sourceFileID,
+		OwnerID:        ownerID,
+		TenantID:       f.TenantID,
+		CreatedAt:      f.CreatedAt,
+		UpdatedAt:      f.UpdatedAt,
+		DeletedAt:      f.DeletedAt,
 	}
 }
@@ -23,7 +23,7 @@ func TestSqlFileRepository(t *testing.T) {
 		fileID := uuid.New()
 
 		// Act
-		err := repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "key")
+		err := repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", nil)
 
 		// Assert
 		require.NoError(t, err)
@@ -31,13 +31,31 @@ func TestSqlFileRepository(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, fileID, file.ID)
 		require.Equal(t, "test.mp4", file.Filename)
+		require.Equal(t, "bucket", file.Bucket)
+	})
+
+	t.Run("Create - With owner", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		ownerID := "user-1"
+
+		// Act
+		err := repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", &ownerID)
+
+		// Assert
+		require.NoError(t, err)
+		file, err := repo.FindById(ctx, fileID)
+		require.NoError(t, err)
+		require.NotNil(t, file.OwnerID)
+		require.Equal(t, ownerID, *file.OwnerID)
 	})
 
 	t.Run("UpdateStatus - Success", func(t *testing.T) {
 		// Arrange
 		truncate()
 		fileID := uuid.New()
-		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "key")
+		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", nil)
 
 		// Act
 		err := repo.UpdateStatus(ctx, fileID, domain.FileStatusCompleted)
@@ -59,11 +77,40 @@ func TestSqlFileRepository(t *testing.T) {
 		require.ErrorIs(t, err, domain.ErrFileMetadataNotFound)
 	})
 
+	t.Run("UpdateStorageClass - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+
+		// Act
+		err := repo.UpdateStorageClass(ctx, fileID, "GLACIER")
+
+		// Assert
+		require.NoError(t, err)
+		file, _ := repo.FindById(ctx, fileID)
+		require.Equal(t, "GLACIER", file.StorageClass)
+	})
+
+	t.Run("Create - Default Storage Class", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+
+		// Act
+		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", nil)
+
+		// Assert
+		file, err := repo.FindById(ctx, fileID)
+		require.NoError(t, err)
+		require.Equal(t, domain.StorageClassStandard, file.StorageClass)
+	})
+
 	t.Run("Delete (Soft Delete) - Success", func(t *testing.T) {
 		// Arrange
 		truncate()
 		fileID := uuid.New()
-		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "key")
+		_ = repo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", nil)
 
 		// Act
 		err := repo.Delete(ctx, fileID)
@@ -92,11 +139,11 @@ func TestSqlFileRepository(t *testing.T) {
 		expiredID := uuid.New()
 		recentID := uuid.New()
 
-		_ = repo.Create(ctx, expiredID, "old.mp4", "video/mp4", domain.FileTypeVideo, 100, domain.FileStatusUploading, "sum1", "key1")
-		_ = repo.Create(ctx, recentID, "new.mp4", "video/mp4", domain.FileTypeVideo, 100, domain.FileStatusUploading, "sum2", "key2")
+		_ = repo.Create(ctx, expiredID, "old.mp4", "video/mp4", domain.FileTypeVideo, 100, domain.FileStatusUploading, "sum1", "bucket", "key1", nil)
+		_ = repo.Create(ctx, recentID, "new.mp4", "video/mp4", domain.FileTypeVideo, 100, domain.FileStatusUploading, "sum2", "bucket", "key2", nil)
 
 		// Act
-		files, err := repo.FindExpired(ctx, time.Now().Add(time.Minute))
+		files, err := repo.FindExpired(ctx, time.Now().Add(time.Minute), uuid.Nil, 100)
 
 		// Assert
 		require.NoError(t, err)
@@ -110,4 +157,191 @@ func TestSqlFileRepository(t *testing.T) {
 		}
 		require.True(t, found)
 	})
+
+	t.Run("CreateCopy - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		sourceID := uuid.New()
+		copyID := uuid.New()
+		_ = repo.Create(ctx, sourceID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+		source, err := repo.FindById(ctx, sourceID)
+		require.NoError(t, err)
+
+		// Act
+		err = repo.CreateCopy(ctx, copyID, *source, "clip.mp4", "bucket", "copy-key", nil)
+
+		// Assert
+		require.NoError(t, err)
+		file, err := repo.FindById(ctx, copyID)
+		require.NoError(t, err)
+		require.Equal(t, "clip.mp4", file.Filename)
+		require.Equal(t, domain.FileStatusCompleted, file.Status)
+		require.Equal(t, "sum", file.Checksum)
+		require.NotNil(t, file.SourceFileID)
+		require.Equal(t, sourceID, *file.SourceFileID)
+	})
+
+	t.Run("FindByStatus - Pages by id", func(t *testing.T) {
+		// Arrange
+		truncate()
+		for i := 0; i < 3; i++ {
+			_ = repo.Create(ctx, uuid.New(), "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+		}
+		_ = repo.Create(ctx, uuid.New(), "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "key", nil)
+
+		// Act
+		first, err := repo.FindByStatus(ctx, domain.FileStatusCompleted, uuid.Nil, 2)
+		require.NoError(t, err)
+		second, err := repo.FindByStatus(ctx, domain.FileStatusCompleted, first[1].ID, 2)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		require.Len(t, second, 1)
+		require.Equal(t, domain.FileStatusCompleted, second[0].Status)
+	})
+
+	t.Run("ExistsByStorageKey - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		_ = repo.Create(ctx, fileID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "stored-key", nil)
+		_ = repo.Delete(ctx, fileID)
+
+		// Act
+		deleted, err := repo.ExistsByStorageKey(ctx, "bucket", "stored-key")
+		require.NoError(t, err)
+		anyBucket, err := repo.ExistsByStorageKey(ctx, "", "stored-key")
+		require.NoError(t, err)
+		otherBucket, err := repo.ExistsByStorageKey(ctx, "other", "stored-key")
+		require.NoError(t, err)
+		unknown, err := repo.ExistsByStorageKey(ctx, "bucket", "unknown-key")
+
+		// Assert
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.True(t, anyBucket)
+		require.False(t, otherBucket)
+		require.False(t, unknown)
+	})
+
+	t.Run("CreateShared - Shares the source object", func(t *testing.T) {
+		// Arrange
+		truncate()
+		sourceID := uuid.New()
+		sharedID := uuid.New()
+		ownerID := "user-2"
+		_ = repo.Create(ctx, sourceID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+		_ = repo.UpdateStorageClass(ctx, sourceID, "GLACIER")
+		source, err := repo.FindById(ctx, sourceID)
+		require.NoError(t, err)
+
+		// Act
+		err = repo.CreateShared(ctx, sharedID, *source, "replay.mp4", &ownerID)
+
+		// Assert
+		require.NoError(t, err)
+		file, err := repo.FindById(ctx, sharedID)
+		require.NoError(t, err)
+		require.Equal(t, "replay.mp4", file.Filename)
+		require.Equal(t, domain.FileStatusCompleted, file.Status)
+		require.Equal(t, "bucket", file.Bucket)
+		require.Equal(t, "key", file.StorageKey)
+		require.Equal(t, "GLACIER", file.StorageClass)
+		require.Nil(t, file.SourceFileID)
+		require.Equal(t, ownerID, *file.OwnerID)
+	})
+
+	t.Run("UpdateChecksum - Only sets a missing checksum", func(t *testing.T) {
+		// Arrange
+		truncate()
+		withoutID := uuid.New()
+		withID := uuid.New()
+		_ = repo.Create(ctx, withoutID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "", "bucket", "a", nil)
+		_ = repo.Create(ctx, withID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "b", nil)
+
+		// Act
+		err := repo.UpdateChecksum(ctx, withoutID, "computed")
+		declaredErr := repo.UpdateChecksum(ctx, withID, "computed")
+
+		// Assert
+		require.NoError(t, err)
+		require.ErrorIs(t, declaredErr, domain.ErrFileMetadataNotFound)
+		file, err := repo.FindById(ctx, withoutID)
+		require.NoError(t, err)
+		require.Equal(t, "computed", file.Checksum)
+		file, err = repo.FindById(ctx, withID)
+		require.NoError(t, err)
+		require.Equal(t, "sum", file.Checksum)
+	})
+
+	t.Run("UpdateStorageClass - Updates the files sharing the object", func(t *testing.T) {
+		// Arrange
+		truncate()
+		sourceID := uuid.New()
+		sharedID := uuid.New()
+		_ = repo.Create(ctx, sourceID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+		source, err := repo.FindById(ctx, sourceID)
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateShared(ctx, sharedID, *source, "replay.mp4", nil))
+
+		// Act
+		err = repo.UpdateStorageClass(ctx, sourceID, "GLACIER")
+
+		// Assert
+		require.NoError(t, err)
+		file, err := repo.FindById(ctx, sharedID)
+		require.NoError(t, err)
+		require.Equal(t, "GLACIER", file.StorageClass)
+	})
+
+	t.Run("FindDuplicates - Completed files of the owner first", func(t *testing.T) {
+		// Arrange
+		truncate()
+		ownerID := "user-1"
+		otherOwner := "user-2"
+		otherID := uuid.New()
+		ownID := uuid.New()
+		_ = repo.Create(ctx, otherID, "a.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "a", &otherOwner)
+		_ = repo.Create(ctx, ownID, "b.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "b", &ownerID)
+		_ = repo.Create(ctx, uuid.New(), "c.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusUploading, "sum", "bucket", "c", &ownerID)
+		_ = repo.Create(ctx, uuid.New(), "d.mp4", "video/mp4", domain.FileTypeVideo, 2048, domain.FileStatusCompleted, "sum", "bucket", "d", &ownerID)
+		deletedID := uuid.New()
+		_ = repo.Create(ctx, deletedID, "e.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "e", &ownerID)
+		_ = repo.Delete(ctx, deletedID)
+
+		// Act
+		files, err := repo.FindDuplicates(ctx, "sum", 1024, "video/mp4", &ownerID, 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		require.Equal(t, ownID, files[0].ID)
+		require.Equal(t, otherID, files[1].ID)
+	})
+
+	t.Run("CountReferences - Live files and versions using the object", func(t *testing.T) {
+		// Arrange
+		truncate()
+		sourceID := uuid.New()
+		_ = repo.Create(ctx, sourceID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "key", nil)
+		source, err := repo.FindById(ctx, sourceID)
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateShared(ctx, uuid.New(), *source, "replay.mp4", nil))
+		failedID := uuid.New()
+		_ = repo.Create(ctx, failedID, "match.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusFailed, "sum", "bucket", "key", nil)
+
+		// Act
+		others, err := repo.CountReferences(ctx, "bucket", "key", sourceID)
+		require.NoError(t, err)
+		all, err := repo.CountReferences(ctx, "bucket", "key", uuid.Nil)
+		require.NoError(t, err)
+		unknown, err := repo.CountReferences(ctx, "bucket", "unknown-key", uuid.Nil)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, 1, others)
+		require.Equal(t, 2, all)
+		require.Equal(t, 0, unknown)
+	})
 }
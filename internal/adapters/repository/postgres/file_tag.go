@@ -79,9 +79,9 @@ func (s *sqlFileTagRepository) CreateMany(ctx context.Context, fileID uuid.UUID,
 
 // FindByFileID finds all tags for a file
 func (s *sqlFileTagRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) ([]domain.FileTag, error) {
-	query := `SELECT file_id, tag_id FROM file_metadata_tags WHERE file_id = $1`
+	query := `SELECT file_id, tag_id FROM file_metadata_tags WHERE file_id = $1 AND ` + fileInTenant
 
-	rows, err := s.db.QueryContext(ctx, query, fileID)
+	rows, err := s.db.QueryContext(ctx, query, fileID, tenantArg(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("error querying file tags: %w", err)
 	}
@@ -105,9 +105,9 @@ func (s *sqlFileTagRepository) FindByFileID(ctx context.Context, fileID uuid.UUI
 
 // DeleteByFileID removes all tag associations for a given file
 func (s *sqlFileTagRepository) DeleteByFileID(ctx context.Context, fileID uuid.UUID) error {
-	query := `DELETE FROM file_metadata_tags WHERE file_id = $1`
+	query := `DELETE FROM file_metadata_tags WHERE file_id = $1 AND ` + fileInTenant
 
-	_, err := s.db.ExecContext(ctx, query, fileID)
+	_, err := s.db.ExecContext(ctx, query, fileID, tenantArg(ctx))
 	if err != nil {
 		return fmt.Errorf("error deleting file tags: %w", err)
 	}
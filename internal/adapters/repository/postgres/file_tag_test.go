@@ -29,7 +29,9 @@ func TestSqlFileTagRepository(t *testing.T) {
 			1024*1024,
 			domain.FileStatusUploading,
 			"checksum-"+id.String(),
+			"bucket",
 			"temp/path/"+id.String(),
+			nil,
 		)
 		require.NoError(t, err)
 	}
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type sqlFileVersionRepository struct {
+	db SQLQuerier
+}
+
+// NewSqlFileVersionRepository creates sqlFileVersionRepository that implements port.FileVersionRepository
+func NewSqlFileVersionRepository(db SQLQuerier) port.FileVersionRepository {
+	return &sqlFileVersionRepository{
+		db: db,
+	}
+}
+
+const fileVersionColumns = `id, file_id, version, filename, mime_type, size_bytes, bucket, storage_key,
+                     checksum, status, created_at, updated_at`
+
+// Create creates a new file version
+func (s *sqlFileVersionRepository) Create(ctx context.Context, version domain.FileVersion) error {
+	query := `INSERT INTO file_version (id, file_id, version, filename, mime_type, size_bytes, bucket, storage_key, checksum, status)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := s.db.ExecContext(ctx, query, version.ID, version.FileID, version.Version, version.Filename, version.MimeType,
+		version.SizeBytes, version.Bucket, version.StorageKey, version.Checksum, version.Status)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				return fmt.Errorf("file version %d : %w", version.Version, domain.ErrAlreadyExists)
+			}
+		}
+		return fmt.Errorf("error inserting file version: %w", err)
+	}
+	return nil
+}
+
+// NextVersion returns the next version number of a file.
+// It locks the file row, so concurrent callers inside transactions wait for each other's version to be created.
+func (s *sqlFileVersionRepository) NextVersion(ctx context.Context, fileID uuid.UUID) (int, error) {
+	if _, err := s.db.ExecContext(ctx, `SELECT 1 FROM file_metadata WHERE id = $1 FOR UPDATE`, fileID); err != nil {
+		return 0, fmt.Errorf("error locking file for next version: %w", err)
+	}
+
+	query := `SELECT COALESCE(MAX(version), 0) + 1 FROM file_version WHERE file_id = $1 AND ` + fileInTenant
+
+	var next int
+	if err := s.db.QueryRowContext(ctx, query, fileID, tenantArg(ctx)).Scan(&next); err != nil {
+		return 0, fmt.Errorf("error computing next file version: %w", err)
+	}
+	return next, nil
+}
+
+// FindByID finds by id
+func (s *sqlFileVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.FileVersion, error) {
+	query := `SELECT ` + fileVersionColumns + `
+              FROM file_version
+              WHERE id = $1 AND ` + fileInTenant
+
+	return s.findOne(ctx, query, id, tenantArg(ctx))
+}
+
+// FindByFileIDAndVersion finds a version of a file by its number
+func (s *sqlFileVersionRepository) FindByFileIDAndVersion(ctx context.Context, fileID uuid.UUID, version int) (*domain.FileVersion, error) {
+	query := `SELECT ` + fileVersionColumns + `
+              FROM file_version
+              WHERE file_id = $1 AND version = $3 AND ` + fileInTenant
+
+	return s.findOne(ctx, query, fileID, tenantArg(ctx), version)
+}
+
+// ListByFileID lists the versions of a file, newest first
+func (s *sqlFileVersionRepository) ListByFileID(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error) {
+	query := `SELECT ` + fileVersionColumns + `
+              FROM file_version
+              WHERE file_id = $1 AND ` + fileInTenant + `
+              ORDER BY version DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, fileID, tenantArg(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error querying file versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make([]domain.FileVersion, 0)
+	for rows.Next() {
+		var row dbFileVersion
+		if err := row.scan(rows); err != nil {
+			return nil, fmt.Errorf("error scanning file version: %w", err)
+		}
+		versions = append(versions, *row.ToDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating file versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// UpdateStatus updates status
+func (s *sqlFileVersionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) error {
+	query := `UPDATE file_version
+              SET status = $1, updated_at = now()
+              WHERE id = $2 AND ($3::text IS NULL OR EXISTS (
+                  SELECT 1 FROM file_metadata f WHERE f.id = file_version.file_id AND f.tenant_id = $3))`
+
+	result, err := s.db.ExecContext(ctx, query, status, id, tenantArg(ctx))
+	if err != nil {
+		return fmt.Errorf("error updating file version: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrFileVersionNotFound
+	}
+
+	return nil
+}
+
+func (s *sqlFileVersionRepository) findOne(ctx context.Context, query string, args ...any) (*domain.FileVersion, error) {
+	var row dbFileVersion
+	if err := row.scan(s.db.QueryRowContext(ctx, query, args...)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrFileVersionNotFound
+		}
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
+
+// dbFileVersion represents a file version in DB
+type dbFileVersion struct {
+	ID         uuid.UUID      `db:"id"`
+	FileID     uuid.UUID      `db:"file_id"`
+	Version    int            `db:"version"`
+	Name       string         `db:"filename"`
+	MimeType   string         `db:"mime_type"`
+	Size       int64          `db:"size_bytes"`
+	Bucket     string         `db:"bucket"`
+	StorageKey string         `db:"storage_key"`
+	Checksum   sql.NullString `db:"checksum"`
+	Status     string         `db:"status"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func (f *dbFileVersion) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(
+		&f.ID,
+		&f.FileID,
+		&f.Version,
+		&f.Name,
+		&f.MimeType,
+		&f.Size,
+		&f.Bucket,
+		&f.StorageKey,
+		&f.Checksum,
+		&f.Status,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+}
+
+// ToDomain converts to domain.FileVersion
+func (f *dbFileVersion) ToDomain() *domain.FileVersion {
+	return &domain.FileVersion{
+		ID:         f.ID,
+		FileID:     f.FileID,
+		Version:    f.Version,
+		Filename:   f.Name,
+		MimeType:   f.MimeType,
+		SizeBytes:  f.Size,
+		Bucket:     f.Bucket,
+		StorageKey: f.StorageKey,
+		Checksum:   f.Checksum.String,
+		Status:     domain.FileStatus(f.Status),
+		CreatedAt:  f.CreatedAt,
+		UpdatedAt:  f.UpdatedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlFileVersionRepository(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	fileRepo := postgres.NewSqlFileRepository(dbConnection)
+	repo := postgres.NewSqlFileVersionRepository(dbConnection)
+
+	createFile := func(t *testing.T) uuid.UUID {
+		fileID := uuid.New()
+		require.NoError(t, fileRepo.Create(ctx, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024, domain.FileStatusCompleted, "sum", "bucket", "video/"+fileID.String(), nil))
+		return fileID
+	}
+	newVersion := func(fileID uuid.UUID, number int) domain.FileVersion {
+		id := uuid.New()
+		return domain.FileVersion{
+			ID:         id,
+			FileID:     fileID,
+			Version:    number,
+			Filename:   "test.mp4",
+			MimeType:   "video/mp4",
+			SizeBytes:  2048,
+			Bucket:     "bucket",
+			StorageKey: "video/" + id.String(),
+			Checksum:   "sum2",
+			Status:     domain.FileStatusUploading,
+		}
+	}
+
+	t.Run("Create - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+		version := newVersion(fileID, 2)
+
+		// Act
+		err := repo.Create(ctx, version)
+
+		// Assert
+		require.NoError(t, err)
+		found, err := repo.FindByID(ctx, version.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, found.Version)
+		require.Equal(t, domain.FileStatusUploading, found.Status)
+	})
+
+	t.Run("Create - Duplicate Version", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+		require.NoError(t, repo.Create(ctx, newVersion(fileID, 2)))
+
+		// Act
+		err := repo.Create(ctx, newVersion(fileID, 2))
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrAlreadyExists)
+	})
+
+	t.Run("NextVersion - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+
+		// Act
+		first, err := repo.NextVersion(ctx, fileID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, newVersion(fileID, first)))
+		second, err := repo.NextVersion(ctx, fileID)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, 1, first)
+		require.Equal(t, 2, second)
+	})
+
+	t.Run("NextVersion - Concurrent transactions get distinct numbers", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+		uow := postgres.NewUnitOfWork(dbConnection)
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+
+		// Act
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = uow.Execute(ctx, func(u port.UnitOfWork) error {
+					next, err := u.FileVersionRepo().NextVersion(ctx, fileID)
+					if err != nil {
+						return err
+					}
+					return u.FileVersionRepo().Create(ctx, newVersion(fileID, next))
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		versions, err := repo.ListByFileID(ctx, fileID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.Equal(t, 2, versions[0].Version)
+		require.Equal(t, 1, versions[1].Version)
+	})
+
+	t.Run("ListByFileID - Newest First", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+		require.NoError(t, repo.Create(ctx, newVersion(fileID, 1)))
+		require.NoError(t, repo.Create(ctx, newVersion(fileID, 2)))
+
+		// Act
+		versions, err := repo.ListByFileID(ctx, fileID)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.Equal(t, 2, versions[0].Version)
+	})
+
+	t.Run("FindByFileIDAndVersion - Not Found", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+
+		// Act
+		_, err := repo.FindByFileIDAndVersion(ctx, fileID, 4)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrFileVersionNotFound)
+	})
+
+	t.Run("SetCurrentVersion - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := createFile(t)
+		version := newVersion(fileID, 2)
+		require.NoError(t, repo.Create(ctx, version))
+		require.NoError(t, repo.UpdateStatus(ctx, version.ID, domain.FileStatusCompleted))
+
+		// Act
+		err := fileRepo.SetCurrentVersion(ctx, fileID, version)
+
+		// Assert
+		require.NoError(t, err)
+		file, err := fileRepo.FindById(ctx, fileID)
+		require.NoError(t, err)
+		require.Equal(t, 2, file.CurrentVersion)
+		require.Equal(t, version.StorageKey, file.StorageKey)
+		require.Equal(t, int64(2048), file.SizeBytes)
+	})
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"score-play/internal/core/port"
+)
+
+type jobLock struct {
+	db *sql.DB
+}
+
+// NewJobLock creates a port.JobLock backed by session advisory locks, held on a dedicated connection while the job runs
+func NewJobLock(db *sql.DB) port.JobLock {
+	return &jobLock{db: db}
+}
+
+// TryRun takes the advisory lock of job without waiting, so the instances that lose the race skip the run
+func (l *jobLock) TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error acquiring connection for job %s: %w", job, err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext('job:' || $1))`, job).Scan(&locked); err != nil {
+		return false, fmt.Errorf("error locking job %s: %w", job, err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		_, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext('job:' || $1))`, job)
+		if unlockErr != nil {
+			// closing the session releases the lock, so the connection must not go back to the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,67 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/adapters/repository/postgres"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobLock(t *testing.T) {
+	dbConnection, cleanup, _ := postgres.NewTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	lock := postgres.NewJobLock(dbConnection)
+
+	t.Run("TryRun - Skips the job while another instance runs it", func(t *testing.T) {
+		// Arrange
+		var innerRan bool
+		var innerErr error
+
+		// Act
+		ran, err := lock.TryRun(ctx, "cleanup", func(ctx context.Context) error {
+			innerRan, innerErr = lock.TryRun(ctx, "cleanup", func(ctx context.Context) error { return nil })
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ran)
+		require.NoError(t, innerErr)
+		assert.False(t, innerRan)
+	})
+
+	t.Run("TryRun - Releases the lock when the job fails", func(t *testing.T) {
+		// Arrange
+		expectedError := errors.New("job failed")
+		_, err := lock.TryRun(ctx, "cleanup", func(ctx context.Context) error { return expectedError })
+		require.ErrorIs(t, err, expectedError)
+
+		// Act
+		ran, err := lock.TryRun(ctx, "cleanup", func(ctx context.Context) error { return nil })
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("TryRun - Jobs are locked independently", func(t *testing.T) {
+		// Arrange
+		var otherRan bool
+
+		// Act
+		ran, err := lock.TryRun(ctx, "cleanup", func(ctx context.Context) error {
+			var otherErr error
+			otherRan, otherErr = lock.TryRun(ctx, "reconcile", func(ctx context.Context) error { return nil })
+			return otherErr
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.True(t, otherRan)
+	})
+}
@@ -28,9 +28,9 @@ func NewSqlTagRepository(db SQLQuerier) port.TagRepository {
 // Create creates a new tag
 func (s *sqlTagRepository) Create(ctx context.Context, name string) error {
 
-	query := `INSERT INTO tags (name) VALUES (LOWER($1))`
+	query := `INSERT INTO tags (tenant_id, name) VALUES ($1, LOWER($2))`
 
-	_, err := s.db.ExecContext(ctx, query, name)
+	_, err := s.db.ExecContext(ctx, query, domain.TenantOrDefault(ctx), name)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			if pqErr.Code == "23505" {
@@ -61,14 +61,15 @@ func (s *sqlTagRepository) CreateMany(ctx context.Context, tags []string) (int,
 	}
 
 	placeholders := make([]string, len(tagsList))
-	args := make([]interface{}, len(tagsList))
+	args := make([]interface{}, len(tagsList)+1)
+	args[0] = domain.TenantOrDefault(ctx)
 	for i, tag := range tagsList {
-		placeholders[i] = fmt.Sprintf("(LOWER($%d))", i+1)
-		args[i] = tag
+		placeholders[i] = fmt.Sprintf("($1, LOWER($%d))", i+2)
+		args[i+1] = tag
 	}
 
 	query := fmt.Sprintf(
-		"INSERT INTO tags (name) VALUES %s ON CONFLICT DO NOTHING",
+		"INSERT INTO tags (tenant_id, name) VALUES %s ON CONFLICT DO NOTHING",
 		strings.Join(placeholders, ", "),
 	)
 
@@ -91,11 +92,11 @@ func (s *sqlTagRepository) CreateMany(ctx context.Context, tags []string) (int,
 
 // FindByName finds a tag by name
 func (s *sqlTagRepository) FindByName(ctx context.Context, name string) (*domain.Tag, error) {
-	query := `SELECT id, name, created_at FROM tags WHERE name = LOWER($1)`
+	query := `SELECT id, name, created_at FROM tags WHERE name = LOWER($1) AND ($2::text IS NULL OR tenant_id = $2)`
 
 	var tagDB dbTag
 
-	err := s.db.QueryRowContext(ctx, query, name).Scan(
+	err := s.db.QueryRowContext(ctx, query, name, tenantArg(ctx)).Scan(
 		&tagDB.ID,
 		&tagDB.Name,
 		&tagDB.CreatedAt,
@@ -123,14 +124,15 @@ func (s *sqlTagRepository) FindByNames(ctx context.Context, names []string) (map
 	}
 
 	placeholders := make([]string, len(lowerNames))
-	args := make([]interface{}, len(lowerNames))
+	args := make([]interface{}, len(lowerNames)+1)
+	args[0] = tenantArg(ctx)
 	for i, name := range lowerNames {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = name
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args[i+1] = name
 	}
 
 	query := fmt.Sprintf(
-		"SELECT id, name FROM tags WHERE name IN (%s)",
+		"SELECT id, name FROM tags WHERE ($1::text IS NULL OR tenant_id = $1) AND name IN (%s)",
 		strings.Join(placeholders, ", "),
 	)
 
@@ -164,14 +166,15 @@ func (s *sqlTagRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]do
 	}
 
 	placeholders := make([]string, len(ids))
-	args := make([]interface{}, len(ids))
+	args := make([]interface{}, len(ids)+1)
+	args[0] = tenantArg(ctx)
 	for i, id := range ids {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args[i+1] = id
 	}
 
 	query := fmt.Sprintf(
-		"SELECT id, name FROM tags WHERE id IN (%s)",
+		"SELECT id, name FROM tags WHERE ($1::text IS NULL OR tenant_id = $1) AND id IN (%s)",
 		strings.Join(placeholders, ", "),
 	)
 
@@ -220,18 +223,19 @@ func (s *sqlTagRepository) List(ctx context.Context, limit int, marker *string)
 		query = `
 			SELECT id, name, created_at 
 			FROM tags 
-			WHERE name > $1 
+			WHERE name > $1 AND ($3::text IS NULL OR tenant_id = $3)
 			ORDER BY name ASC 
 			LIMIT $2`
-		args = []interface{}{lowerMarker, limit + 1}
+		args = []interface{}{lowerMarker, limit + 1, tenantArg(ctx)}
 	} else {
 		// Fetch first page
 		query = `
 			SELECT id, name, created_at 
 			FROM tags 
+			WHERE ($2::text IS NULL OR tenant_id = $2)
 			ORDER BY name ASC 
 			LIMIT $1`
-		args = []interface{}{limit + 1}
+		args = []interface{}{limit + 1, tenantArg(ctx)}
 	}
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
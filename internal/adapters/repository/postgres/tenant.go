@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"score-play/internal/core/domain"
+)
+
+const (
+	// fileInTenant scopes rows of tables keyed by file_id to the tenant of the file, the tenant is bound to $2
+	fileInTenant = `($2::text IS NULL OR EXISTS (SELECT 1 FROM file_metadata f WHERE f.id = file_id AND f.tenant_id = $2))`
+	// sessionInTenant scopes upload_session updates, the tenant is bound to $3
+	sessionInTenant = `($3::text IS NULL OR tenant_id = $3)`
+)
+
+// tenantArg returns the tenant of ctx as a query argument.
+// Queries filter with `($n::text IS NULL OR tenant_id = $n)` so that unscoped background jobs see every tenant.
+func tenantArg(ctx context.Context) sql.NullString {
+	tenant, ok := domain.TenantFromContext(ctx)
+	return sql.NullString{String: tenant, Valid: ok}
+}
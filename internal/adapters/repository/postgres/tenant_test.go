@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+	tagRepo := postgres.NewSqlTagRepository(dbConnection)
+	fileRepo := postgres.NewSqlFileRepository(dbConnection)
+	fileTagRepo := postgres.NewFileTagRepository(dbConnection)
+	sessionRepo := postgres.NewSQLUploadSessionRepository(dbConnection)
+
+	t.Run("Tags - names are unique per tenant", func(t *testing.T) {
+		// Arrange
+		truncate()
+		_, err := tagRepo.CreateMany(acme, []string{"goal"})
+		require.NoError(t, err)
+
+		// Act
+		created, err := tagRepo.CreateMany(globex, []string{"goal"})
+		_, duplicate := tagRepo.CreateMany(acme, []string{"Goal"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.ErrorIs(t, duplicate, domain.ErrAlreadyExists)
+	})
+
+	t.Run("Tags - tenant cannot read another tenant's tags", func(t *testing.T) {
+		// Arrange
+		truncate()
+		_, err := tagRepo.CreateMany(acme, []string{"goal"})
+		require.NoError(t, err)
+		acmeTag, err := tagRepo.FindByName(acme, "goal")
+		require.NoError(t, err)
+
+		// Act
+		_, findErr := tagRepo.FindByName(globex, "goal")
+		byNames, namesErr := tagRepo.FindByNames(globex, []string{"goal"})
+		byIDs, idsErr := tagRepo.FindByIDs(globex, []uuid.UUID{acmeTag.ID})
+		list, _, listErr := tagRepo.List(globex, 10, nil)
+
+		// Assert
+		assert.ErrorIs(t, findErr, domain.ErrTagNotFound)
+		require.NoError(t, namesErr)
+		assert.Empty(t, byNames)
+		require.NoError(t, idsErr)
+		assert.Empty(t, byIDs)
+		require.NoError(t, listErr)
+		assert.Empty(t, list)
+	})
+
+	t.Run("Files - tenant cannot read or change another tenant's file", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		require.NoError(t, fileRepo.Create(acme, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024,
+			domain.FileStatusUploading, "sum", "bucket", "acme/video/key", nil))
+		_, err := tagRepo.CreateMany(acme, []string{"goal"})
+		require.NoError(t, err)
+		tag, err := tagRepo.FindByName(acme, "goal")
+		require.NoError(t, err)
+		_, err = fileTagRepo.CreateMany(acme, fileID, []uuid.UUID{tag.ID})
+		require.NoError(t, err)
+
+		// Act
+		_, findErr := fileRepo.FindById(globex, fileID)
+		updateErr := fileRepo.UpdateStatus(globex, fileID, domain.FileStatusCompleted)
+		deleteErr := fileRepo.Delete(globex, fileID)
+		fileTags, fileTagsErr := fileTagRepo.FindByFileID(globex, fileID)
+
+		// Assert
+		assert.ErrorIs(t, findErr, domain.ErrFileMetadataNotFound)
+		assert.ErrorIs(t, updateErr, domain.ErrFileMetadataNotFound)
+		assert.ErrorIs(t, deleteErr, domain.ErrFileMetadataNotFound)
+		require.NoError(t, fileTagsErr)
+		assert.Empty(t, fileTags)
+
+		file, err := fileRepo.FindById(acme, fileID)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", file.TenantID)
+		assert.Equal(t, domain.FileStatusUploading, file.Status)
+	})
+
+	t.Run("Sessions - tenant cannot use another tenant's session", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		sessionID := uuid.New()
+		require.NoError(t, fileRepo.Create(acme, fileID, "test.mp4", "video/mp4", domain.FileTypeVideo, 1024,
+			domain.FileStatusUploading, "sum", "bucket", "key", nil))
+		require.NoError(t, sessionRepo.Create(acme, domain.UploadSession{
+			ID:               sessionID,
+			FileID:           fileID,
+			ProviderUploadID: "upload-id",
+			PartSize:         5 << 20,
+			ExpiresAt:        time.Now().Add(time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}))
+
+		// Act
+		_, activeErr := sessionRepo.FindByIDAndActive(globex, sessionID)
+		extendErr := sessionRepo.UpdateExpiresAt(globex, sessionID, time.Now().Add(2*time.Hour))
+
+		// Assert
+		assert.ErrorIs(t, activeErr, domain.ErrSessionNotFound)
+		assert.ErrorIs(t, extendErr, domain.ErrSessionNotFound)
+		_, err := sessionRepo.FindByIDAndActive(acme, sessionID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Background jobs see every tenant", func(t *testing.T) {
+		// Arrange
+		truncate()
+		require.NoError(t, fileRepo.Create(acme, uuid.New(), "a.mp4", "video/mp4", domain.FileTypeVideo, 1,
+			domain.FileStatusUploading, "sum", "bucket", "a", nil))
+		require.NoError(t, fileRepo.Create(globex, uuid.New(), "b.mp4", "video/mp4", domain.FileTypeVideo, 1,
+			domain.FileStatusUploading, "sum", "bucket", "b", nil))
+
+		// Act
+		files, err := fileRepo.FindExpired(context.Background(), time.Now().Add(time.Hour), uuid.Nil, 100)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+}
@@ -41,6 +41,34 @@ func (u *sqlUnitOfWork) FileTagRepo() port.FileTagRepository {
 	return NewFileTagRepository(u.db)
 }
 
+func (u *sqlUnitOfWork) FileVersionRepo() port.FileVersionRepository {
+	if u.tx != nil {
+		return NewSqlFileVersionRepository(u.tx)
+	}
+	return NewSqlFileVersionRepository(u.db)
+}
+
+func (u *sqlUnitOfWork) UsageRepo() port.UsageRepository {
+	if u.tx != nil {
+		return NewSqlUsageRepository(u.tx)
+	}
+	return NewSqlUsageRepository(u.db)
+}
+
+func (u *sqlUnitOfWork) CleanupRunRepo() port.CleanupRunRepository {
+	if u.tx != nil {
+		return NewSqlCleanupRunRepository(u.tx)
+	}
+	return NewSqlCleanupRunRepository(u.db)
+}
+
+func (u *sqlUnitOfWork) FileImportRepo() port.FileImportRepository {
+	if u.tx != nil {
+		return NewSqlFileImportRepository(u.tx)
+	}
+	return NewSqlFileImportRepository(u.db)
+}
+
 func (u *sqlUnitOfWork) Execute(ctx context.Context, fn func(uow port.UnitOfWork) error) error {
 	tx, err := u.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/port"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type sqlUploadSessionRepository struct {
@@ -24,20 +26,25 @@ func NewSQLUploadSessionRepository(db SQLQuerier) port.UploadSessionRepository {
 func (s *sqlUploadSessionRepository) Create(ctx context.Context, session domain.UploadSession) error {
 	query := `
 		INSERT INTO upload_session (
-			id, file_id, provider_upload_id, part_size, expires_at, status
-		) VALUES ($1, $2, $3, $4, $5, $6)`
+			id, file_id, version_id, provider_upload_id, part_size, expires_at, status, tenant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	_, err := s.db.ExecContext(
 		ctx,
 		query,
 		session.ID,
 		session.FileID,
+		session.VersionID,
 		session.ProviderUploadID,
 		session.PartSize,
 		session.ExpiresAt,
 		session.Status,
+		domain.TenantOrDefault(ctx),
 	)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("open upload session of file %s : %w", session.FileID, domain.ErrAlreadyExists)
+		}
 		return err
 	}
 	return nil
@@ -45,9 +52,9 @@ func (s *sqlUploadSessionRepository) Create(ctx context.Context, session domain.
 
 // UpdateExpiresAt updates expires at
 func (s *sqlUploadSessionRepository) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
-	query := `UPDATE upload_session SET expires_at = $1, updated_at = now() WHERE id = $2 AND status = 'open'`
+	query := `UPDATE upload_session SET expires_at = $1, updated_at = now() WHERE id = $2 AND status = 'open' AND ` + sessionInTenant
 
-	result, err := s.db.ExecContext(ctx, query, expiresAt, id)
+	result, err := s.db.ExecContext(ctx, query, expiresAt, id, tenantArg(ctx))
 	if err != nil {
 		return err
 	}
@@ -64,18 +71,78 @@ func (s *sqlUploadSessionRepository) UpdateExpiresAt(ctx context.Context, id uui
 	return nil
 }
 
+// UpdateOffset records the bytes an open session has received, with the state of their checksum
+func (s *sqlUploadSessionRepository) UpdateOffset(ctx context.Context, id uuid.UUID, offset int64, checksumState []byte) error {
+	query := `UPDATE upload_session SET upload_offset = $1, checksum_state = $4, updated_at = now() WHERE id = $2 AND status = 'open' AND ` + sessionInTenant
+
+	result, err := s.db.ExecContext(ctx, query, offset, id, tenantArg(ctx), checksumState)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// AcquireLease gives the open session id to holder until the given time, unless another holder has a lease not expired at now.
+// Holders renew their lease by acquiring it again. It returns domain.ErrUploadLocked when another holder has the lease.
+func (s *sqlUploadSessionRepository) AcquireLease(ctx context.Context, id uuid.UUID, holder uuid.UUID, now time.Time, until time.Time) error {
+	query := `
+		UPDATE upload_session SET lease_holder = $1, lease_until = $2
+		WHERE id = $3 AND status = 'open' AND (lease_holder IS NULL OR lease_holder = $1 OR lease_until < $4)
+			AND ($5::text IS NULL OR tenant_id = $5)`
+
+	result, err := s.db.ExecContext(ctx, query, holder, until, id, now, tenantArg(ctx))
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		if _, err := s.FindByIDAndActive(ctx, id); err != nil {
+			return err
+		}
+		return domain.ErrUploadLocked
+	}
+
+	return nil
+}
+
+// ReleaseLease ends the lease of holder on the session id, whatever its status. A lease already taken over is left untouched.
+func (s *sqlUploadSessionRepository) ReleaseLease(ctx context.Context, id uuid.UUID, holder uuid.UUID) error {
+	query := `UPDATE upload_session SET lease_holder = NULL, lease_until = NULL WHERE id = $1 AND lease_holder = $2`
+
+	_, err := s.db.ExecContext(ctx, query, id, holder)
+	return err
+}
+
 func (s *sqlUploadSessionRepository) FindByIDAndActive(ctx context.Context, id uuid.UUID) (*domain.UploadSession, error) {
 	query := `
-		SELECT id, file_id, provider_upload_id, part_size, expires_at, status, created_at, updated_at
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
 		FROM upload_session 
-		WHERE id = $1 AND status = 'open'`
+		WHERE id = $1 AND status = 'open' AND ($2::text IS NULL OR tenant_id = $2)`
 
 	var row dbUploadSession
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := s.db.QueryRowContext(ctx, query, id, tenantArg(ctx)).Scan(
 		&row.ID,
 		&row.FileID,
+		&row.VersionID,
 		&row.ProviderUploadID,
 		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
 		&row.ExpiresAt,
 		&row.Status,
 		&row.CreatedAt,
@@ -94,9 +161,9 @@ func (s *sqlUploadSessionRepository) FindByIDAndActive(ctx context.Context, id u
 
 // UpdateStatusByFileID updates session status by file ID
 func (s *sqlUploadSessionRepository) UpdateStatusByFileID(ctx context.Context, fileID uuid.UUID, status domain.UploadSessionStatus) error {
-	query := `UPDATE upload_session SET status = $1, updated_at = now() WHERE file_id = $2`
+	query := `UPDATE upload_session SET status = $1, updated_at = now() WHERE file_id = $2 AND ` + sessionInTenant
 
-	result, err := s.db.ExecContext(ctx, query, status, fileID)
+	result, err := s.db.ExecContext(ctx, query, status, fileID, tenantArg(ctx))
 	if err != nil {
 		return err
 	}
@@ -113,13 +180,17 @@ func (s *sqlUploadSessionRepository) UpdateStatusByFileID(ctx context.Context, f
 	return nil
 }
 
-func (s *sqlUploadSessionRepository) FindAllExpired(ctx context.Context, now time.Time) ([]domain.UploadSession, error) {
+// FindAllExpired returns a page of the open sessions expired at now, ordered by id after the given one.
+// It does not lock them, callers claim each session with ClaimExpired in the transaction that changes it.
+func (s *sqlUploadSessionRepository) FindAllExpired(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]domain.UploadSession, error) {
 	query := `
-		SELECT id, file_id, provider_upload_id, part_size, expires_at, status, created_at, updated_at
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
 		FROM upload_session 
-		WHERE status = 'open' AND expires_at < $1`
+		WHERE status = 'open' AND expires_at < $1 AND ($2::text IS NULL OR tenant_id = $2) AND id > $3
+		ORDER BY id
+		LIMIT $4`
 
-	rows, err := s.db.QueryContext(ctx, query, now)
+	rows, err := s.db.QueryContext(ctx, query, now, tenantArg(ctx), after, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -131,8 +202,11 @@ func (s *sqlUploadSessionRepository) FindAllExpired(ctx context.Context, now tim
 		if err := rows.Scan(
 			&row.ID,
 			&row.FileID,
+			&row.VersionID,
 			&row.ProviderUploadID,
 			&row.PartSize,
+			&row.UploadOffset,
+			&row.ChecksumState,
 			&row.ExpiresAt,
 			&row.Status,
 			&row.CreatedAt,
@@ -150,18 +224,120 @@ func (s *sqlUploadSessionRepository) FindAllExpired(ctx context.Context, now tim
 	return sessions, nil
 }
 
+// ClaimExpired locks the session until the end of the transaction if it is still open and expired at now.
+// It returns domain.ErrSessionNotFound when the session was completed, aborted or is locked by another transaction.
+func (s *sqlUploadSessionRepository) ClaimExpired(ctx context.Context, id uuid.UUID, now time.Time) (*domain.UploadSession, error) {
+	query := `
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
+		FROM upload_session 
+		WHERE id = $1 AND status = 'open' AND expires_at < $2 AND ($3::text IS NULL OR tenant_id = $3)
+		FOR UPDATE SKIP LOCKED`
+
+	var row dbUploadSession
+	err := s.db.QueryRowContext(ctx, query, id, now, tenantArg(ctx)).Scan(
+		&row.ID,
+		&row.FileID,
+		&row.VersionID,
+		&row.ProviderUploadID,
+		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
+		&row.ExpiresAt,
+		&row.Status,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
+
+// FindByFileID finds the open session uploading the file itself, not one of its versions
 func (s *sqlUploadSessionRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (*domain.UploadSession, error) {
 	query := `
-		SELECT id, file_id, provider_upload_id, part_size, expires_at, status, created_at, updated_at
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
+		FROM upload_session 
+		WHERE file_id = $1 AND version_id IS NULL AND status = 'open' AND ($2::text IS NULL OR tenant_id = $2)`
+
+	var row dbUploadSession
+	err := s.db.QueryRowContext(ctx, query, fileID, tenantArg(ctx)).Scan(
+		&row.ID,
+		&row.FileID,
+		&row.VersionID,
+		&row.ProviderUploadID,
+		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
+		&row.ExpiresAt,
+		&row.Status,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
+
+// FindByVersionID finds the open session uploading the version versionID
+func (s *sqlUploadSessionRepository) FindByVersionID(ctx context.Context, versionID uuid.UUID) (*domain.UploadSession, error) {
+	query := `
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
 		FROM upload_session 
-		WHERE file_id = $1 AND status = 'open'`
+		WHERE version_id = $1 AND status = 'open' AND ($2::text IS NULL OR tenant_id = $2)`
 
 	var row dbUploadSession
-	err := s.db.QueryRowContext(ctx, query, fileID).Scan(
+	err := s.db.QueryRowContext(ctx, query, versionID, tenantArg(ctx)).Scan(
 		&row.ID,
 		&row.FileID,
+		&row.VersionID,
 		&row.ProviderUploadID,
 		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
+		&row.ExpiresAt,
+		&row.Status,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
+
+// FindByProviderUploadID finds the session of a multipart upload of the storage, whatever its status
+func (s *sqlUploadSessionRepository) FindByProviderUploadID(ctx context.Context, providerUploadID string) (*domain.UploadSession, error) {
+	query := `
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
+		FROM upload_session 
+		WHERE provider_upload_id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
+
+	var row dbUploadSession
+	err := s.db.QueryRowContext(ctx, query, providerUploadID, tenantArg(ctx)).Scan(
+		&row.ID,
+		&row.FileID,
+		&row.VersionID,
+		&row.ProviderUploadID,
+		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
 		&row.ExpiresAt,
 		&row.Status,
 		&row.CreatedAt,
@@ -180,16 +356,19 @@ func (s *sqlUploadSessionRepository) FindByFileID(ctx context.Context, fileID uu
 
 func (s *sqlUploadSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.UploadSession, error) {
 	query := `
-		SELECT id, file_id, provider_upload_id, part_size, expires_at, status, created_at, updated_at
+		SELECT id, file_id, version_id, provider_upload_id, part_size, upload_offset, checksum_state, expires_at, status, created_at, updated_at
 		FROM upload_session 
-		WHERE id = $1`
+		WHERE id = $1 AND ($2::text IS NULL OR tenant_id = $2)`
 
 	var row dbUploadSession
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := s.db.QueryRowContext(ctx, query, id, tenantArg(ctx)).Scan(
 		&row.ID,
 		&row.FileID,
+		&row.VersionID,
 		&row.ProviderUploadID,
 		&row.PartSize,
+		&row.UploadOffset,
+		&row.ChecksumState,
 		&row.ExpiresAt,
 		&row.Status,
 		&row.CreatedAt,
@@ -208,9 +387,9 @@ func (s *sqlUploadSessionRepository) FindByID(ctx context.Context, id uuid.UUID)
 
 // UpdateStatus updates status
 func (s *sqlUploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadSessionStatus) error {
-	query := `UPDATE upload_session SET status = $1, updated_at = now() WHERE id = $2`
+	query := `UPDATE upload_session SET status = $1, updated_at = now() WHERE id = $2 AND ` + sessionInTenant
 
-	result, err := s.db.ExecContext(ctx, query, status, id)
+	result, err := s.db.ExecContext(ctx, query, status, id, tenantArg(ctx))
 	if err != nil {
 		return err
 	}
@@ -227,24 +406,45 @@ func (s *sqlUploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.U
 	return nil
 }
 
+// CountOpen counts the open sessions, expired ones not cleaned up yet included
+func (s *sqlUploadSessionRepository) CountOpen(ctx context.Context) (int64, error) {
+	query := `SELECT count(*) FROM upload_session WHERE status = 'open' AND ($1::text IS NULL OR tenant_id = $1)`
+
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, tenantArg(ctx)).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 type dbUploadSession struct {
-	ID               uuid.UUID `db:"id"`
-	FileID           uuid.UUID `db:"file_id"`
-	ProviderUploadID string    `db:"provider_upload_id"`
-	PartSize         int       `db:"part_size"`
-	ExpiresAt        time.Time `db:"expires_at"`
-	Status           string    `db:"status"`
-	CreatedAt        time.Time `db:"created_at"`
-	UpdatedAt        time.Time `db:"updated_at"`
+	ID               uuid.UUID     `db:"id"`
+	FileID           uuid.UUID     `db:"file_id"`
+	VersionID        uuid.NullUUID `db:"version_id"`
+	ProviderUploadID string        `db:"provider_upload_id"`
+	PartSize         int           `db:"part_size"`
+	UploadOffset     int64         `db:"upload_offset"`
+	ChecksumState    []byte        `db:"checksum_state"`
+	ExpiresAt        time.Time     `db:"expires_at"`
+	Status           string        `db:"status"`
+	CreatedAt        time.Time     `db:"created_at"`
+	UpdatedAt        time.Time     `db:"updated_at"`
 }
 
 // ToDomain converts db obj to domain
 func (s *dbUploadSession) ToDomain() *domain.UploadSession {
+	var versionID *uuid.UUID
+	if s.VersionID.Valid {
+		versionID = &s.VersionID.UUID
+	}
 	return &domain.UploadSession{
 		ID:               s.ID,
 		FileID:           s.FileID,
+		VersionID:        versionID,
 		ProviderUploadID: s.ProviderUploadID,
 		PartSize:         s.PartSize,
+		UploadOffset:     s.UploadOffset,
+		ChecksumState:    s.ChecksumState,
 		ExpiresAt:        s.ExpiresAt,
 		Status:           domain.UploadSessionStatus(s.Status),
 		CreatedAt:        s.CreatedAt,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"score-play/internal/adapters/repository/postgres"
 	"score-play/internal/core/domain"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 
 	sessionRepo := postgres.NewSQLUploadSessionRepository(dbConnection)
 	fileRepo := postgres.NewSqlFileRepository(dbConnection)
+	versionRepo := postgres.NewSqlFileVersionRepository(dbConnection)
 	setupTestFile := func(t *testing.T, id uuid.UUID) {
 		err := fileRepo.Create(
 			ctx,
@@ -28,7 +30,9 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 			1024*1024,
 			domain.FileStatusUploading,
 			"checksum-"+id.String(),
+			"bucket",
 			"temp/path/"+id.String(),
+			nil,
 		)
 		require.NoError(t, err)
 	}
@@ -76,6 +80,79 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("Create - Versions are uploaded concurrently with the file", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		require.NoError(t, sessionRepo.Create(ctx, domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			ProviderUploadID: "file-upload-id",
+			PartSize:         5242880,
+			ExpiresAt:        time.Now().Add(time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}))
+		versionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		for i, versionID := range versionIDs {
+			require.NoError(t, versionRepo.Create(ctx, domain.FileVersion{
+				ID: versionID, FileID: fileID, Version: i + 2, Filename: "video.mp4", MimeType: "video/mp4",
+				SizeBytes: 1024, Bucket: "bucket", StorageKey: versionID.String(), Status: domain.FileStatusUploading,
+			}))
+		}
+
+		// Act
+		errs := make([]error, len(versionIDs))
+		var wg sync.WaitGroup
+		for i, versionID := range versionIDs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = sessionRepo.Create(ctx, domain.UploadSession{
+					ID:               uuid.New(),
+					FileID:           fileID,
+					VersionID:        &versionID,
+					ProviderUploadID: "upload-" + versionID.String(),
+					PartSize:         5242880,
+					ExpiresAt:        time.Now().Add(time.Hour),
+					Status:           domain.UploadSessionStatusOpen,
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		count, err := sessionRepo.CountOpen(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("Create - Already exists if the file has an open session", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		session := domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			ProviderUploadID: "file-upload-id",
+			PartSize:         5242880,
+			ExpiresAt:        time.Now().Add(time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}
+		require.NoError(t, sessionRepo.Create(ctx, session))
+		session.ID = uuid.New()
+
+		// Act
+		err := sessionRepo.Create(ctx, session)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrAlreadyExists)
+	})
+
 	t.Run("UpdateExpiresAt - Success", func(t *testing.T) {
 		// Arrange
 		truncate()
@@ -96,6 +173,105 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.WithinDuration(t, newExpiry, updated.ExpiresAt, time.Second)
 	})
 
+	t.Run("UpdateOffset - Success", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		sessionID := uuid.New()
+		_ = sessionRepo.Create(ctx, domain.UploadSession{
+			ID: sessionID, FileID: fileID, Status: domain.UploadSessionStatusOpen, ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		// Act
+		err := sessionRepo.UpdateOffset(ctx, sessionID, 1024, []byte("state"))
+
+		// Assert
+		require.NoError(t, err)
+		updated, _ := sessionRepo.FindByIDAndActive(ctx, sessionID)
+		require.Equal(t, int64(1024), updated.UploadOffset)
+		require.Equal(t, []byte("state"), updated.ChecksumState)
+	})
+
+	t.Run("UpdateOffset - Closed session", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		sessionID := uuid.New()
+		_ = sessionRepo.Create(ctx, domain.UploadSession{
+			ID: sessionID, FileID: fileID, Status: domain.UploadSessionStatusAborted, ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		// Act
+		err := sessionRepo.UpdateOffset(ctx, sessionID, 1024, nil)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("AcquireLease - Locked until the lease of another holder expires", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		sessionID := uuid.New()
+		_ = sessionRepo.Create(ctx, domain.UploadSession{
+			ID: sessionID, FileID: fileID, Status: domain.UploadSessionStatusOpen, ExpiresAt: time.Now().Add(time.Hour),
+		})
+		holder, other := uuid.New(), uuid.New()
+		now := time.Now()
+		require.NoError(t, sessionRepo.AcquireLease(ctx, sessionID, holder, now, now.Add(time.Minute)))
+
+		// Act
+		renewErr := sessionRepo.AcquireLease(ctx, sessionID, holder, now, now.Add(2*time.Minute))
+		lockedErr := sessionRepo.AcquireLease(ctx, sessionID, other, now, now.Add(time.Minute))
+		expiredErr := sessionRepo.AcquireLease(ctx, sessionID, other, now.Add(3*time.Minute), now.Add(4*time.Minute))
+
+		// Assert
+		require.NoError(t, renewErr)
+		require.ErrorIs(t, lockedErr, domain.ErrUploadLocked)
+		require.NoError(t, expiredErr)
+	})
+
+	t.Run("AcquireLease - Released lease", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		sessionID := uuid.New()
+		_ = sessionRepo.Create(ctx, domain.UploadSession{
+			ID: sessionID, FileID: fileID, Status: domain.UploadSessionStatusOpen, ExpiresAt: time.Now().Add(time.Hour),
+		})
+		holder := uuid.New()
+		now := time.Now()
+		require.NoError(t, sessionRepo.AcquireLease(ctx, sessionID, holder, now, now.Add(time.Minute)))
+		require.NoError(t, sessionRepo.ReleaseLease(ctx, sessionID, holder))
+
+		// Act
+		err := sessionRepo.AcquireLease(ctx, sessionID, uuid.New(), now, now.Add(time.Minute))
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("AcquireLease - Closed session", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		sessionID := uuid.New()
+		_ = sessionRepo.Create(ctx, domain.UploadSession{
+			ID: sessionID, FileID: fileID, Status: domain.UploadSessionStatusAborted, ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		// Act
+		err := sessionRepo.AcquireLease(ctx, sessionID, uuid.New(), time.Now(), time.Now().Add(time.Minute))
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
 	t.Run("FindByFileID - Nominal case", func(t *testing.T) {
 		// Arrange
 		truncate()
@@ -176,6 +352,64 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.Nil(t, found)
 	})
 
+	t.Run("FindByVersionID - Finds the session of the version, not the one of another version", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		versionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		for i, versionID := range versionIDs {
+			require.NoError(t, versionRepo.Create(ctx, domain.FileVersion{
+				ID: versionID, FileID: fileID, Version: i + 2, Filename: "video.mp4", MimeType: "video/mp4",
+				SizeBytes: 1024, Bucket: "bucket", StorageKey: versionID.String(), Status: domain.FileStatusUploading,
+			}))
+			require.NoError(t, sessionRepo.Create(ctx, domain.UploadSession{
+				ID:               uuid.New(),
+				FileID:           fileID,
+				VersionID:        &versionID,
+				ProviderUploadID: "upload-" + versionID.String(),
+				PartSize:         5242880,
+				ExpiresAt:        time.Now().Add(time.Hour),
+				Status:           domain.UploadSessionStatusOpen,
+			}))
+		}
+
+		// Act
+		found, err := sessionRepo.FindByVersionID(ctx, versionIDs[1])
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, "upload-"+versionIDs[1].String(), found.ProviderUploadID)
+	})
+
+	t.Run("FindByFileID - Ignores the sessions of versions", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		versionID := uuid.New()
+		require.NoError(t, versionRepo.Create(ctx, domain.FileVersion{
+			ID: versionID, FileID: fileID, Version: 2, Filename: "video.mp4", MimeType: "video/mp4",
+			SizeBytes: 1024, Bucket: "bucket", StorageKey: versionID.String(), Status: domain.FileStatusUploading,
+		}))
+		require.NoError(t, sessionRepo.Create(ctx, domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			VersionID:        &versionID,
+			ProviderUploadID: "version-upload-id",
+			PartSize:         5242880,
+			ExpiresAt:        time.Now().Add(time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}))
+
+		// Act
+		found, err := sessionRepo.FindByFileID(ctx, fileID)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+		require.Nil(t, found)
+	})
+
 	t.Run("FindAllExpired - Returns expired open sessions", func(t *testing.T) {
 		// Arrange
 		truncate()
@@ -234,7 +468,7 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.NoError(t, err)
 
 		// Act
-		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now)
+		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 100)
 
 		// Assert
 		require.NoError(t, err)
@@ -253,6 +487,94 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.False(t, expiredIDs[expiredCompletedSession.ID])
 	})
 
+	t.Run("FindAllExpired - Pages by id", func(t *testing.T) {
+		// Arrange
+		truncate()
+		now := time.Now().Round(time.Microsecond)
+		for i := 0; i < 3; i++ {
+			fileID := uuid.New()
+			setupTestFile(t, fileID)
+			require.NoError(t, sessionRepo.Create(ctx, domain.UploadSession{
+				ID:               uuid.New(),
+				FileID:           fileID,
+				ProviderUploadID: "expired",
+				PartSize:         5242880,
+				ExpiresAt:        now.Add(-time.Hour),
+				Status:           domain.UploadSessionStatusOpen,
+			}))
+		}
+
+		// Act
+		first, firstErr := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 2)
+		require.NoError(t, firstErr)
+		require.Len(t, first, 2)
+		second, secondErr := sessionRepo.FindAllExpired(ctx, now, first[1].ID, 2)
+
+		// Assert
+		require.NoError(t, secondErr)
+		require.Len(t, second, 1)
+		require.Less(t, first[0].ID.String(), first[1].ID.String())
+		require.Less(t, first[1].ID.String(), second[0].ID.String())
+	})
+
+	t.Run("ClaimExpired - Skips sessions locked by another transaction", func(t *testing.T) {
+		// Arrange
+		truncate()
+		now := time.Now().Round(time.Microsecond)
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		session := domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			ProviderUploadID: "expired",
+			PartSize:         5242880,
+			ExpiresAt:        now.Add(-time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}
+		require.NoError(t, sessionRepo.Create(ctx, session))
+
+		tx, err := dbConnection.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		claimed, err := postgres.NewSQLUploadSessionRepository(tx).ClaimExpired(ctx, session.ID, now)
+		require.NoError(t, err)
+		require.Equal(t, session.ID, claimed.ID)
+
+		// Act
+		again, againErr := sessionRepo.ClaimExpired(ctx, session.ID, now)
+		listed, listErr := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 100)
+
+		// Assert
+		require.ErrorIs(t, againErr, domain.ErrSessionNotFound)
+		require.Nil(t, again)
+		require.NoError(t, listErr)
+		require.Empty(t, listed)
+	})
+
+	t.Run("ClaimExpired - Returns not found for sessions not expired", func(t *testing.T) {
+		// Arrange
+		truncate()
+		now := time.Now().Round(time.Microsecond)
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		session := domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			ProviderUploadID: "valid",
+			PartSize:         5242880,
+			ExpiresAt:        now.Add(time.Hour),
+			Status:           domain.UploadSessionStatusOpen,
+		}
+		require.NoError(t, sessionRepo.Create(ctx, session))
+
+		// Act
+		claimed, err := sessionRepo.ClaimExpired(ctx, session.ID, now)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+		require.Nil(t, claimed)
+	})
+
 	t.Run("FindAllExpired - Returns empty list when no expired sessions", func(t *testing.T) {
 		// Arrange
 		truncate()
@@ -272,7 +594,7 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.NoError(t, err)
 
 		// Act
-		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now)
+		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 100)
 
 		// Assert
 		require.NoError(t, err)
@@ -285,7 +607,7 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		now := time.Now()
 
 		// Act
-		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now)
+		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 100)
 
 		// Assert
 		require.NoError(t, err)
@@ -324,7 +646,7 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.NoError(t, err)
 
 		// Act
-		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now)
+		expiredSessions, err := sessionRepo.FindAllExpired(ctx, now, uuid.Nil, 100)
 
 		// Assert
 		require.NoError(t, err)
@@ -332,4 +654,56 @@ func TestSqlUploadSessionRepository(t *testing.T) {
 		require.Equal(t, expiredOpenSession.ID, expiredSessions[0].ID)
 		require.Equal(t, domain.UploadSessionStatusOpen, expiredSessions[0].Status)
 	})
+
+	t.Run("CountOpen - Counts open sessions only", func(t *testing.T) {
+		// Arrange
+		truncate()
+		now := time.Now().Round(time.Microsecond)
+		for i, status := range []domain.UploadSessionStatus{domain.UploadSessionStatusOpen, domain.UploadSessionStatusOpen, domain.UploadSessionStatusAborted, domain.UploadSessionStatusCompleted} {
+			fileID := uuid.New()
+			setupTestFile(t, fileID)
+			err := sessionRepo.Create(ctx, domain.UploadSession{
+				ID:               uuid.New(),
+				FileID:           fileID,
+				ProviderUploadID: "upload-" + string(status),
+				PartSize:         5242880,
+				ExpiresAt:        now.Add(time.Duration(i-1) * time.Hour),
+				Status:           status,
+			})
+			require.NoError(t, err)
+		}
+
+		// Act
+		count, err := sessionRepo.CountOpen(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, int64(2), count)
+	})
+
+	t.Run("FindByProviderUploadID - Finds sessions of any status", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		setupTestFile(t, fileID)
+		session := domain.UploadSession{
+			ID:               uuid.New(),
+			FileID:           fileID,
+			ProviderUploadID: "aborted-upload",
+			PartSize:         5242880,
+			ExpiresAt:        time.Now().Add(-time.Hour).Round(time.Microsecond),
+			Status:           domain.UploadSessionStatusAborted,
+		}
+		require.NoError(t, sessionRepo.Create(ctx, session))
+
+		// Act
+		found, err := sessionRepo.FindByProviderUploadID(ctx, "aborted-upload")
+		require.NoError(t, err)
+		_, notFoundErr := sessionRepo.FindByProviderUploadID(ctx, "unknown-upload")
+
+		// Assert
+		require.Equal(t, session.ID, found.ID)
+		require.Equal(t, domain.UploadSessionStatusAborted, found.Status)
+		require.ErrorIs(t, notFoundErr, domain.ErrSessionNotFound)
+	})
 }
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+)
+
+type sqlUsageRepository struct {
+	db SQLQuerier
+}
+
+// NewSqlUsageRepository creates sqlUsageRepository that implements port.UsageRepository
+func NewSqlUsageRepository(db SQLQuerier) port.UsageRepository {
+	return &sqlUsageRepository{
+		db: db,
+	}
+}
+
+// Lock takes a transaction scoped advisory lock on the tenant, so that concurrent uploads cannot both pass the quota
+func (s *sqlUsageRepository) Lock(ctx context.Context) error {
+	query := `SELECT pg_advisory_xact_lock(hashtext('quota:' || $1))`
+
+	if _, err := s.db.ExecContext(ctx, query, domain.TenantOrDefault(ctx)); err != nil {
+		return fmt.Errorf("error locking tenant usage: %w", err)
+	}
+	return nil
+}
+
+// Get computes the usage from the files and versions, so failed and expired uploads stop counting once the cleanup marks them
+func (s *sqlUsageRepository) Get(ctx context.Context, ownerID *string) (*domain.Usage, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT SUM(v.size_bytes) FROM file_version v JOIN file_metadata f ON f.id = v.file_id
+			          WHERE v.status = 'completed' AND f.deleted_at IS NULL
+			            AND f.tenant_id = $1 AND ($2::text IS NULL OR f.owner_id = $2)), 0),
+			COALESCE((SELECT SUM(f.size_bytes) FROM file_metadata f
+			          WHERE f.status = 'uploading' AND f.deleted_at IS NULL
+			            AND f.tenant_id = $1 AND ($2::text IS NULL OR f.owner_id = $2)), 0)
+			+ COALESCE((SELECT SUM(v.size_bytes) FROM file_version v JOIN file_metadata f ON f.id = v.file_id
+			            WHERE v.status = 'uploading' AND f.deleted_at IS NULL
+			              AND f.tenant_id = $1 AND ($2::text IS NULL OR f.owner_id = $2)), 0),
+			(SELECT COUNT(*) FROM file_metadata f
+			 WHERE f.status <> 'failed' AND f.deleted_at IS NULL
+			   AND f.tenant_id = $1 AND ($2::text IS NULL OR f.owner_id = $2))`
+
+	var usage domain.Usage
+	err := s.db.QueryRowContext(ctx, query, domain.TenantOrDefault(ctx), ownerID).Scan(
+		&usage.BytesStored,
+		&usage.BytesReserved,
+		&usage.FileCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error computing usage: %w", err)
+	}
+	return &usage, nil
+}
@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository/postgres"
+	"score-play/internal/core/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlUsageRepository(t *testing.T) {
+	dbConnection, cleanup, truncate := postgres.NewTestDB(t)
+	defer cleanup()
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	fileRepo := postgres.NewSqlFileRepository(dbConnection)
+	versionRepo := postgres.NewSqlFileVersionRepository(dbConnection)
+	repo := postgres.NewSqlUsageRepository(dbConnection)
+	owner := "user-1"
+
+	createCompleted := func(t *testing.T, ctx context.Context, size int64, ownerID *string) uuid.UUID {
+		fileID := uuid.New()
+		require.NoError(t, fileRepo.Create(ctx, fileID, "a.mp4", "video/mp4", domain.FileTypeVideo, size,
+			domain.FileStatusCompleted, "sum", "bucket", fileID.String(), ownerID))
+		require.NoError(t, versionRepo.Create(ctx, domain.FileVersion{
+			ID: fileID, FileID: fileID, Version: 1, Filename: "a.mp4", MimeType: "video/mp4",
+			SizeBytes: size, Bucket: "bucket", StorageKey: fileID.String(), Status: domain.FileStatusCompleted,
+		}))
+		return fileID
+	}
+
+	t.Run("Get - counts stored and reserved bytes per tenant and owner", func(t *testing.T) {
+		// Arrange
+		truncate()
+		createCompleted(t, ctx, 100, &owner)
+		createCompleted(t, ctx, 50, nil)
+		createCompleted(t, domain.ContextWithTenant(context.Background(), "globex"), 1000, &owner)
+		require.NoError(t, fileRepo.Create(ctx, uuid.New(), "b.mp4", "video/mp4", domain.FileTypeVideo, 30,
+			domain.FileStatusUploading, "sum", "bucket", "uploading", &owner))
+
+		// Act
+		tenant, tenantErr := repo.Get(ctx, nil)
+		user, userErr := repo.Get(ctx, &owner)
+
+		// Assert
+		require.NoError(t, tenantErr)
+		assert.Equal(t, &domain.Usage{BytesStored: 150, BytesReserved: 30, FileCount: 3}, tenant)
+		require.NoError(t, userErr)
+		assert.Equal(t, &domain.Usage{BytesStored: 100, BytesReserved: 30, FileCount: 2}, user)
+	})
+
+	t.Run("Get - failed and deleted uploads are released", func(t *testing.T) {
+		// Arrange
+		truncate()
+		fileID := uuid.New()
+		require.NoError(t, fileRepo.Create(ctx, fileID, "b.mp4", "video/mp4", domain.FileTypeVideo, 30,
+			domain.FileStatusUploading, "sum", "bucket", "uploading", nil))
+		require.NoError(t, fileRepo.UpdateStatus(ctx, fileID, domain.FileStatusFailed))
+		require.NoError(t, fileRepo.Delete(ctx, fileID))
+
+		// Act
+		usage, err := repo.Get(ctx, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &domain.Usage{}, usage)
+	})
+
+	t.Run("Lock - can be taken in a transaction", func(t *testing.T) {
+		// Arrange
+		truncate()
+		tx, err := dbConnection.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		// Act
+		err = postgres.NewSqlUsageRepository(tx).Lock(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"net/url"
+	"score-play/internal/adapters/tracing"
 	"score-play/internal/config"
 	"score-play/internal/core/domain"
 	"sort"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
 // Adapter is an adapter for minio
@@ -35,30 +38,117 @@ func NewAdapter(ctx context.Context, cfg config.MinioConfig, logger *slog.Logger
 		return nil, fmt.Errorf("failed to create minio client: %w", err)
 	}
 
-	exists, err := client.BucketExists(ctx, cfg.BucketName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if bucket exists: %w", err)
+	core := minio.Core{Client: client}
+	adapter := &Adapter{client: client, config: cfg, core: &core, logger: logger}
+
+	for _, bucket := range adapter.Buckets() {
+		exists, err := client.BucketExists(ctx, bucket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if bucket exists: %w", err)
+		}
+		if !exists {
+			if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+				return nil, fmt.Errorf("failed to create bucket: %w", err)
+			}
+		}
+
+		if cfg.Lifecycle.Enabled {
+			if err := adapter.ApplyLifecycle(ctx, bucket); err != nil {
+				return nil, err
+			}
+		}
 	}
-	if !exists {
-		if err := client.MakeBucket(ctx, cfg.BucketName, minio.MakeBucketOptions{}); err != nil {
-			return nil, fmt.Errorf("failed to create bucket: %w", err)
+
+	return adapter, nil
+}
+
+// CheckBuckets checks minio answers and every bucket objects are routed to exists
+func (a *Adapter) CheckBuckets(ctx context.Context) (map[string]any, error) {
+	buckets := a.Buckets()
+	for _, bucket := range buckets {
+		exists, err := a.client.BucketExists(ctx, bucket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check bucket %s: %w", bucket, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("bucket %s does not exist", bucket)
 		}
 	}
+	return map[string]any{"buckets": buckets}, nil
+}
 
-	core := minio.Core{Client: client}
-	return &Adapter{client: client, config: cfg, core: &core, logger: logger}, nil
+// ApplyLifecycle replaces the bucket lifecycle rules with the ones built from config
+func (a *Adapter) ApplyLifecycle(ctx context.Context, bucket string) error {
+	lifecycleCfg := buildLifecycleConfig(a.config.Lifecycle)
+
+	if len(lifecycleCfg.Rules) == 0 {
+		a.logger.Warn("lifecycle enabled but no rule configured", slog.String("bucket", bucket))
+		return nil
+	}
+
+	if err := a.client.SetBucketLifecycle(ctx, bucket, lifecycleCfg); err != nil {
+		return fmt.Errorf("failed to set bucket lifecycle: %w", err)
+	}
+
+	a.logger.Info("bucket lifecycle applied",
+		slog.String("bucket", bucket),
+		slog.Int("rules", len(lifecycleCfg.Rules)))
+
+	return nil
+}
+
+func buildLifecycleConfig(cfg config.LifecycleConfig) *lifecycle.Configuration {
+	lifecycleCfg := lifecycle.NewConfiguration()
+
+	if cfg.TransitionAfterDays > 0 && cfg.TransitionStorageClass != "" {
+		lifecycleCfg.Rules = append(lifecycleCfg.Rules, lifecycle.Rule{
+			ID:     "transition",
+			Status: "Enabled",
+			Transition: lifecycle.Transition{
+				Days:         lifecycle.ExpirationDays(cfg.TransitionAfterDays),
+				StorageClass: cfg.TransitionStorageClass,
+			},
+		})
+	}
+
+	if cfg.NoncurrentExpirationDays > 0 {
+		lifecycleCfg.Rules = append(lifecycleCfg.Rules, lifecycle.Rule{
+			ID:     "expire-noncurrent",
+			Status: "Enabled",
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+				NoncurrentDays: lifecycle.ExpirationDays(cfg.NoncurrentExpirationDays),
+			},
+		})
+	}
+
+	// safety net in case the cleanup task never aborts an upload
+	if cfg.AbortIncompleteMultipartDays > 0 {
+		lifecycleCfg.Rules = append(lifecycleCfg.Rules, lifecycle.Rule{
+			ID:     "abort-incomplete-multipart",
+			Status: "Enabled",
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: lifecycle.ExpirationDays(cfg.AbortIncompleteMultipartDays),
+			},
+		})
+	}
+
+	return lifecycleCfg
 }
 
 // GeneratePresignedURLSimpleUpload is a func that generates a presigned url for a simple upload
-func (a *Adapter) GeneratePresignedURLSimpleUpload(ctx context.Context, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+func (a *Adapter) GeneratePresignedURLSimpleUpload(ctx context.Context, bucket string, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error) {
 
 	requestHeaders := make(http.Header)
 	requestHeaders.Set("x-amz-checksum-sha256", checksumSha256)
 	requestHeaders.Set("x-amz-sdk-checksum-algorithm", "SHA256")
 	requestHeaders.Set("x-amz-checksum-sha256", checksumSha256)
 	requestHeaders.Set("x-amz-meta-checksum-sha256", checksumSha256)
+	// lets the worker link the validation of the object to this request
+	for k, v := range tracing.ObjectMetadata(ctx) {
+		requestHeaders.Set("x-amz-meta-"+k, v)
+	}
 
-	presignedURL, err := a.client.PresignHeader(ctx, http.MethodPut, a.config.BucketName, fileKey, a.config.SimplePresignedDuration, nil, requestHeaders)
+	presignedURL, err := a.client.PresignHeader(ctx, http.MethodPut, a.bucketOrDefault(bucket), fileKey, a.config.SimplePresignedDuration, nil, requestHeaders)
 
 	if err != nil {
 
@@ -70,8 +160,50 @@ func (a *Adapter) GeneratePresignedURLSimpleUpload(ctx context.Context, fileKey
 	return presignedURL.String(), a.headerToMap(requestHeaders), &expiresAt, nil
 }
 
+// GeneratePresignedPostPolicy is a func that signs a post policy for a simple upload from an html form.
+// The policy pins the key, the content type and the size, and carries the checksum like the presigned url does.
+func (a *Adapter) GeneratePresignedPostPolicy(ctx context.Context, bucket string, fileKey string, contentType string, sizeBytes int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+	expiresAt := time.Now().Add(a.config.SimplePresignedDuration)
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(a.bucketOrDefault(bucket)); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetKey(fileKey); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetExpires(expiresAt); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetContentType(contentType); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetContentLengthRange(sizeBytes, sizeBytes); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetChecksum(minio.NewChecksumString(minio.ChecksumSHA256, checksumSha256)); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	if err := policy.SetUserMetadata("checksum-sha256", checksumSha256); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+	}
+	// lets the worker link the validation of the object to this request
+	for k, v := range tracing.ObjectMetadata(ctx) {
+		if err := policy.SetUserMetadata(k, v); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to build post policy: %w", err)
+		}
+	}
+
+	postURL, fields, err := a.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to generate post policy: %w", err)
+	}
+
+	return postURL.String(), fields, &expiresAt, nil
+}
+
 // InitMultipartUpload inits a multi part upload
-func (a *Adapter) InitMultipartUpload(ctx context.Context, fileKey string, checksum string) (string, error) {
+func (a *Adapter) InitMultipartUpload(ctx context.Context, bucket string, fileKey string, checksum string) (string, error) {
 
 	opts := minio.PutObjectOptions{
 		UserMetadata: map[string]string{
@@ -79,7 +211,10 @@ func (a *Adapter) InitMultipartUpload(ctx context.Context, fileKey string, check
 			"Checksum-Sha256":          checksum,
 		},
 	}
-	uploadID, err := a.core.NewMultipartUpload(ctx, a.config.BucketName, fileKey, opts)
+	for k, v := range tracing.ObjectMetadata(ctx) {
+		opts.UserMetadata[k] = v
+	}
+	uploadID, err := a.core.NewMultipartUpload(ctx, a.bucketOrDefault(bucket), fileKey, opts)
 	if err != nil {
 		return "", fmt.Errorf("failed to init multipart upload: %w", err)
 	}
@@ -87,7 +222,7 @@ func (a *Adapter) InitMultipartUpload(ctx context.Context, fileKey string, check
 }
 
 // GeneratePresignedURLForPart generates presigned url for a part
-func (a *Adapter) GeneratePresignedURLForPart(ctx context.Context, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+func (a *Adapter) GeneratePresignedURLForPart(ctx context.Context, bucket string, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
 	reqParams := make(url.Values)
 	reqParams.Set("partNumber", fmt.Sprintf("%d", partNumber))
 	reqParams.Set("uploadId", uploadID)
@@ -98,7 +233,7 @@ func (a *Adapter) GeneratePresignedURLForPart(ctx context.Context, fileKey strin
 	//reqHeaders.Set("Content-Length", fmt.Sprintf("%d", contentLength))
 	reqHeaders.Set("x-amz-sdk-checksum-algorithm", "SHA256")
 
-	presignedURL, err := a.core.PresignHeader(ctx, http.MethodPut, a.config.BucketName, fileKey, a.config.MultiPartPresignedDuration, reqParams, reqHeaders)
+	presignedURL, err := a.core.PresignHeader(ctx, http.MethodPut, a.bucketOrDefault(bucket), fileKey, a.config.MultiPartPresignedDuration, reqParams, reqHeaders)
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("failed to generate presigned URL for part: %w", err)
 	}
@@ -108,7 +243,7 @@ func (a *Adapter) GeneratePresignedURLForPart(ctx context.Context, fileKey strin
 }
 
 // CompleteMultipartUpload marks the minio multipart as complete
-func (a *Adapter) CompleteMultipartUpload(ctx context.Context, fileKey string, uploadID string, parts []domain.UploadPart) error {
+func (a *Adapter) CompleteMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string, parts []domain.UploadPart) error {
 
 	sort.Slice(parts, func(i, j int) bool {
 		return parts[i].PartNumber < parts[j].PartNumber
@@ -129,7 +264,7 @@ func (a *Adapter) CompleteMultipartUpload(ctx context.Context, fileKey string, u
 		SendContentMd5: false,
 	}
 
-	_, err := a.core.CompleteMultipartUpload(ctx, a.config.BucketName, fileKey, uploadID, completeParts, opts)
+	_, err := a.core.CompleteMultipartUpload(ctx, a.bucketOrDefault(bucket), fileKey, uploadID, completeParts, opts)
 	if err != nil {
 		return fmt.Errorf("failed to complete multipart upload: %w", err)
 	}
@@ -138,8 +273,8 @@ func (a *Adapter) CompleteMultipartUpload(ctx context.Context, fileKey string, u
 }
 
 // GetObject retrieves an obj
-func (a *Adapter) GetObject(ctx context.Context, fileKey string) (io.ReadCloser, error) {
-	object, err := a.client.GetObject(ctx, a.config.BucketName, fileKey, minio.GetObjectOptions{})
+func (a *Adapter) GetObject(ctx context.Context, bucket string, fileKey string) (io.ReadCloser, error) {
+	object, err := a.client.GetObject(ctx, a.bucketOrDefault(bucket), fileKey, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get object: %w", err)
 	}
@@ -147,12 +282,12 @@ func (a *Adapter) GetObject(ctx context.Context, fileKey string) (io.ReadCloser,
 }
 
 // ListPartsPaginated lists uploaded parts with pagination
-func (a *Adapter) ListPartsPaginated(ctx context.Context, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error) {
+func (a *Adapter) ListPartsPaginated(ctx context.Context, bucket string, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error) {
 	if maxParts <= 0 || maxParts > 1000 {
 		maxParts = 1000 //max size for minio
 	}
 
-	result, err := a.core.ListObjectParts(ctx, a.config.BucketName, fileKey, uploadID, partNumberMarker, maxParts)
+	result, err := a.core.ListObjectParts(ctx, a.bucketOrDefault(bucket), fileKey, uploadID, partNumberMarker, maxParts)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list parts: %w", err)
 	}
@@ -170,17 +305,90 @@ func (a *Adapter) ListPartsPaginated(ctx context.Context, fileKey string, upload
 	return parts, result.NextPartNumberMarker, nil
 }
 
-// GetObjectInfo retrieves obj info
-func (a *Adapter) GetObjectInfo(ctx context.Context, fileKey string) (*minio.ObjectInfo, error) {
-	info, err := a.client.StatObject(ctx, a.config.BucketName, fileKey, minio.StatObjectOptions{})
+// UploadPart sends part partNumber of a multipart upload, the storage rejects data not matching checksumSha256
+func (a *Adapter) UploadPart(ctx context.Context, bucket string, fileKey string, uploadID string, partNumber int, data io.Reader, size int64, checksumSha256 string) (domain.UploadPart, error) {
+	headers := make(http.Header)
+	headers.Set("x-amz-checksum-sha256", checksumSha256)
+
+	part, err := a.core.PutObjectPart(ctx, a.bucketOrDefault(bucket), fileKey, uploadID, partNumber, data, size, minio.PutObjectPartOptions{CustomHeader: headers})
 	if err != nil {
+		return domain.UploadPart{}, fmt.Errorf("failed to upload part: %w", err)
+	}
+	return domain.UploadPart{
+		PartNumber:     part.PartNumber,
+		ETag:           strings.Trim(part.ETag, "\""),
+		ChecksumSHA256: checksumSha256,
+		ContentLength:  size,
+	}, nil
+}
+
+// PutObject stores data under fileKey, replacing the object if it exists
+func (a *Adapter) PutObject(ctx context.Context, bucket string, fileKey string, data io.Reader, size int64) error {
+	_, err := a.client.PutObject(ctx, a.bucketOrDefault(bucket), fileKey, data, size, minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+// GetObjectInfo retrieves obj info, the error wraps domain.ErrObjectNotFound when there is no such object
+func (a *Adapter) GetObjectInfo(ctx context.Context, bucket string, fileKey string) (*minio.ObjectInfo, error) {
+	info, err := a.client.StatObject(ctx, a.bucketOrDefault(bucket), fileKey, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return nil, fmt.Errorf("failed to get object info: %w", domain.ErrObjectNotFound)
+		}
 		return nil, fmt.Errorf("failed to get object info: %w", err)
 	}
 	return &info, nil
 }
 
-func (a *Adapter) AbortMultipartUpload(ctx context.Context, fileKey string, uploadID string) error {
-	err := a.core.AbortMultipartUpload(ctx, a.config.BucketName, fileKey, uploadID)
+// ListObjects calls fn for every object of bucket, the listing stops when fn fails
+func (a *Adapter) ListObjects(ctx context.Context, bucket string, fn func(object domain.StoredObject) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	bucket = a.bucketOrDefault(bucket)
+	for object := range a.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+		if err := fn(domain.StoredObject{
+			Bucket:       bucket,
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListIncompleteUploads calls fn for every multipart upload in progress in bucket, the listing stops when fn fails
+func (a *Adapter) ListIncompleteUploads(ctx context.Context, bucket string, fn func(upload domain.IncompleteUpload) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	bucket = a.bucketOrDefault(bucket)
+	for upload := range a.client.ListIncompleteUploads(ctx, bucket, "", true) {
+		if upload.Err != nil {
+			return fmt.Errorf("failed to list incomplete uploads: %w", upload.Err)
+		}
+		if err := fn(domain.IncompleteUpload{
+			Bucket:    bucket,
+			Key:       upload.Key,
+			UploadID:  upload.UploadID,
+			Initiated: upload.Initiated,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Adapter) AbortMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string) error {
+	err := a.core.AbortMultipartUpload(ctx, a.bucketOrDefault(bucket), fileKey, uploadID)
 	if err != nil {
 		return fmt.Errorf("failed to abort multipart upload: %w", err)
 	}
@@ -192,22 +400,22 @@ func (a *Adapter) AbortMultipartUpload(ctx context.Context, fileKey string, uplo
 	return nil
 }
 
-func (a *Adapter) GetHeaderBytes(ctx context.Context, fileKey string, n int64) ([]byte, error) {
+func (a *Adapter) GetHeaderBytes(ctx context.Context, bucket string, fileKey string, n int64) ([]byte, error) {
 	opts := minio.GetObjectOptions{}
 	err := opts.SetRange(0, n-1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set range: %w", err)
 	}
 
-	object, err := a.client.GetObject(ctx, a.config.BucketName, fileKey, opts)
+	object, err := a.client.GetObject(ctx, a.bucketOrDefault(bucket), fileKey, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get partial object: %w", err)
 	}
 	defer object.Close()
 
 	buffer := make([]byte, n)
-	numRead, err := object.Read(buffer)
-	if err != nil && err != io.EOF {
+	numRead, err := io.ReadFull(object, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return nil, fmt.Errorf("failed to read header bytes: %w", err)
 	}
 
@@ -215,29 +423,94 @@ func (a *Adapter) GetHeaderBytes(ctx context.Context, fileKey string, n int64) (
 }
 
 // DeleteObject deletes an object from storage
-func (a *Adapter) DeleteObject(ctx context.Context, fileKey string) error {
-	err := a.client.RemoveObject(ctx, a.config.BucketName, fileKey, minio.RemoveObjectOptions{})
+func (a *Adapter) DeleteObject(ctx context.Context, bucket string, fileKey string) error {
+	err := a.client.RemoveObject(ctx, a.bucketOrDefault(bucket), fileKey, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete object: %w", err)
 	}
 
 	a.logger.Info("object deleted",
 		slog.String("fileKey", fileKey),
-		slog.String("bucket", a.config.BucketName))
+		slog.String("bucket", a.bucketOrDefault(bucket)))
 
 	return nil
 }
 
-// GeneratePresignedURLForDownload generates a presigned URL for downloading a file
-func (a *Adapter) GeneratePresignedURLForDownload(ctx context.Context, fileKey string) (string, *time.Time, error) {
-	presignedURL, err := a.client.PresignedGetObject(ctx, a.config.BucketName, fileKey, a.config.SimplePresignedDuration, nil)
+// GeneratePresignedURLForDownload generates a presigned URL for downloading a file.
+// The response headers are overridden from the options, and a range is signed so the client must send the returned headers.
+func (a *Adapter) GeneratePresignedURLForDownload(ctx context.Context, bucket string, fileKey string, opts domain.DownloadOptions) (string, map[string]string, *time.Time, error) {
+	ttl := a.config.DownloadSignedURLDuration
+	if opts.TTL > 0 {
+		ttl = opts.TTL
+	}
+	if a.config.DownloadSignedURLMaxTTL > 0 && ttl > a.config.DownloadSignedURLMaxTTL {
+		ttl = a.config.DownloadSignedURLMaxTTL
+	}
+
+	params := url.Values{}
+	if opts.Disposition != "" {
+		disposition := opts.Disposition
+		if opts.Filename != "" {
+			disposition = mime.FormatMediaType(opts.Disposition, map[string]string{"filename": opts.Filename})
+		}
+		params.Set("response-content-disposition", disposition)
+	}
+	if opts.ContentType != "" {
+		params.Set("response-content-type", opts.ContentType)
+	}
+
+	headers := http.Header{}
+	if opts.Range != "" {
+		headers.Set("Range", opts.Range)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	presignedURL, err := a.client.PresignHeader(ctx, http.MethodGet, a.bucketOrDefault(bucket), fileKey, ttl, params, headers)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate presigned download URL: %w", err)
+		return "", nil, nil, fmt.Errorf("failed to generate presigned download URL: %w", err)
+	}
+
+	return presignedURL.String(), a.headerToMap(headers), &expiresAt, nil
+}
+
+// RestoreObject requests a temporary restored copy of an archived object
+func (a *Adapter) RestoreObject(ctx context.Context, bucket string, fileKey string) error {
+	req := minio.RestoreRequest{}
+	req.SetDays(a.config.RestoreDays)
+
+	if err := a.client.RestoreObject(ctx, a.bucketOrDefault(bucket), fileKey, "", req); err != nil {
+		return fmt.Errorf("failed to restore object: %w", err)
+	}
+
+	a.logger.Info("object restore requested",
+		slog.String("fileKey", fileKey),
+		slog.Int("days", a.config.RestoreDays))
+
+	return nil
+}
+
+// CopyObject copies an object server side, the data never goes through the API.
+// ComposeObject switches to a multipart copy for sources over 5GiB and keeps the source user metadata.
+// A clip is copied with a ranged multipart copy, without the source checksum which no longer matches.
+func (a *Adapter) CopyObject(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, clip *domain.Clip) error {
+	dst := minio.CopyDestOptions{Bucket: a.bucketOrDefault(dstBucket), Object: dstKey}
+	src := minio.CopySrcOptions{Bucket: a.bucketOrDefault(srcBucket), Object: srcKey}
+	if clip != nil {
+		src.MatchRange = true
+		src.Start = clip.Offset
+		src.End = clip.Offset + clip.Length - 1
+		dst.ReplaceMetadata = true
 	}
 
-	expiresAt := time.Now().Add(a.config.DownloadSignedURLDuration)
+	if _, err := a.client.ComposeObject(ctx, dst, src); err != nil {
+		return fmt.Errorf("failed to copy object: %w", err)
+	}
+
+	a.logger.Info("object copied",
+		slog.String("srcKey", srcKey),
+		slog.String("dstKey", dstKey))
 
-	return presignedURL.String(), &expiresAt, nil
+	return nil
 }
 
 func (a *Adapter) headerToMap(headers http.Header) map[string]string {
@@ -1,6 +1,7 @@
 package minio_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"math/rand"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"score-play/internal/adapters/storage/minio"
@@ -18,6 +20,8 @@ import (
 	"testing"
 	"time"
 
+	minioclient "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -129,7 +133,7 @@ func TestSimpleUpload(t *testing.T) {
 	checksumHash := calculateSHA256(fileContent)
 
 	// Act
-	presignedURL, headers, expiresAt, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksumHash)
+	presignedURL, headers, expiresAt, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksumHash)
 
 	// Assert
 	require.NoError(t, err)
@@ -154,7 +158,7 @@ func TestSimpleUpload(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-	object, err := adapter.GetObject(ctx, fileKey)
+	object, err := adapter.GetObject(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, object)
@@ -162,6 +166,62 @@ func TestSimpleUpload(t *testing.T) {
 	assert.Equal(t, fileContent, buf.String())
 }
 
+// postForm posts content to url as an html form would, the fields first then the file
+func postForm(t *testing.T, url string, fields map[string]string, content string) *http.Response {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for key, value := range fields {
+		require.NoError(t, form.WriteField(key, value))
+	}
+	part, err := form.CreateFormFile("file", "upload.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	resp, err := http.Post(url, form.FormDataContentType(), &body)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestPostPolicyUpload(t *testing.T) {
+	// Arrange
+	endpoint, cleanup := setupContainer(t)
+	defer cleanup()
+	ctx := context.Background()
+	adapter := createAdapter(t, endpoint, ctx)
+
+	fileKey := "test-files/post-upload.txt"
+	fileContent := "Hello, MinIO!"
+	checksumHash := calculateSHA256(fileContent)
+
+	// Act
+	postURL, fields, expiresAt, err := adapter.GeneratePresignedPostPolicy(ctx, testBucket, fileKey, "text/plain", int64(len(fileContent)), checksumHash)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, expiresAt.After(time.Now()))
+	assert.Equal(t, fileKey, fields["key"])
+	assert.Equal(t, "text/plain", fields["Content-Type"])
+
+	t.Run("Should reject another size", func(t *testing.T) {
+		resp := postForm(t, postURL, fields, fileContent+"!")
+		defer resp.Body.Close()
+		assert.True(t, resp.StatusCode >= 400)
+	})
+
+	t.Run("Should store the file", func(t *testing.T) {
+		resp := postForm(t, postURL, fields, fileContent)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		info, err := adapter.GetObjectInfo(ctx, testBucket, fileKey)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(fileContent)), info.Size)
+		assert.Equal(t, checksumHash, info.UserMetadata["Checksum-Sha256"])
+	})
+}
+
 func TestMultipartUpload(t *testing.T) {
 	// Arrange
 	endpoint, cleanup := setupContainer(t)
@@ -184,7 +244,7 @@ func TestMultipartUpload(t *testing.T) {
 	}
 
 	// Act
-	uploadID, err := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, err := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 
 	// Assert
 	require.NoError(t, err)
@@ -197,7 +257,7 @@ func TestMultipartUpload(t *testing.T) {
 	for _, part := range parts {
 		checksumHash := calculateSHA256(part.content)
 
-		presignedURL, headers, expiresAt, presignErr := adapter.GeneratePresignedURLForPart(ctx, fileKey, part.number, uploadID, contentType, int64(len(part.content)), checksumHash)
+		presignedURL, headers, expiresAt, presignErr := adapter.GeneratePresignedURLForPart(ctx, testBucket, fileKey, part.number, uploadID, contentType, int64(len(part.content)), checksumHash)
 		require.NoError(t, presignErr)
 		require.NotNil(t, expiresAt)
 		validateS3PresignedRequest(t, presignedURL, headers, checksumHash)
@@ -226,12 +286,12 @@ func TestMultipartUpload(t *testing.T) {
 	}
 
 	// Act
-	err = adapter.CompleteMultipartUpload(ctx, fileKey, uploadID, completedParts)
+	err = adapter.CompleteMultipartUpload(ctx, testBucket, fileKey, uploadID, completedParts)
 
 	// Assert
 	require.NoError(t, err)
 
-	object, err := adapter.GetObject(ctx, fileKey)
+	object, err := adapter.GetObject(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, object)
@@ -253,7 +313,7 @@ func TestSimpleUpload_InvalidChecksum_ShouldFail(t *testing.T) {
 	checksumHash := calculateSHA256(originalContent)
 
 	// Act
-	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksumHash)
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksumHash)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(maliciousContent))
@@ -294,7 +354,7 @@ func TestSimpleUpload_ExpiredURL_ShouldFail(t *testing.T) {
 	checksum := calculateSHA256(content)
 
 	// Act
-	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksum)
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksum)
 	require.NoError(t, err)
 
 	time.Sleep(2 * time.Second)
@@ -325,7 +385,7 @@ func TestListPartsPaginated(t *testing.T) {
 	contentType := "text/plain"
 	client := &http.Client{Timeout: 10 * time.Second}
 
-	uploadID, err := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, err := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 	require.NoError(t, err)
 
 	expectedChecksums := make(map[int]string)
@@ -335,7 +395,7 @@ func TestListPartsPaginated(t *testing.T) {
 		checksum := calculateSHA256(content)
 		expectedChecksums[i] = checksum
 
-		url, headers, _, _ := adapter.GeneratePresignedURLForPart(ctx, fileKey, i, uploadID, contentType, int64(len(content)), checksum)
+		url, headers, _, _ := adapter.GeneratePresignedURLForPart(ctx, testBucket, fileKey, i, uploadID, contentType, int64(len(content)), checksum)
 
 		req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(content))
 		for k, v := range headers {
@@ -348,7 +408,7 @@ func TestListPartsPaginated(t *testing.T) {
 
 	t.Run("Should list all parts", func(t *testing.T) {
 		// Act
-		parts, nextMarker, err := adapter.ListPartsPaginated(ctx, fileKey, uploadID, 10, 0)
+		parts, nextMarker, err := adapter.ListPartsPaginated(ctx, testBucket, fileKey, uploadID, 10, 0)
 
 		// Assert
 		require.NoError(t, err)
@@ -369,7 +429,7 @@ func TestListPartsPaginated(t *testing.T) {
 	})
 
 	t.Run("Should paginate correctly", func(t *testing.T) {
-		parts1, marker1, err1 := adapter.ListPartsPaginated(ctx, fileKey, uploadID, 2, 0)
+		parts1, marker1, err1 := adapter.ListPartsPaginated(ctx, testBucket, fileKey, uploadID, 2, 0)
 
 		require.NoError(t, err1)
 		assert.Len(t, parts1, 2)
@@ -380,7 +440,7 @@ func TestListPartsPaginated(t *testing.T) {
 			assert.Equal(t, expectedChecksums[part.PartNumber], part.ChecksumSHA256)
 		}
 
-		parts2, marker2, err2 := adapter.ListPartsPaginated(ctx, fileKey, uploadID, 2, marker1)
+		parts2, marker2, err2 := adapter.ListPartsPaginated(ctx, testBucket, fileKey, uploadID, 2, marker1)
 
 		require.NoError(t, err2)
 		assert.Len(t, parts2, 1)
@@ -393,7 +453,7 @@ func TestListPartsPaginated(t *testing.T) {
 
 	t.Run("Should handle invalid maxParts", func(t *testing.T) {
 		// Act
-		parts, _, err := adapter.ListPartsPaginated(ctx, fileKey, uploadID, -1, 0)
+		parts, _, err := adapter.ListPartsPaginated(ctx, testBucket, fileKey, uploadID, -1, 0)
 
 		// Assert
 		require.NoError(t, err)
@@ -410,7 +470,7 @@ func TestListPartsPaginated_Errors(t *testing.T) {
 	adapter := createAdapter(t, endpoint, ctx)
 
 	// Act
-	parts, marker, err := adapter.ListPartsPaginated(ctx, "non-existent", "invalid-id", 10, 0)
+	parts, marker, err := adapter.ListPartsPaginated(ctx, testBucket, "non-existent", "invalid-id", 10, 0)
 
 	// Assert
 	assert.Error(t, err)
@@ -426,7 +486,7 @@ func TestCompleteMultipartUpload_Success(t *testing.T) {
 	adapter := createAdapter(t, endpoint, ctx)
 
 	fileKey := "test-files/large-multipart.bin"
-	uploadID, err := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, err := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 	require.NoError(t, err)
 
 	const minPartSize = 5 * 1024 * 1024
@@ -445,7 +505,7 @@ func TestCompleteMultipartUpload_Success(t *testing.T) {
 
 	for _, p := range partsData {
 		checksum := calculateSHA256(p.content)
-		url, headers, _, _ := adapter.GeneratePresignedURLForPart(ctx, fileKey, p.number, uploadID, "application/octet-stream", int64(len(p.content)), checksum)
+		url, headers, _, _ := adapter.GeneratePresignedURLForPart(ctx, testBucket, fileKey, p.number, uploadID, "application/octet-stream", int64(len(p.content)), checksum)
 
 		req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(p.content))
 		for k, v := range headers {
@@ -465,12 +525,12 @@ func TestCompleteMultipartUpload_Success(t *testing.T) {
 	}
 
 	// Act
-	err = adapter.CompleteMultipartUpload(ctx, fileKey, uploadID, completedParts)
+	err = adapter.CompleteMultipartUpload(ctx, testBucket, fileKey, uploadID, completedParts)
 
 	// Assert
 	require.NoError(t, err)
 
-	objInfo, err := adapter.GetObjectInfo(ctx, fileKey)
+	objInfo, err := adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 	assert.Equal(t, expectedTotalSize, objInfo.Size)
 }
@@ -484,7 +544,7 @@ func TestCompleteMultipartUpload_ShouldSortParts(t *testing.T) {
 	adapter := createAdapter(t, endpoint, ctx)
 
 	fileKey := "test-files/random-order-parts.bin"
-	uploadID, err := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, err := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 	require.NoError(t, err)
 
 	const (
@@ -501,6 +561,7 @@ func TestCompleteMultipartUpload_ShouldSortParts(t *testing.T) {
 
 		url, headers, _, err := adapter.GeneratePresignedURLForPart(
 			ctx,
+			testBucket,
 			fileKey,
 			partNumber,
 			uploadID,
@@ -535,12 +596,12 @@ func TestCompleteMultipartUpload_ShouldSortParts(t *testing.T) {
 	})
 
 	// Act
-	err = adapter.CompleteMultipartUpload(ctx, fileKey, uploadID, parts)
+	err = adapter.CompleteMultipartUpload(ctx, testBucket, fileKey, uploadID, parts)
 
 	// Assert
 	assert.NoError(t, err)
 
-	objInfo, err := adapter.GetObjectInfo(ctx, fileKey)
+	objInfo, err := adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 
 	assert.Equal(t, int64(minPartSize*partCount), objInfo.Size)
@@ -554,14 +615,14 @@ func TestCompleteMultipartUpload_Error_InvalidPart(t *testing.T) {
 	adapter := createAdapter(t, endpoint, ctx)
 
 	fileKey := "test-files/invalid-part.txt"
-	uploadID, _ := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, _ := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 
 	badParts := []domain.UploadPart{
 		{PartNumber: 1, ETag: "\"invalid-etag\""},
 	}
 
 	// Act
-	err := adapter.CompleteMultipartUpload(ctx, fileKey, uploadID, badParts)
+	err := adapter.CompleteMultipartUpload(ctx, testBucket, fileKey, uploadID, badParts)
 
 	// Assert
 	assert.Error(t, err)
@@ -578,7 +639,7 @@ func TestDeleteObject_Success(t *testing.T) {
 	fileContent := "This file will be deleted"
 	checksumHash := calculateSHA256(fileContent)
 
-	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksumHash)
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksumHash)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(fileContent))
@@ -593,16 +654,16 @@ func TestDeleteObject_Success(t *testing.T) {
 	resp.Body.Close()
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 
-	_, err = adapter.GetObjectInfo(ctx, fileKey)
+	_, err = adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 
 	// Act
-	err = adapter.DeleteObject(ctx, fileKey)
+	err = adapter.DeleteObject(ctx, testBucket, fileKey)
 
 	// Assert
 	require.NoError(t, err)
 
-	_, err = adapter.GetObjectInfo(ctx, fileKey)
+	_, err = adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	assert.Error(t, err, "File should not exist after deletion")
 }
 
@@ -616,7 +677,7 @@ func TestDeleteObject_NonExistentFile(t *testing.T) {
 	nonExistentKey := "test-files/does-not-exist.txt"
 
 	// Act
-	err := adapter.DeleteObject(ctx, nonExistentKey)
+	err := adapter.DeleteObject(ctx, testBucket, nonExistentKey)
 
 	// Assert
 	require.NoError(t, err, "Deleting non-existent file should not return error")
@@ -641,7 +702,7 @@ func TestDeleteObject_AfterMultipartUpload(t *testing.T) {
 		{content: "Last part", number: 2},
 	}
 
-	uploadID, err := adapter.InitMultipartUpload(ctx, fileKey, "")
+	uploadID, err := adapter.InitMultipartUpload(ctx, testBucket, fileKey, "")
 	require.NoError(t, err)
 
 	completedParts := make([]domain.UploadPart, 0, len(parts))
@@ -649,7 +710,7 @@ func TestDeleteObject_AfterMultipartUpload(t *testing.T) {
 
 	for _, part := range parts {
 		checksumHash := calculateSHA256(part.content)
-		presignedURL, headers, _, presignErr := adapter.GeneratePresignedURLForPart(ctx, fileKey, part.number, uploadID, contentType, int64(len(part.content)), checksumHash)
+		presignedURL, headers, _, presignErr := adapter.GeneratePresignedURLForPart(ctx, testBucket, fileKey, part.number, uploadID, contentType, int64(len(part.content)), checksumHash)
 		require.NoError(t, presignErr)
 
 		req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(part.content))
@@ -670,20 +731,20 @@ func TestDeleteObject_AfterMultipartUpload(t *testing.T) {
 		resp.Body.Close()
 	}
 
-	err = adapter.CompleteMultipartUpload(ctx, fileKey, uploadID, completedParts)
+	err = adapter.CompleteMultipartUpload(ctx, testBucket, fileKey, uploadID, completedParts)
 	require.NoError(t, err)
 
-	objInfo, err := adapter.GetObjectInfo(ctx, fileKey)
+	objInfo, err := adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	require.NoError(t, err)
 	require.NotNil(t, objInfo)
 
 	// Act
-	err = adapter.DeleteObject(ctx, fileKey)
+	err = adapter.DeleteObject(ctx, testBucket, fileKey)
 
 	// Assert
 	require.NoError(t, err)
 
-	_, err = adapter.GetObjectInfo(ctx, fileKey)
+	_, err = adapter.GetObjectInfo(ctx, testBucket, fileKey)
 	assert.Error(t, err, "File should not exist after deletion")
 }
 
@@ -704,7 +765,7 @@ func TestDeleteObject_MultipleDeletions(t *testing.T) {
 		content := fmt.Sprintf("Content of %s", fileKey)
 		checksum := calculateSHA256(content)
 
-		presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksum)
+		presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksum)
 		require.NoError(t, err)
 
 		req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(content))
@@ -722,13 +783,13 @@ func TestDeleteObject_MultipleDeletions(t *testing.T) {
 
 	// Act
 	for _, fileKey := range fileKeys {
-		err := adapter.DeleteObject(ctx, fileKey)
+		err := adapter.DeleteObject(ctx, testBucket, fileKey)
 		require.NoError(t, err)
 	}
 
 	// Assert
 	for _, fileKey := range fileKeys {
-		_, err := adapter.GetObjectInfo(ctx, fileKey)
+		_, err := adapter.GetObjectInfo(ctx, testBucket, fileKey)
 		assert.Error(t, err, "File %s should not exist after deletion", fileKey)
 	}
 }
@@ -744,7 +805,7 @@ func TestGeneratePresignedURLForDownload_Success(t *testing.T) {
 	fileContent := "This is a test file for download"
 	checksumHash := calculateSHA256(fileContent)
 
-	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksumHash)
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksumHash)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(fileContent))
@@ -761,7 +822,7 @@ func TestGeneratePresignedURLForDownload_Success(t *testing.T) {
 
 	// Act - Generate download URL
 	beforeGeneration := time.Now()
-	downloadURL, expiresAt, err := adapter.GeneratePresignedURLForDownload(ctx, fileKey)
+	downloadURL, _, expiresAt, err := adapter.GeneratePresignedURLForDownload(ctx, testBucket, fileKey, domain.DownloadOptions{})
 
 	// Assert
 	require.NoError(t, err)
@@ -791,6 +852,56 @@ func TestGeneratePresignedURLForDownload_Success(t *testing.T) {
 	assert.Equal(t, fileContent, string(downloadedContent))
 }
 
+func TestGeneratePresignedURLForDownload_Options(t *testing.T) {
+	// Arrange
+	endpoint, cleanup := setupContainer(t)
+	defer cleanup()
+	ctx := context.Background()
+	adapter := createAdapter(t, endpoint, ctx)
+
+	fileKey := "test-files/options-test.txt"
+	fileContent := "0123456789"
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, calculateSHA256(fileContent))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(fileContent))
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	uploadResp, err := client.Do(req)
+	require.NoError(t, err)
+	uploadResp.Body.Close()
+	require.Equal(t, http.StatusOK, uploadResp.StatusCode)
+
+	// Act
+	downloadURL, downloadHeaders, _, err := adapter.GeneratePresignedURLForDownload(ctx, testBucket, fileKey, domain.DownloadOptions{
+		Disposition: domain.DispositionAttachment,
+		Filename:    "match report.txt",
+		ContentType: "text/plain",
+		Range:       "bytes=2-5",
+	})
+	require.NoError(t, err)
+
+	downloadReq, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	require.NoError(t, err)
+	for key, value := range downloadHeaders {
+		downloadReq.Header.Set(key, value)
+	}
+	downloadResp, err := client.Do(downloadReq)
+	require.NoError(t, err)
+	defer downloadResp.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusPartialContent, downloadResp.StatusCode)
+	assert.Equal(t, `attachment; filename="match report.txt"`, downloadResp.Header.Get("Content-Disposition"))
+	assert.Equal(t, "text/plain", downloadResp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(downloadResp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "2345", string(body))
+}
+
 func TestGeneratePresignedURLForDownload_NonExistentFile(t *testing.T) {
 	// Arrange
 	endpoint, cleanup := setupContainer(t)
@@ -802,7 +913,7 @@ func TestGeneratePresignedURLForDownload_NonExistentFile(t *testing.T) {
 
 	// Act
 	beforeGeneration := time.Now()
-	downloadURL, expiresAt, err := adapter.GeneratePresignedURLForDownload(ctx, nonExistentKey)
+	downloadURL, _, expiresAt, err := adapter.GeneratePresignedURLForDownload(ctx, testBucket, nonExistentKey, domain.DownloadOptions{})
 
 	// Assert
 	require.NoError(t, err)
@@ -835,7 +946,7 @@ func TestSniffing(t *testing.T) {
 		pdfContent := "%PDF-1.4\n" + strings.Repeat("test", 100)
 		checksum := calculateSHA256(pdfContent)
 
-		url, headers, _, _ := adapter.GeneratePresignedURLSimpleUpload(ctx, fileKey, checksum)
+		url, headers, _, _ := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, fileKey, checksum)
 		req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(pdfContent))
 		for k, v := range headers {
 			req.Header.Set(k, v)
@@ -844,7 +955,7 @@ func TestSniffing(t *testing.T) {
 		resp.Body.Close()
 
 		// Act
-		bytes, err := adapter.GetHeaderBytes(ctx, fileKey, 512)
+		bytes, err := adapter.GetHeaderBytes(ctx, testBucket, fileKey, 512)
 
 		// Assert
 		require.NoError(t, err)
@@ -853,3 +964,123 @@ func TestSniffing(t *testing.T) {
 	})
 
 }
+
+func TestApplyLifecycle(t *testing.T) {
+	// Arrange
+	endpoint, cleanup := setupContainer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cfg := config.MinioConfig{
+		Endpoint:   endpoint,
+		AccessKey:  testAccessKey,
+		SecretKey:  testSecretKey,
+		BucketName: testBucket,
+		Lifecycle: config.LifecycleConfig{
+			Enabled:                      true,
+			NoncurrentExpirationDays:     30,
+			AbortIncompleteMultipartDays: 7,
+		},
+	}
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Act
+	_, err := minio.NewAdapter(ctx, cfg, discardLogger)
+
+	// Assert
+	require.NoError(t, err)
+
+	client, err := minioclient.New(endpoint, &minioclient.Options{
+		Creds: credentials.NewStaticV4(testAccessKey, testSecretKey, ""),
+	})
+	require.NoError(t, err)
+
+	lifecycleCfg, err := client.GetBucketLifecycle(ctx, testBucket)
+	require.NoError(t, err)
+	require.Len(t, lifecycleCfg.Rules, 2)
+
+	rules := make(map[string]int)
+	for _, rule := range lifecycleCfg.Rules {
+		rules[rule.ID] = int(rule.NoncurrentVersionExpiration.NoncurrentDays) + int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	}
+	assert.Equal(t, 30, rules["expire-noncurrent"])
+	assert.Equal(t, 7, rules["abort-incomplete-multipart"])
+}
+
+func TestCopyObject(t *testing.T) {
+	// Arrange
+	endpoint, cleanup := setupContainer(t)
+	defer cleanup()
+	ctx := context.Background()
+	adapter := createAdapter(t, endpoint, ctx)
+
+	fileContent := "Hello, copy!"
+	checksumHash := calculateSHA256(fileContent)
+
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, "test-files/source.txt", checksumHash)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(fileContent))
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Act
+	err = adapter.CopyObject(ctx, testBucket, "test-files/source.txt", testBucket, "test-files/copy.txt", nil)
+
+	// Assert
+	require.NoError(t, err)
+	object, err := adapter.GetObject(ctx, testBucket, "test-files/copy.txt")
+	require.NoError(t, err)
+	buf := new(strings.Builder)
+	_, err = io.Copy(buf, object)
+	require.NoError(t, err)
+	assert.Equal(t, fileContent, buf.String())
+
+	info, err := adapter.GetObjectInfo(ctx, testBucket, "test-files/copy.txt")
+	require.NoError(t, err)
+	assert.Equal(t, checksumHash, info.UserMetadata["Checksum-Sha256"])
+}
+
+func TestCopyObject_Clip(t *testing.T) {
+	// Arrange
+	endpoint, cleanup := setupContainer(t)
+	defer cleanup()
+	ctx := context.Background()
+	adapter := createAdapter(t, endpoint, ctx)
+
+	fileContent := "Hello, clipped copy!"
+	checksumHash := calculateSHA256(fileContent)
+
+	presignedURL, headers, _, err := adapter.GeneratePresignedURLSimpleUpload(ctx, testBucket, "test-files/source.txt", checksumHash)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, presignedURL, strings.NewReader(fileContent))
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Act
+	err = adapter.CopyObject(ctx, testBucket, "test-files/source.txt", testBucket, "test-files/clip.txt", &domain.Clip{Offset: 7, Length: 7})
+
+	// Assert
+	require.NoError(t, err)
+	object, err := adapter.GetObject(ctx, testBucket, "test-files/clip.txt")
+	require.NoError(t, err)
+	buf := new(strings.Builder)
+	_, err = io.Copy(buf, object)
+	require.NoError(t, err)
+	assert.Equal(t, "clipped", buf.String())
+
+	info, err := adapter.GetObjectInfo(ctx, testBucket, "test-files/clip.txt")
+	require.NoError(t, err)
+	assert.Empty(t, info.UserMetadata["Checksum-Sha256"])
+}
@@ -0,0 +1,84 @@
+package minio
+
+import (
+	"fmt"
+	"score-play/internal/core/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResolveLocation picks the bucket and the object key of a new file from the routing config
+func (a *Adapter) ResolveLocation(fileType domain.FileType, tenant string, fileID uuid.UUID, at time.Time) (string, string) {
+	bucket := a.config.BucketName
+	switch fileType {
+	case domain.FileTypeImage:
+		if a.config.Routing.ImageBucketName != "" {
+			bucket = a.config.Routing.ImageBucketName
+		}
+	case domain.FileTypeVideo:
+		if a.config.Routing.VideoBucketName != "" {
+			bucket = a.config.Routing.VideoBucketName
+		}
+	}
+
+	return bucket, buildKey(a.config.Routing.KeyTemplate, fileType, tenant, fileID, at)
+}
+
+// Buckets returns every bucket objects can be routed to
+func (a *Adapter) Buckets() []string {
+	buckets := []string{a.config.BucketName}
+	for _, bucket := range []string{a.config.Routing.ImageBucketName, a.config.Routing.VideoBucketName} {
+		if bucket == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range buckets {
+			if existing == bucket {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// bucketOrDefault falls back to the default bucket for files created before routing existed
+func (a *Adapter) bucketOrDefault(bucket string) string {
+	if bucket == "" {
+		return a.config.BucketName
+	}
+	return bucket
+}
+
+// buildKey renders the key template. The file id always ends the key since the worker parses it back from events.
+func buildKey(template string, fileType domain.FileType, tenant string, fileID uuid.UUID, at time.Time) string {
+	if template == "" {
+		template = "{tenant}/{type}/{id}"
+	}
+	at = at.UTC()
+
+	replacer := strings.NewReplacer(
+		"{tenant}", tenant,
+		"{type}", string(fileType),
+		"{yyyy}", fmt.Sprintf("%04d", at.Year()),
+		"{mm}", fmt.Sprintf("%02d", int(at.Month())),
+		"{dd}", fmt.Sprintf("%02d", at.Day()),
+		"{id}", "",
+	)
+
+	segments := strings.Split(replacer.Replace(template), "/")
+	cleaned := make([]string, 0, len(segments)+1)
+	for _, segment := range segments {
+		if segment != "" {
+			cleaned = append(cleaned, segment)
+		}
+	}
+	cleaned = append(cleaned, fileID.String())
+
+	return strings.Join(cleaned, "/")
+}
@@ -0,0 +1,57 @@
+package minio
+
+import (
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildKey(t *testing.T) {
+	fileID := uuid.New()
+	at := time.Date(2025, time.March, 7, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		tenant   string
+		expected string
+	}{
+		{"default template", "", "", "video/" + fileID.String()},
+		{"default template with tenant", "", "acme", "acme/video/" + fileID.String()},
+		{"date prefix", "{type}/{yyyy}/{mm}/{dd}/{id}", "", "video/2025/03/07/" + fileID.String()},
+		{"tenant prefix", "{tenant}/{type}/{id}", "acme", "acme/video/" + fileID.String()},
+		{"empty tenant is dropped", "{tenant}/{type}/{id}", "", "video/" + fileID.String()},
+		{"id always last", "{id}/{type}", "", "video/" + fileID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildKey(tt.template, domain.FileTypeVideo, tt.tenant, fileID, at))
+		})
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	adapter := &Adapter{config: config.MinioConfig{
+		BucketName: "default",
+		Routing: config.RoutingConfig{
+			ImageBucketName: "images",
+			KeyTemplate:     "{type}/{id}",
+		},
+	}}
+	fileID := uuid.New()
+
+	bucket, key := adapter.ResolveLocation(domain.FileTypeImage, "", fileID, time.Now())
+	assert.Equal(t, "images", bucket)
+	assert.Equal(t, "image/"+fileID.String(), key)
+
+	bucket, _ = adapter.ResolveLocation(domain.FileTypeVideo, "", fileID, time.Now())
+	assert.Equal(t, "default", bucket)
+
+	assert.Equal(t, []string{"default", "images"}, adapter.Buckets())
+	assert.Equal(t, "default", adapter.bucketOrDefault(""))
+}
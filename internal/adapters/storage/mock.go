@@ -6,6 +6,7 @@ import (
 	"score-play/internal/core/domain"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,57 +19,119 @@ func NewMockStorage() *MockStorage {
 	return &MockStorage{}
 }
 
-func (m *MockStorage) GeneratePresignedURLSimpleUpload(ctx context.Context, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error) {
-	args := m.Called(ctx, fileKey, checksumSha256)
+func (m *MockStorage) ResolveLocation(fileType domain.FileType, tenant string, fileID uuid.UUID, at time.Time) (string, string) {
+	args := m.Called(fileType, tenant, fileID, at)
+	return args.String(0), args.String(1)
+}
+
+func (m *MockStorage) GeneratePresignedURLSimpleUpload(ctx context.Context, bucket string, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, bucket, fileKey, checksumSha256)
+	return args.String(0), args.Get(1).(map[string]string), args.Get(2).(*time.Time), args.Error(3)
+}
+
+func (m *MockStorage) GeneratePresignedPostPolicy(ctx context.Context, bucket string, fileKey string, contentType string, sizeBytes int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, bucket, fileKey, contentType, sizeBytes, checksumSha256)
 	return args.String(0), args.Get(1).(map[string]string), args.Get(2).(*time.Time), args.Error(3)
 }
 
-func (m *MockStorage) InitMultipartUpload(ctx context.Context, fileName string, checksum string) (string, error) {
-	args := m.Called(ctx, fileName, checksum)
+func (m *MockStorage) InitMultipartUpload(ctx context.Context, bucket string, fileName string, checksum string) (string, error) {
+	args := m.Called(ctx, bucket, fileName, checksum)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockStorage) GetHeaderBytes(ctx context.Context, fileKey string, n int64) ([]byte, error) {
-	args := m.Called(ctx, fileKey, n)
+func (m *MockStorage) GetHeaderBytes(ctx context.Context, bucket string, fileKey string, n int64) ([]byte, error) {
+	args := m.Called(ctx, bucket, fileKey, n)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockStorage) GeneratePresignedURLForPart(ctx context.Context, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
-	args := m.Called(ctx, fileKey, partNumber, uploadID, mimeType, contentLength, checksumSha256)
+func (m *MockStorage) GeneratePresignedURLForPart(ctx context.Context, bucket string, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, bucket, fileKey, partNumber, uploadID, mimeType, contentLength, checksumSha256)
 	return args.String(0), args.Get(1).(map[string]string), args.Get(2).(*time.Time), args.Error(3)
 }
 
-func (m *MockStorage) CompleteMultipartUpload(ctx context.Context, fileKey string, uploadID string, parts []domain.UploadPart) error {
-	args := m.Called(ctx, fileKey, uploadID, parts)
+func (m *MockStorage) CompleteMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string, parts []domain.UploadPart) error {
+	args := m.Called(ctx, bucket, fileKey, uploadID, parts)
 	return args.Error(0)
 }
 
-func (m *MockStorage) GetObject(ctx context.Context, fileKey string) (io.ReadCloser, error) {
-	args := m.Called(ctx, fileKey)
+func (m *MockStorage) GetObject(ctx context.Context, bucket string, fileKey string) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, fileKey)
 	return args.Get(0).(io.ReadCloser), args.Error(1)
 }
 
-func (m *MockStorage) GetObjectInfo(ctx context.Context, fileKey string) (*minio.ObjectInfo, error) {
-	args := m.Called(ctx, fileKey)
+func (m *MockStorage) GetObjectInfo(ctx context.Context, bucket string, fileKey string) (*minio.ObjectInfo, error) {
+	args := m.Called(ctx, bucket, fileKey)
 	return args.Get(0).(*minio.ObjectInfo), args.Error(1)
 }
 
-func (m *MockStorage) ListPartsPaginated(ctx context.Context, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error) {
-	args := m.Called(ctx, fileKey, uploadID, maxParts, partNumberMarker)
+func (m *MockStorage) ListPartsPaginated(ctx context.Context, bucket string, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error) {
+	args := m.Called(ctx, bucket, fileKey, uploadID, maxParts, partNumberMarker)
 	return args.Get(0).([]domain.UploadPart), args.Int(1), args.Error(2)
 }
 
-func (m *MockStorage) AbortMultipartUpload(ctx context.Context, fileKey string, uploadID string) error {
-	args := m.Called(ctx, fileKey, uploadID)
+// UploadPart records the content of data so expectations can match it
+func (m *MockStorage) UploadPart(ctx context.Context, bucket string, fileKey string, uploadID string, partNumber int, data io.Reader, size int64, checksumSha256 string) (domain.UploadPart, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return domain.UploadPart{}, err
+	}
+	args := m.Called(ctx, bucket, fileKey, uploadID, partNumber, content, size, checksumSha256)
+	return args.Get(0).(domain.UploadPart), args.Error(1)
+}
+
+func (m *MockStorage) AbortMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string) error {
+	args := m.Called(ctx, bucket, fileKey, uploadID)
+	return args.Error(0)
+}
+
+func (m *MockStorage) DeleteObject(ctx context.Context, bucket string, fileKey string) error {
+	args := m.Called(ctx, bucket, fileKey)
 	return args.Error(0)
 }
 
-func (m *MockStorage) DeleteObject(ctx context.Context, fileKey string) error {
-	args := m.Called(ctx, fileKey)
+// PutObject records the content of data so expectations can match it
+func (m *MockStorage) PutObject(ctx context.Context, bucket string, fileKey string, data io.Reader, size int64) error {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	args := m.Called(ctx, bucket, fileKey, content, size)
 	return args.Error(0)
 }
 
-func (m *MockStorage) GeneratePresignedURLForDownload(ctx context.Context, fileKey string) (string, *time.Time, error) {
-	args := m.Called(ctx, fileKey)
-	return args.Get(0).(string), args.Get(1).(*time.Time), args.Error(2)
+func (m *MockStorage) GeneratePresignedURLForDownload(ctx context.Context, bucket string, fileKey string, opts domain.DownloadOptions) (string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, bucket, fileKey, opts)
+	return args.Get(0).(string), args.Get(1).(map[string]string), args.Get(2).(*time.Time), args.Error(3)
+}
+
+func (m *MockStorage) CopyObject(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, clip *domain.Clip) error {
+	args := m.Called(ctx, srcBucket, srcKey, dstBucket, dstKey, clip)
+	return args.Error(0)
+}
+
+func (m *MockStorage) RestoreObject(ctx context.Context, bucket string, fileKey string) error {
+	args := m.Called(ctx, bucket, fileKey)
+	return args.Error(0)
+}
+
+// ListObjects calls fn with the objects of the expectation
+func (m *MockStorage) ListObjects(ctx context.Context, bucket string, fn func(object domain.StoredObject) error) error {
+	args := m.Called(ctx, bucket)
+	for _, object := range args.Get(0).([]domain.StoredObject) {
+		if err := fn(object); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// ListIncompleteUploads calls fn with the uploads of the expectation
+func (m *MockStorage) ListIncompleteUploads(ctx context.Context, bucket string, fn func(upload domain.IncompleteUpload) error) error {
+	args := m.Called(ctx, bucket)
+	for _, upload := range args.Get(0).([]domain.IncompleteUpload) {
+		if err := fn(upload); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
 }
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"score-play/internal/core/port"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// storageEvent is the part of a storage event carrying the user metadata of the object
+type storageEvent struct {
+	Records []struct {
+		S3 struct {
+			Object struct {
+				UserMetadata map[string]string `json:"userMetadata"`
+			} `json:"object"`
+		} `json:"s3"`
+	} `json:"Records"`
+}
+
+type messageService struct {
+	next port.MessageService
+}
+
+// NewMessageService traces the messages handled by next.
+// The span continues the trace of ctx, if the broker carried one, and links to the request that uploaded the object.
+func NewMessageService(next port.MessageService) port.MessageService {
+	return &messageService{next: next}
+}
+
+// HandleMessage traces the handling of a storage event
+func (s *messageService) HandleMessage(ctx context.Context, data []byte) (err error) {
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindConsumer)}
+	var event storageEvent
+	if json.Unmarshal(data, &event) == nil {
+		for _, record := range event.Records {
+			upload := trace.SpanContextFromContext(ContextFromObjectMetadata(context.Background(), record.S3.Object.UserMetadata))
+			if upload.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: upload}))
+			}
+		}
+	}
+
+	ctx, span := start(ctx, "MessageService.HandleMessage", opts...)
+	defer func() { end(span, err) }()
+	return s.next.HandleMessage(ctx, data)
+}
@@ -0,0 +1,467 @@
+package tracing
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startDB starts the span of a repository call
+func startDB(ctx context.Context, name string) (context.Context, trace.Span) {
+	return start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("db.system", "postgresql")))
+}
+
+type unitOfWork struct {
+	next port.UnitOfWork
+}
+
+// NewUnitOfWork traces the transactions of next and the calls of its repositories
+func NewUnitOfWork(next port.UnitOfWork) port.UnitOfWork {
+	return &unitOfWork{next: next}
+}
+
+// Execute traces the transaction, the repositories of the transaction being traced too
+func (u *unitOfWork) Execute(ctx context.Context, fn func(uow port.UnitOfWork) error) (err error) {
+	ctx, span := start(ctx, "UnitOfWork.Execute")
+	defer func() { end(span, err) }()
+	return u.next.Execute(ctx, func(tx port.UnitOfWork) error {
+		return fn(&unitOfWork{next: tx})
+	})
+}
+
+func (u *unitOfWork) TagRepo() port.TagRepository {
+	return NewTagRepository(u.next.TagRepo())
+}
+
+func (u *unitOfWork) FileRepo() port.FileRepository {
+	return &fileRepository{next: u.next.FileRepo()}
+}
+
+func (u *unitOfWork) UploadSessionRepo() port.UploadSessionRepository {
+	return &uploadSessionRepository{next: u.next.UploadSessionRepo()}
+}
+
+func (u *unitOfWork) FileTagRepo() port.FileTagRepository {
+	return &fileTagRepository{next: u.next.FileTagRepo()}
+}
+
+func (u *unitOfWork) FileVersionRepo() port.FileVersionRepository {
+	return &fileVersionRepository{next: u.next.FileVersionRepo()}
+}
+
+func (u *unitOfWork) UsageRepo() port.UsageRepository {
+	return &usageRepository{next: u.next.UsageRepo()}
+}
+
+func (u *unitOfWork) CleanupRunRepo() port.CleanupRunRepository {
+	return &cleanupRunRepository{next: u.next.CleanupRunRepo()}
+}
+
+func (u *unitOfWork) FileImportRepo() port.FileImportRepository {
+	return &fileImportRepository{next: u.next.FileImportRepo()}
+}
+
+type tagRepository struct {
+	next port.TagRepository
+}
+
+// NewTagRepository traces the calls of next
+func NewTagRepository(next port.TagRepository) port.TagRepository {
+	return &tagRepository{next: next}
+}
+
+func (r *tagRepository) CreateMany(ctx context.Context, tags []string) (created int, err error) {
+	ctx, span := startDB(ctx, "TagRepository.CreateMany")
+	defer func() { end(span, err) }()
+	return r.next.CreateMany(ctx, tags)
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, name string) (tag *domain.Tag, err error) {
+	ctx, span := startDB(ctx, "TagRepository.FindByName")
+	defer func() { end(span, err) }()
+	return r.next.FindByName(ctx, name)
+}
+
+func (r *tagRepository) FindByNames(ctx context.Context, names []string) (ids map[string]uuid.UUID, err error) {
+	ctx, span := startDB(ctx, "TagRepository.FindByNames")
+	defer func() { end(span, err) }()
+	return r.next.FindByNames(ctx, names)
+}
+
+func (r *tagRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (tags []domain.Tag, err error) {
+	ctx, span := startDB(ctx, "TagRepository.FindByIDs")
+	defer func() { end(span, err) }()
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *tagRepository) List(ctx context.Context, limit int, marker *string) (tags []domain.Tag, next *string, err error) {
+	ctx, span := startDB(ctx, "TagRepository.List")
+	defer func() { end(span, err) }()
+	return r.next.List(ctx, limit, marker)
+}
+
+type fileRepository struct {
+	next port.FileRepository
+}
+
+func (r *fileRepository) Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, bucket string, storageKey string, ownerID *string) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, id, fileName, mimeType, mediaType, size, status, checksum, bucket, storageKey, ownerID)
+}
+
+func (r *fileRepository) CreateShared(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, ownerID *string) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.CreateShared")
+	defer func() { end(span, err) }()
+	return r.next.CreateShared(ctx, id, source, fileName, ownerID)
+}
+
+func (r *fileRepository) CreateCopy(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, bucket string, storageKey string, ownerID *string) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.CreateCopy")
+	defer func() { end(span, err) }()
+	return r.next.CreateCopy(ctx, id, source, fileName, bucket, storageKey, ownerID)
+}
+
+func (r *fileRepository) FindById(ctx context.Context, id uuid.UUID) (file *domain.FileMetadata, err error) {
+	ctx, span := startDB(ctx, "FileRepository.FindById")
+	defer func() { end(span, err) }()
+	return r.next.FindById(ctx, id)
+}
+
+func (r *fileRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.UpdateStatus")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+func (r *fileRepository) UpdateStorageClass(ctx context.Context, id uuid.UUID, storageClass string) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.UpdateStorageClass")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStorageClass(ctx, id, storageClass)
+}
+
+func (r *fileRepository) UpdateChecksum(ctx context.Context, id uuid.UUID, checksum string) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.UpdateChecksum")
+	defer func() { end(span, err) }()
+	return r.next.UpdateChecksum(ctx, id, checksum)
+}
+
+func (r *fileRepository) SetCurrentVersion(ctx context.Context, id uuid.UUID, version domain.FileVersion) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.SetCurrentVersion")
+	defer func() { end(span, err) }()
+	return r.next.SetCurrentVersion(ctx, id, version)
+}
+
+func (r *fileRepository) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startDB(ctx, "FileRepository.Delete")
+	defer func() { end(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *fileRepository) FindExpired(ctx context.Context, expirationTime time.Time, after uuid.UUID, limit int) (files []domain.FileMetadata, err error) {
+	ctx, span := startDB(ctx, "FileRepository.FindExpired")
+	defer func() { end(span, err) }()
+	return r.next.FindExpired(ctx, expirationTime, after, limit)
+}
+
+func (r *fileRepository) FindByStatus(ctx context.Context, status domain.FileStatus, after uuid.UUID, limit int) (files []domain.FileMetadata, err error) {
+	ctx, span := startDB(ctx, "FileRepository.FindByStatus")
+	defer func() { end(span, err) }()
+	return r.next.FindByStatus(ctx, status, after, limit)
+}
+
+func (r *fileRepository) ExistsByStorageKey(ctx context.Context, bucket string, storageKey string) (exists bool, err error) {
+	ctx, span := startDB(ctx, "FileRepository.ExistsByStorageKey")
+	defer func() { end(span, err) }()
+	return r.next.ExistsByStorageKey(ctx, bucket, storageKey)
+}
+
+func (r *fileRepository) FindDuplicates(ctx context.Context, checksum string, sizeBytes int64, mimeType string, ownerID *string, limit int) (files []domain.FileMetadata, err error) {
+	ctx, span := startDB(ctx, "FileRepository.FindDuplicates")
+	defer func() { end(span, err) }()
+	return r.next.FindDuplicates(ctx, checksum, sizeBytes, mimeType, ownerID, limit)
+}
+
+func (r *fileRepository) CountReferences(ctx context.Context, bucket string, storageKey string, excludeID uuid.UUID) (count int, err error) {
+	ctx, span := startDB(ctx, "FileRepository.CountReferences")
+	defer func() { end(span, err) }()
+	return r.next.CountReferences(ctx, bucket, storageKey, excludeID)
+}
+
+type uploadSessionRepository struct {
+	next port.UploadSessionRepository
+}
+
+func (r *uploadSessionRepository) Create(ctx context.Context, session domain.UploadSession) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, session)
+}
+
+func (r *uploadSessionRepository) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.UpdateExpiresAt")
+	defer func() { end(span, err) }()
+	return r.next.UpdateExpiresAt(ctx, id, expiresAt)
+}
+
+func (r *uploadSessionRepository) UpdateOffset(ctx context.Context, id uuid.UUID, offset int64, checksumState []byte) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.UpdateOffset")
+	defer func() { end(span, err) }()
+	return r.next.UpdateOffset(ctx, id, offset, checksumState)
+}
+
+func (r *uploadSessionRepository) AcquireLease(ctx context.Context, id uuid.UUID, holder uuid.UUID, now time.Time, until time.Time) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.AcquireLease")
+	defer func() { end(span, err) }()
+	return r.next.AcquireLease(ctx, id, holder, now, until)
+}
+
+func (r *uploadSessionRepository) ReleaseLease(ctx context.Context, id uuid.UUID, holder uuid.UUID) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.ReleaseLease")
+	defer func() { end(span, err) }()
+	return r.next.ReleaseLease(ctx, id, holder)
+}
+
+func (r *uploadSessionRepository) FindByIDAndActive(ctx context.Context, id uuid.UUID) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindByIDAndActive")
+	defer func() { end(span, err) }()
+	return r.next.FindByIDAndActive(ctx, id)
+}
+
+func (r *uploadSessionRepository) UpdateStatusByFileID(ctx context.Context, fileID uuid.UUID, status domain.UploadSessionStatus) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.UpdateStatusByFileID")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStatusByFileID(ctx, fileID, status)
+}
+
+func (r *uploadSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindByID")
+	defer func() { end(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *uploadSessionRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindByFileID")
+	defer func() { end(span, err) }()
+	return r.next.FindByFileID(ctx, fileID)
+}
+
+func (r *uploadSessionRepository) FindByVersionID(ctx context.Context, versionID uuid.UUID) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindByVersionID")
+	defer func() { end(span, err) }()
+	return r.next.FindByVersionID(ctx, versionID)
+}
+
+func (r *uploadSessionRepository) FindByProviderUploadID(ctx context.Context, providerUploadID string) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindByProviderUploadID")
+	defer func() { end(span, err) }()
+	return r.next.FindByProviderUploadID(ctx, providerUploadID)
+}
+
+func (r *uploadSessionRepository) FindAllExpired(ctx context.Context, now time.Time, after uuid.UUID, limit int) (sessions []domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.FindAllExpired")
+	defer func() { end(span, err) }()
+	return r.next.FindAllExpired(ctx, now, after, limit)
+}
+
+func (r *uploadSessionRepository) ClaimExpired(ctx context.Context, id uuid.UUID, now time.Time) (session *domain.UploadSession, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.ClaimExpired")
+	defer func() { end(span, err) }()
+	return r.next.ClaimExpired(ctx, id, now)
+}
+
+func (r *uploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadSessionStatus) (err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.UpdateStatus")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+func (r *uploadSessionRepository) CountOpen(ctx context.Context) (count int64, err error) {
+	ctx, span := startDB(ctx, "UploadSessionRepository.CountOpen")
+	defer func() { end(span, err) }()
+	return r.next.CountOpen(ctx)
+}
+
+type fileTagRepository struct {
+	next port.FileTagRepository
+}
+
+func (r *fileTagRepository) Create(ctx context.Context, fileID uuid.UUID, tagID uuid.UUID) (err error) {
+	ctx, span := startDB(ctx, "FileTagRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, fileID, tagID)
+}
+
+func (r *fileTagRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (fileTags []domain.FileTag, err error) {
+	ctx, span := startDB(ctx, "FileTagRepository.FindByFileID")
+	defer func() { end(span, err) }()
+	return r.next.FindByFileID(ctx, fileID)
+}
+
+func (r *fileTagRepository) CreateMany(ctx context.Context, fileID uuid.UUID, tagIDs []uuid.UUID) (created int, err error) {
+	ctx, span := startDB(ctx, "FileTagRepository.CreateMany")
+	defer func() { end(span, err) }()
+	return r.next.CreateMany(ctx, fileID, tagIDs)
+}
+
+func (r *fileTagRepository) DeleteByFileID(ctx context.Context, fileID uuid.UUID) (err error) {
+	ctx, span := startDB(ctx, "FileTagRepository.DeleteByFileID")
+	defer func() { end(span, err) }()
+	return r.next.DeleteByFileID(ctx, fileID)
+}
+
+type fileVersionRepository struct {
+	next port.FileVersionRepository
+}
+
+func (r *fileVersionRepository) Create(ctx context.Context, version domain.FileVersion) (err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, version)
+}
+
+func (r *fileVersionRepository) NextVersion(ctx context.Context, fileID uuid.UUID) (version int, err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.NextVersion")
+	defer func() { end(span, err) }()
+	return r.next.NextVersion(ctx, fileID)
+}
+
+func (r *fileVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (version *domain.FileVersion, err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.FindByID")
+	defer func() { end(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *fileVersionRepository) FindByFileIDAndVersion(ctx context.Context, fileID uuid.UUID, version int) (fileVersion *domain.FileVersion, err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.FindByFileIDAndVersion")
+	defer func() { end(span, err) }()
+	return r.next.FindByFileIDAndVersion(ctx, fileID, version)
+}
+
+func (r *fileVersionRepository) ListByFileID(ctx context.Context, fileID uuid.UUID) (versions []domain.FileVersion, err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.ListByFileID")
+	defer func() { end(span, err) }()
+	return r.next.ListByFileID(ctx, fileID)
+}
+
+func (r *fileVersionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) (err error) {
+	ctx, span := startDB(ctx, "FileVersionRepository.UpdateStatus")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+type usageRepository struct {
+	next port.UsageRepository
+}
+
+func (r *usageRepository) Lock(ctx context.Context) (err error) {
+	ctx, span := startDB(ctx, "UsageRepository.Lock")
+	defer func() { end(span, err) }()
+	return r.next.Lock(ctx)
+}
+
+func (r *usageRepository) Get(ctx context.Context, ownerID *string) (usage *domain.Usage, err error) {
+	ctx, span := startDB(ctx, "UsageRepository.Get")
+	defer func() { end(span, err) }()
+	return r.next.Get(ctx, ownerID)
+}
+
+type apiKeyRepository struct {
+	next port.APIKeyRepository
+}
+
+// NewAPIKeyRepository traces the calls of next
+func NewAPIKeyRepository(next port.APIKeyRepository) port.APIKeyRepository {
+	return &apiKeyRepository{next: next}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key domain.APIKey, hash string) (err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, key, hash)
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (key *domain.APIKey, err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.FindByID")
+	defer func() { end(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (key *domain.APIKey, err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.FindByHash")
+	defer func() { end(span, err) }()
+	return r.next.FindByHash(ctx, hash)
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) (keys []domain.APIKey, err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.List")
+	defer func() { end(span, err) }()
+	return r.next.List(ctx)
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.Revoke")
+	defer func() { end(span, err) }()
+	return r.next.Revoke(ctx, id, at)
+}
+
+func (r *apiKeyRepository) Expire(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.Expire")
+	defer func() { end(span, err) }()
+	return r.next.Expire(ctx, id, at)
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := startDB(ctx, "APIKeyRepository.TouchLastUsed")
+	defer func() { end(span, err) }()
+	return r.next.TouchLastUsed(ctx, id, at)
+}
+
+type cleanupRunRepository struct {
+	next port.CleanupRunRepository
+}
+
+func (r *cleanupRunRepository) Create(ctx context.Context, run domain.CleanupRun) (err error) {
+	ctx, span := startDB(ctx, "CleanupRunRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, run)
+}
+
+type fileImportRepository struct {
+	next port.FileImportRepository
+}
+
+func (r *fileImportRepository) Create(ctx context.Context, fileImport domain.FileImport) (err error) {
+	ctx, span := startDB(ctx, "FileImportRepository.Create")
+	defer func() { end(span, err) }()
+	return r.next.Create(ctx, fileImport)
+}
+
+func (r *fileImportRepository) FindByFileID(ctx context.Context, fileID uuid.UUID) (fileImport *domain.FileImport, err error) {
+	ctx, span := startDB(ctx, "FileImportRepository.FindByFileID")
+	defer func() { end(span, err) }()
+	return r.next.FindByFileID(ctx, fileID)
+}
+
+func (r *fileImportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (fileImport *domain.FileImport, err error) {
+	ctx, span := startDB(ctx, "FileImportRepository.ClaimNext")
+	defer func() { end(span, err) }()
+	return r.next.ClaimNext(ctx, staleBefore)
+}
+
+func (r *fileImportRepository) UpdateProgress(ctx context.Context, fileID uuid.UUID, bytesReceived int64) (err error) {
+	ctx, span := startDB(ctx, "FileImportRepository.UpdateProgress")
+	defer func() { end(span, err) }()
+	return r.next.UpdateProgress(ctx, fileID, bytesReceived)
+}
+
+func (r *fileImportRepository) UpdateStatus(ctx context.Context, fileID uuid.UUID, status domain.ImportStatus, errMsg string) (err error) {
+	ctx, span := startDB(ctx, "FileImportRepository.UpdateStatus")
+	defer func() { end(span, err) }()
+	return r.next.UpdateStatus(ctx, fileID, status, errMsg)
+}
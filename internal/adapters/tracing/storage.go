@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startStorage starts the span of a storage call on an object
+func startStorage(ctx context.Context, name string, bucket string, key string) (context.Context, trace.Span) {
+	return start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("aws.s3.bucket", bucket),
+		attribute.String("aws.s3.key", key),
+	))
+}
+
+type fileStorage struct {
+	port.FileStorage
+}
+
+// NewFileStorage traces the calls of next, presigning included
+func NewFileStorage(next port.FileStorage) port.FileStorage {
+	return &fileStorage{FileStorage: next}
+}
+
+func (s *fileStorage) GeneratePresignedURLSimpleUpload(ctx context.Context, bucket string, fileKey string, checksumSha256 string) (url string, headers map[string]string, expiresAt *time.Time, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GeneratePresignedURLSimpleUpload", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GeneratePresignedURLSimpleUpload(ctx, bucket, fileKey, checksumSha256)
+}
+
+func (s *fileStorage) GeneratePresignedPostPolicy(ctx context.Context, bucket string, fileKey string, contentType string, sizeBytes int64, checksumSha256 string) (url string, fields map[string]string, expiresAt *time.Time, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GeneratePresignedPostPolicy", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GeneratePresignedPostPolicy(ctx, bucket, fileKey, contentType, sizeBytes, checksumSha256)
+}
+
+func (s *fileStorage) InitMultipartUpload(ctx context.Context, bucket string, fileName string, checksum string) (uploadID string, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.InitMultipartUpload", bucket, fileName)
+	defer func() { end(span, err) }()
+	return s.FileStorage.InitMultipartUpload(ctx, bucket, fileName, checksum)
+}
+
+func (s *fileStorage) GeneratePresignedURLForPart(ctx context.Context, bucket string, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (url string, headers map[string]string, expiresAt *time.Time, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GeneratePresignedURLForPart", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GeneratePresignedURLForPart(ctx, bucket, fileKey, partNumber, uploadID, mimeType, contentLength, checksumSha256)
+}
+
+func (s *fileStorage) CompleteMultipartUpload(ctx context.Context, bucket string, fileName string, uploadID string, parts []domain.UploadPart) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.CompleteMultipartUpload", bucket, fileName)
+	defer func() { end(span, err) }()
+	return s.FileStorage.CompleteMultipartUpload(ctx, bucket, fileName, uploadID, parts)
+}
+
+func (s *fileStorage) GetObjectInfo(ctx context.Context, bucket string, fileKey string) (info *minio.ObjectInfo, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GetObjectInfo", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GetObjectInfo(ctx, bucket, fileKey)
+}
+
+func (s *fileStorage) ListPartsPaginated(ctx context.Context, bucket string, fileKey string, uploadID string, maxParts int, partNumberMarker int) (parts []domain.UploadPart, nextMarker int, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.ListPartsPaginated", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.ListPartsPaginated(ctx, bucket, fileKey, uploadID, maxParts, partNumberMarker)
+}
+
+func (s *fileStorage) UploadPart(ctx context.Context, bucket string, fileKey string, uploadID string, partNumber int, data io.Reader, size int64, checksumSha256 string) (part domain.UploadPart, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.UploadPart", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.UploadPart(ctx, bucket, fileKey, uploadID, partNumber, data, size, checksumSha256)
+}
+
+func (s *fileStorage) AbortMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.AbortMultipartUpload", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.AbortMultipartUpload(ctx, bucket, fileKey, uploadID)
+}
+
+func (s *fileStorage) DeleteObject(ctx context.Context, bucket string, fileKey string) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.DeleteObject", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.DeleteObject(ctx, bucket, fileKey)
+}
+
+func (s *fileStorage) PutObject(ctx context.Context, bucket string, fileKey string, data io.Reader, size int64) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.PutObject", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.PutObject(ctx, bucket, fileKey, data, size)
+}
+
+func (s *fileStorage) GeneratePresignedURLForDownload(ctx context.Context, bucket string, fileKey string, opts domain.DownloadOptions) (url string, headers map[string]string, expiresAt *time.Time, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GeneratePresignedURLForDownload", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GeneratePresignedURLForDownload(ctx, bucket, fileKey, opts)
+}
+
+func (s *fileStorage) GetHeaderBytes(ctx context.Context, bucket string, fileKey string, n int64) (header []byte, err error) {
+	ctx, span := startStorage(ctx, "FileStorage.GetHeaderBytes", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.GetHeaderBytes(ctx, bucket, fileKey, n)
+}
+
+func (s *fileStorage) RestoreObject(ctx context.Context, bucket string, fileKey string) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.RestoreObject", bucket, fileKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.RestoreObject(ctx, bucket, fileKey)
+}
+
+func (s *fileStorage) CopyObject(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, clip *domain.Clip) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.CopyObject", dstBucket, dstKey)
+	defer func() { end(span, err) }()
+	return s.FileStorage.CopyObject(ctx, srcBucket, srcKey, dstBucket, dstKey, clip)
+}
+
+func (s *fileStorage) ListObjects(ctx context.Context, bucket string, fn func(object domain.StoredObject) error) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.ListObjects", bucket, "")
+	defer func() { end(span, err) }()
+	return s.FileStorage.ListObjects(ctx, bucket, fn)
+}
+
+func (s *fileStorage) ListIncompleteUploads(ctx context.Context, bucket string, fn func(upload domain.IncompleteUpload) error) (err error) {
+	ctx, span := startStorage(ctx, "FileStorage.ListIncompleteUploads", bucket, "")
+	defer func() { end(span, err) }()
+	return s.FileStorage.ListIncompleteUploads(ctx, bucket, fn)
+}
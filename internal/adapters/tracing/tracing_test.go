@@ -0,0 +1,151 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/adapters/tracing"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// setupTracing records the spans in memory until the end of the test
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return exporter
+}
+
+func spanByName(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span named %s", name)
+	return tracetest.SpanStub{}
+}
+
+func TestUnitOfWork(t *testing.T) {
+	// Arrange
+	exporter := setupTracing(t)
+	uow := repository.NewMockUnitOfWork()
+	fileID := uuid.New()
+	uow.On("Execute", mock.Anything, mock.Anything).Return(nil)
+	uow.GetFileRepoMock().On("FindById", mock.Anything, fileID).Return((*domain.FileMetadata)(nil), domain.ErrFileMetadataNotFound)
+	traced := tracing.NewUnitOfWork(uow)
+
+	// Act
+	err := traced.Execute(context.Background(), func(tx port.UnitOfWork) error {
+		_, err := tx.FileRepo().FindById(context.Background(), fileID)
+		return err
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileMetadataNotFound)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	tx := spanByName(t, spans, "UnitOfWork.Execute")
+	find := spanByName(t, spans, "FileRepository.FindById")
+	assert.Equal(t, codes.Error, tx.Status.Code)
+	assert.Equal(t, codes.Error, find.Status.Code)
+	uow.AssertExpectations(t)
+}
+
+func TestTagRepository(t *testing.T) {
+	// Arrange
+	exporter := setupTracing(t)
+	next := repository.NewMockTagRepository()
+	next.On("FindByName", mock.Anything, "football").Return(&domain.Tag{Name: "football"}, nil)
+	traced := tracing.NewTagRepository(next)
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /api/v1/tag")
+
+	// Act
+	tag, err := traced.FindByName(ctx, "football")
+	parent.End()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "football", tag.Name)
+	span := spanByName(t, exporter.GetSpans(), "TagRepository.FindByName")
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+	assert.Contains(t, span.Attributes, attribute.String("db.system", "postgresql"))
+	next.AssertExpectations(t)
+}
+
+func TestFileStorage(t *testing.T) {
+	// Arrange
+	exporter := setupTracing(t)
+	next := storage.NewMockStorage()
+	next.On("DeleteObject", mock.Anything, "videos", "default/1").Return(errors.New("unreachable"))
+	traced := tracing.NewFileStorage(next)
+
+	// Act
+	err := traced.DeleteObject(context.Background(), "videos", "default/1")
+
+	// Assert
+	assert.Error(t, err)
+	span := spanByName(t, exporter.GetSpans(), "FileStorage.DeleteObject")
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Contains(t, span.Attributes, attribute.String("aws.s3.bucket", "videos"))
+	assert.Contains(t, span.Attributes, attribute.String("aws.s3.key", "default/1"))
+	next.AssertExpectations(t)
+}
+
+type recordingMessageService struct {
+	ctx context.Context
+}
+
+func (s *recordingMessageService) HandleMessage(ctx context.Context, data []byte) error {
+	s.ctx = ctx
+	return nil
+}
+
+func TestMessageService_LinksToUpload(t *testing.T) {
+	// Arrange
+	exporter := setupTracing(t)
+	uploadCtx, uploadSpan := otel.Tracer("test").Start(context.Background(), "POST /api/v1/file/upload")
+	metadata := map[string]string{}
+	for k, v := range tracing.ObjectMetadata(uploadCtx) {
+		metadata["X-Amz-Meta-"+k] = v
+	}
+	uploadSpan.End()
+	event := map[string]any{"Records": []any{map[string]any{"s3": map[string]any{"object": map[string]any{"key": "1", "userMetadata": metadata}}}}}
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	next := &recordingMessageService{}
+	traced := tracing.NewMessageService(next)
+
+	// Act
+	err = traced.HandleMessage(context.Background(), data)
+
+	// Assert
+	require.NoError(t, err)
+	span := spanByName(t, exporter.GetSpans(), "MessageService.HandleMessage")
+	require.Len(t, span.Links, 1)
+	assert.Equal(t, uploadSpan.SpanContext().TraceID(), span.Links[0].SpanContext.TraceID())
+	assert.Equal(t, uploadSpan.SpanContext().SpanID(), span.Links[0].SpanContext.SpanID())
+	assert.Equal(t, span.SpanContext.SpanID(), trace.SpanContextFromContext(next.ctx).SpanID())
+}
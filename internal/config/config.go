@@ -13,6 +13,12 @@ type Config struct {
 	NATS     NATSConfig
 	Database DatabaseConfig
 	Server   ServerConfig
+	Auth     AuthConfig
+	Limit    RateLimitConfig
+	OpenAPI  OpenAPIConfig
+	Metrics  MetricsConfig
+	Tracing  TracingConfig
+	Health   HealthConfig
 }
 
 type Env struct {
@@ -24,6 +30,58 @@ type ServerConfig struct {
 	Port string `envconfig:"SERVER_PORT" default:"8080"`
 }
 
+// AuthConfig configures the bearer token authentication of the api.
+// HS256 tokens are verified with HMACSecret, RS256/ES256 tokens with the keys of JWKSFile or JWKSURL.
+// Api keys (sk_ tokens) are accepted alongside jwts, jwts are rejected when none of the above is configured.
+// Rotated api keys keep working for APIKeyRotationGrace.
+type AuthConfig struct {
+	Enabled             bool          `envconfig:"AUTH_ENABLED" default:"false"`
+	HMACSecret          string        `envconfig:"AUTH_JWT_HMAC_SECRET" default:""`
+	JWKSFile            string        `envconfig:"AUTH_JWT_JWKS_FILE" default:""`
+	JWKSURL             string        `envconfig:"AUTH_JWT_JWKS_URL" default:""`
+	JWKSRefresh         time.Duration `envconfig:"AUTH_JWT_JWKS_REFRESH" default:"15m"`
+	Issuer              string        `envconfig:"AUTH_JWT_ISSUER" default:""`
+	Audience            string        `envconfig:"AUTH_JWT_AUDIENCE" default:""`
+	Leeway              time.Duration `envconfig:"AUTH_JWT_LEEWAY" default:"30s"`
+	RequiredScope       string        `envconfig:"AUTH_REQUIRED_SCOPE" default:""`
+	APIKeyRotationGrace time.Duration `envconfig:"AUTH_APIKEY_ROTATION_GRACE" default:"24h"`
+}
+
+// RateLimitConfig configures the token bucket rate limiting of the api, per client and route group.
+// Rules map a route group (default, upload, parts) to "<requests>/<period>". Groups without rule are not limited.
+type RateLimitConfig struct {
+	Enabled bool              `envconfig:"RATE_LIMIT_ENABLED" default:"false"`
+	Rules   map[string]string `envconfig:"RATE_LIMIT_RULES" default:"default:300/1m,upload:30/1m,parts:60/1m"`
+}
+
+// OpenAPIConfig configures the validation of the api against docs/openapi.yaml.
+// Invalid requests are answered with 400, responses not matching the spec are only logged.
+type OpenAPIConfig struct {
+	ValidateRequests  bool `envconfig:"OPENAPI_VALIDATE_REQUESTS" default:"true"`
+	ValidateResponses bool `envconfig:"OPENAPI_VALIDATE_RESPONSES" default:"false"`
+}
+
+// HealthConfig configures the readiness checks. Results are cached for CacheTTL.
+// Addr is where the worker serves /health/live and /health/ready, the api serves them on its own port.
+type HealthConfig struct {
+	Timeout  time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	CacheTTL time.Duration `envconfig:"HEALTH_CACHE_TTL" default:"5s"`
+	Addr     string        `envconfig:"HEALTH_ADDR" default:":8081"`
+}
+
+// MetricsConfig configures the Prometheus endpoint, served at /metrics on its own address to keep it off the public api.
+type MetricsConfig struct {
+	Enabled bool   `envconfig:"METRICS_ENABLED" default:"true"`
+	Addr    string `envconfig:"METRICS_ADDR" default:":9090"`
+}
+
+// TracingConfig configures the OpenTelemetry tracing, exported with the otlp exporter (OTEL_EXPORTER_OTLP_* variables),
+// to stdout, or not at all (none). SampleRatio applies to traces started here, others follow their parent.
+type TracingConfig struct {
+	Exporter    string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
 type MinioConfig struct {
 	Endpoint                   string        `envconfig:"MINIO_ENDPOINT" required:"true"`
 	BucketName                 string        `envconfig:"MINIO_BUCKET_NAME" required:"true"`
@@ -32,23 +90,85 @@ type MinioConfig struct {
 	SimplePresignedDuration    time.Duration `envconfig:"MINIO_SIMPLE_PRESIGNED_DURATION" default:"15m"`
 	MultiPartPresignedDuration time.Duration `envconfig:"MINIO_MULTIPART_PRESIGNED_DURATION" default:"15m"`
 	DownloadSignedURLDuration  time.Duration `envconfig:"MINIO_DOWNLOAD_SIGNED_URL_DURATION" default:"15m"`
+	DownloadSignedURLMaxTTL    time.Duration `envconfig:"MINIO_DOWNLOAD_SIGNED_URL_MAX_TTL" default:"24h"`
 	UseSSL                     bool          `envconfig:"MINIO_USE_SSL" default:"false"`
+	RestoreDays                int           `envconfig:"MINIO_RESTORE_DAYS" default:"1"`
+	Lifecycle                  LifecycleConfig
+	Routing                    RoutingConfig
 }
+
+// RoutingConfig configures which bucket and key an object is stored at.
+// Empty bucket names fall back to MinioConfig.BucketName.
+// KeyTemplate supports {tenant}, {type}, {yyyy}, {mm}, {dd} and must end with {id}.
+type RoutingConfig struct {
+	ImageBucketName string `envconfig:"MINIO_IMAGE_BUCKET_NAME" default:""`
+	VideoBucketName string `envconfig:"MINIO_VIDEO_BUCKET_NAME" default:""`
+	KeyTemplate     string `envconfig:"MINIO_KEY_TEMPLATE" default:"{tenant}/{type}/{id}"`
+}
+
+// LifecycleConfig configures the bucket lifecycle rules managed by the service
+type LifecycleConfig struct {
+	Enabled                      bool   `envconfig:"MINIO_LIFECYCLE_ENABLED" default:"false"`
+	TransitionAfterDays          int    `envconfig:"MINIO_LIFECYCLE_TRANSITION_AFTER_DAYS" default:"0"`
+	TransitionStorageClass       string `envconfig:"MINIO_LIFECYCLE_TRANSITION_STORAGE_CLASS" default:""`
+	NoncurrentExpirationDays     int    `envconfig:"MINIO_LIFECYCLE_NONCURRENT_EXPIRATION_DAYS" default:"0"`
+	AbortIncompleteMultipartDays int    `envconfig:"MINIO_LIFECYCLE_ABORT_INCOMPLETE_MULTIPART_DAYS" default:"7"`
+}
+
 type FileUploadConfig struct {
 	SingleUploadMaxSize    int64         `envconfig:"UPLOAD_SINGLE_UPLOAD_FILE_SIZE" default:"10485760"`      // 10MB
 	MultipartUploadMaxSize int64         `envconfig:"UPLOAD_MULTIPART_UPLOAD_FILE_SIZE" default:"5368709120"` // 5GB
 	PartSize               int           `envconfig:"UPLOAD_PART_SIZE" default:"10485760"`                    // 10MB
 	SessionTTL             time.Duration `envconfig:"UPLOAD_SESSION_TTL" default:"30m"`
 	CleanupEvery           time.Duration `envconfig:"UPLOAD_CLEANUP_EVERY" default:"15m"`
+	CleanupEnabled         bool          `envconfig:"UPLOAD_CLEANUP_ENABLED" default:"true"` // off when cmd/cleanup runs the cleanup
+	CleanupBatchSize       int           `envconfig:"UPLOAD_CLEANUP_BATCH_SIZE" default:"100"`
+	CleanupTimeBudget      time.Duration `envconfig:"UPLOAD_CLEANUP_TIME_BUDGET" default:"5m"`  // 0 = no limit
+	CleanupFilesAfter      time.Duration `envconfig:"UPLOAD_CLEANUP_FILES_AFTER" default:"24h"` // uploads still uploading after this are failed
+	TusMaxConcurrent       int           `envconfig:"UPLOAD_TUS_MAX_CONCURRENT" default:"32"`   // tus requests served at a time, 0 = no limit
+	Access                 AccessConfig
+	Quota                  QuotaConfig
+	Import                 ImportConfig
+}
+
+// ImportConfig configures the import of files from remote urls by the worker.
+// AllowedHosts lists the hosts files can be imported from, "*.example.com" allowing its subdomains. None if empty.
+// Hosts resolving to private, loopback or link-local addresses are refused unless AllowPrivateNetworks.
+// Imports still running after StaleAfter are retried, up to MaxAttempts attempts.
+type ImportConfig struct {
+	AllowedHosts         []string      `envconfig:"IMPORT_ALLOWED_HOSTS" default:""`
+	AllowPrivateNetworks bool          `envconfig:"IMPORT_ALLOW_PRIVATE_NETWORKS" default:"false"`
+	PollEvery            time.Duration `envconfig:"IMPORT_POLL_EVERY" default:"5s"`
+	Timeout              time.Duration `envconfig:"IMPORT_TIMEOUT" default:"1h"`
+	StaleAfter           time.Duration `envconfig:"IMPORT_STALE_AFTER" default:"10m"`
+	MaxAttempts          int           `envconfig:"IMPORT_MAX_ATTEMPTS" default:"3"`
+}
+
+// QuotaConfig limits the storage of a tenant and of each of its users. Zero disables a limit.
+// Bytes count stored versions and uploads in progress, files count every file not failed or deleted.
+type QuotaConfig struct {
+	TenantMaxBytes int64 `envconfig:"QUOTA_TENANT_MAX_BYTES" default:"0"`
+	TenantMaxFiles int64 `envconfig:"QUOTA_TENANT_MAX_FILES" default:"0"`
+	UserMaxBytes   int64 `envconfig:"QUOTA_USER_MAX_BYTES" default:"0"`
+	UserMaxFiles   int64 `envconfig:"QUOTA_USER_MAX_FILES" default:"0"`
+}
+
+// AccessConfig configures the roles allowed to access files they do not own.
+// RoleOverrides maps a route (see domain.Route*) to space separated roles, e.g. "*:admin,get_file:reviewer".
+type AccessConfig struct {
+	RoleOverrides map[string]string `envconfig:"AUTH_ROLE_OVERRIDES" default:"*:admin,get_file:reviewer,list_versions:reviewer,get_version:reviewer"`
 }
 
+// NATSConfig configures the storage events consumer of the worker.
+// MaxConsumerLag makes the worker unready past this many pending messages, 0 disables it.
 type NATSConfig struct {
-	URL          string `envconfig:"NATS_URL" required:"true"`
-	PORT         string `envconfig:"NATS_PORT" default:"4222"`
-	StreamName   string `envconfig:"NATS_STREAM_NAME" required:"true"`
-	ConsumerName string `envconfig:"NATS_CONSUMER_NAME" required:"true"`
-	Subject      string `envconfig:"NATS_SUBJECT" required:"true"`
-	DeliverGroup string `envconfig:"NATS_DELIVER_GROUP" required:"true"`
+	URL            string `envconfig:"NATS_URL" required:"true"`
+	PORT           string `envconfig:"NATS_PORT" default:"4222"`
+	StreamName     string `envconfig:"NATS_STREAM_NAME" required:"true"`
+	ConsumerName   string `envconfig:"NATS_CONSUMER_NAME" required:"true"`
+	Subject        string `envconfig:"NATS_SUBJECT" required:"true"`
+	DeliverGroup   string `envconfig:"NATS_DELIVER_GROUP" required:"true"`
+	MaxConsumerLag uint64 `envconfig:"NATS_MAX_CONSUMER_LAG" default:"0"`
 }
 type DatabaseConfig struct {
 	Host           string        `envconfig:"DB_HOST" required:"true"`
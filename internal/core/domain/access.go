@@ -0,0 +1,17 @@
+package domain
+
+// Routes whose ownership checks can be bypassed by roles, see config.AccessConfig.
+// RouteAll applies the override to every route.
+const (
+	RouteAll               = "*"
+	RouteGetFile           = "get_file"
+	RouteSignParts         = "sign_parts"
+	RouteListParts         = "list_parts"
+	RouteCompleteMultipart = "complete_multipart"
+	RouteUploadVersion     = "upload_version"
+	RouteListVersions      = "list_versions"
+	RouteGetVersion        = "get_version"
+	RouteCopyFile          = "copy_file"
+	RouteResumeUpload      = "resume_upload"
+	RouteAbortUpload       = "abort_upload"
+)
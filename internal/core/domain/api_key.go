@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes granted to api keys
+const (
+	ScopeUpload    = "upload"
+	ScopeRead      = "read"
+	ScopeTagsWrite = "tags:write"
+	ScopeAdmin     = "admin"
+)
+
+// RoleAdmin is the role of principals authenticated with an admin api key
+const RoleAdmin = "admin"
+
+// APIKeyPrefix starts every api key secret, it tells api keys apart from jwts
+const APIKeyPrefix = "sk_"
+
+// APIKeyIssuer is the issuer of principals authenticated with an api key
+const APIKeyIssuer = "apikey"
+
+// ValidAPIKeyScope reports whether scope can be granted to an api key
+func ValidAPIKeyScope(scope string) bool {
+	return slices.Contains([]string{ScopeUpload, ScopeRead, ScopeTagsWrite, ScopeAdmin}, scope)
+}
+
+// APIKey is a long lived credential of a machine client. Only the hash of its secret is stored.
+type APIKey struct {
+	ID uuid.UUID
+	// SubjectID identifies the principal of the key, it is the id of the first key of a rotation chain
+	SubjectID uuid.UUID
+	TenantID  string
+	Name      string
+	// Prefix is the start of the secret, it helps recognizing a key without storing it
+	Prefix     string
+	Scopes     []string
+	CreatedBy  *string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Active reports whether the key can authenticate requests at now
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil && !k.RevokedAt.After(now) {
+		return false
+	}
+	return k.ExpiresAt == nil || k.ExpiresAt.After(now)
+}
+
+// Principal returns the principal authenticated by the key. The admin scope grants the admin role.
+// Rotated keys share their subject, so they keep owning the files uploaded with the keys they replace.
+func (k APIKey) Principal() Principal {
+	principal := Principal{
+		Subject: APIKeyIssuer + ":" + k.SubjectID.String(),
+		Issuer:  APIKeyIssuer,
+		Scopes:  k.Scopes,
+		Tenant:  k.TenantID,
+	}
+	if slices.Contains(k.Scopes, ScopeAdmin) {
+		principal.Roles = []string{RoleAdmin}
+	}
+	return principal
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cleanup jobs
+const (
+	CleanupJobExpiredSessions = "cleanup_expired_sessions"
+	CleanupJobExpiredFiles    = "cleanup_expired_files"
+)
+
+// CleanupRun is the summary of a run of a cleanup job, kept for auditing
+type CleanupRun struct {
+	ID         uuid.UUID
+	Job        string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Found counts the rows the run went through, those claimed by another run are neither cleaned nor failed
+	Found   int
+	Cleaned int
+	Failed  int
+	// BudgetExceeded is set when the run stopped before the last batch, the next run picks up the rest
+	BudgetExceeded bool
+	// Error is the error that stopped the run, if any
+	Error *string
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+const (
+	DispositionInline     = "inline"
+	DispositionAttachment = "attachment"
+)
+
+// DownloadOptions are the overrides of a presigned download url.
+// A zero TTL uses the default duration and Range is an http Range header value.
+type DownloadOptions struct {
+	Disposition string
+	Filename    string
+	ContentType string
+	TTL         time.Duration
+	Range       string
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrAlreadyExists is an error thrown when entity already exists
 var ErrAlreadyExists = errors.New("already exists")
@@ -46,3 +49,51 @@ var ErrFileUploadFailed = errors.New("file upload failed")
 
 // ErrContentTypeMismatch is an error thrown when content type mismatch
 var ErrContentTypeMismatch = errors.New("content type mismatch")
+
+// ErrFileRestoring is an error thrown when an archived file is being restored
+var ErrFileRestoring = errors.New("file restoring")
+
+// ErrFileVersionNotFound is an error thrown when file version is not found
+var ErrFileVersionNotFound = errors.New("file version not found")
+
+// ErrFileTypeMismatch is an error thrown when a new version does not have the type of the file
+var ErrFileTypeMismatch = errors.New("file type mismatch")
+
+// ErrUnauthenticated is an error thrown when the caller could not be authenticated
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrForbidden is an error thrown when the caller is not allowed to perform an action
+var ErrForbidden = errors.New("forbidden")
+
+// ErrQuotaExceeded is an error thrown when an upload would exceed a storage quota
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ErrFileCountQuotaExceeded is an error thrown when an upload would exceed a file count quota
+var ErrFileCountQuotaExceeded = fmt.Errorf("%w: too many files", ErrQuotaExceeded)
+
+// ErrAPIKeyNotFound is an error thrown when api key is not found
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// ErrInvalidScope is an error thrown when a scope cannot be granted
+var ErrInvalidScope = errors.New("invalid scope")
+
+// ErrObjectNotFound is an error thrown when an object is not in the storage
+var ErrObjectNotFound = errors.New("object not found")
+
+// ErrOffsetMismatch is an error thrown when resumable upload data does not start at the received offset
+var ErrOffsetMismatch = errors.New("offset mismatch")
+
+// ErrUploadLocked is an error thrown when a resumable upload is already receiving data
+var ErrUploadLocked = errors.New("upload locked")
+
+// ErrImportNotFound is an error thrown when a file was not imported from a remote url
+var ErrImportNotFound = errors.New("import not found")
+
+// ErrSourceNotAllowed is an error thrown when a remote url cannot be imported from
+var ErrSourceNotAllowed = errors.New("source not allowed")
+
+// ErrInvalidClip is an error thrown when a clip does not fit in its source file
+var ErrInvalidClip = errors.New("invalid clip")
+
+// ErrSourceUnavailable is an error thrown when a remote url could not be fetched
+var ErrSourceUnavailable = errors.New("source unavailable")
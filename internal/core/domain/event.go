@@ -26,6 +26,8 @@ type EventType string
 const (
 	EventTypeSimpleUploadComplete    EventType = "SimpleUpdateComplete"
 	EventTypeMultipartUploadComplete EventType = "UpdateComplete"
+	EventTypeTransitionComplete      EventType = "TransitionComplete"
+	EventTypeCopyComplete            EventType = "CopyComplete"
 	EventTypeUnknown                 EventType = "Unknown"
 )
 
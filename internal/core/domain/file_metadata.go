@@ -24,17 +24,32 @@ const (
 	FileTypeUnknown FileType = "unknown"
 )
 
+// StorageClassStandard is the storage class of objects that have not been transitioned
+const StorageClassStandard = "STANDARD"
+
+// Clip selects the Length bytes of a file starting at Offset
+type Clip struct {
+	Offset int64
+	Length int64
+}
+
 // FileMetadata represents a file metadata
 type FileMetadata struct {
-	ID         uuid.UUID
-	Filename   string
-	MimeType   string
-	MediaType  string
-	SizeBytes  int64
-	StorageKey string
-	Checksum   string
-	Status     FileStatus
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  *time.Time
+	ID             uuid.UUID
+	Filename       string
+	MimeType       string
+	MediaType      string
+	SizeBytes      int64
+	Bucket         string
+	StorageKey     string
+	Checksum       string
+	Status         FileStatus
+	StorageClass   string
+	CurrentVersion int
+	SourceFileID   *uuid.UUID
+	OwnerID        *string
+	TenantID       string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
 }
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileVersion represents an uploaded version of a file
+type FileVersion struct {
+	ID         uuid.UUID
+	FileID     uuid.UUID
+	Version    int
+	Filename   string
+	MimeType   string
+	SizeBytes  int64
+	Bucket     string
+	StorageKey string
+	Checksum   string
+	Status     FileStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportStatus is the status of the import of a file from a remote url
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+// FileImport is a file the worker fetches from a remote url into the upload session SessionID.
+// It outlives the file when the import fails, to report why.
+type FileImport struct {
+	FileID        uuid.UUID
+	SessionID     uuid.UUID
+	SourceURL     string
+	OwnerID       *string
+	Status        ImportStatus
+	SizeBytes     int64
+	BytesReceived int64
+	Attempts      int
+	Error         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// CheckImportURL parses rawURL and checks it is an http or https url of one of allowedHosts.
+// "*.example.com" allows the subdomains of example.com, the addresses hosts resolve to are checked when fetching.
+func CheckImportURL(rawURL string, allowedHosts []string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSourceNotAllowed, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: scheme %q is not http or https", ErrSourceNotAllowed, u.Scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("%w: credentials in urls are not supported", ErrSourceNotAllowed)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: host %q is not allowed", ErrSourceNotAllowed, host)
+}
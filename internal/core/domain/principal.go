@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Issuer  string
+	Scopes  []string
+	Roles   []string
+	// Tenant is the tenant the token was issued for, empty when the token does not name one
+	Tenant string
+}
+
+// HasScope reports whether the principal was granted scope
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// HasRole reports whether the principal was granted role
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
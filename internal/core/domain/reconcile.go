@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredObject is an object listed from the storage
+type StoredObject struct {
+	Bucket       string    `json:"bucket"`
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// IncompleteUpload is a multipart upload of the storage neither completed nor aborted
+type IncompleteUpload struct {
+	Bucket    string    `json:"bucket"`
+	Key       string    `json:"key"`
+	UploadID  string    `json:"upload_id"`
+	Initiated time.Time `json:"initiated"`
+}
+
+// MissingObject is a completed file whose object is not in the storage
+type MissingObject struct {
+	FileID uuid.UUID `json:"file_id"`
+	Bucket string    `json:"bucket"`
+	Key    string    `json:"key"`
+}
+
+// ReconcileOptions configures a reconciliation. Objects and uploads younger than MinAge are ignored,
+// since the rows of uploads in progress may not be committed yet.
+type ReconcileOptions struct {
+	DryRun bool
+	MinAge time.Duration
+}
+
+// ReconcileReport lists the mismatches between the storage and the database, and how many were repaired
+type ReconcileReport struct {
+	StartedAt      time.Time          `json:"started_at"`
+	FinishedAt     time.Time          `json:"finished_at"`
+	DryRun         bool               `json:"dry_run"`
+	ObjectsScanned int                `json:"objects_scanned"`
+	FilesScanned   int                `json:"files_scanned"`
+	UploadsScanned int                `json:"uploads_scanned"`
+	OrphanObjects  []StoredObject     `json:"orphan_objects"`
+	MissingObjects []MissingObject    `json:"missing_objects"`
+	StrayUploads   []IncompleteUpload `json:"stray_uploads"`
+	Repaired       int                `json:"repaired"`
+	// Errors are the repairs or checks that failed, the reconciliation goes on after them
+	Errors []string `json:"errors"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+)
+
+// DefaultTenant is the tenant of requests that do not name one
+const DefaultTenant = "default"
+
+// ScopeCrossTenant lets a principal without a tenant act on the tenant named by a request
+const ScopeCrossTenant = "tenants:any"
+
+var tenantRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidTenant reports whether tenant can be used as a tenant id. Tenants end up in storage keys.
+func ValidTenant(tenant string) bool {
+	return tenantRegexp.MatchString(tenant)
+}
+
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of ctx scoped to tenant
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to. Background jobs are not scoped.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// TenantOrDefault returns the tenant of ctx, or DefaultTenant when ctx is not scoped
+func TenantOrDefault(ctx context.Context) string {
+	if tenant, ok := TenantFromContext(ctx); ok {
+		return tenant
+	}
+	return DefaultTenant
+}
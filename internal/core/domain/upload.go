@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,12 +21,16 @@ const (
 type UploadSession struct {
 	ID               uuid.UUID
 	FileID           uuid.UUID
+	VersionID        *uuid.UUID
 	ProviderUploadID string
 	PartSize         int
+	UploadOffset     int64
 	ExpiresAt        time.Time
 	Status           UploadSessionStatus
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
+	// ChecksumState is the marshaled SHA-256 of the UploadOffset bytes received through the api, empty for presigned uploads
+	ChecksumState []byte
 }
 
 // UploadPart represents an upload part (chunk)
@@ -37,3 +43,24 @@ type UploadPart struct {
 	Headers        map[string]string
 	ExpiresAt      *time.Time
 }
+
+// tusBufferMarker separates the storage key of a resumable upload from the part number its buffer objects start
+const tusBufferMarker = ".tus-part-"
+
+// TusBufferKey is the key of the object buffering the start of part partNumber of a resumable upload to storageKey,
+// received before the part is full
+func TusBufferKey(storageKey string, partNumber int) string {
+	return storageKey + tusBufferMarker + strconv.Itoa(partNumber)
+}
+
+// TusBufferOwner returns the storage key of the upload a buffer object belongs to, false when key is no buffer
+func TusBufferOwner(key string) (string, bool) {
+	index := strings.LastIndex(key, tusBufferMarker)
+	if index == -1 {
+		return "", false
+	}
+	if _, err := strconv.Atoi(key[index+len(tusBufferMarker):]); err != nil {
+		return "", false
+	}
+	return key[:index], true
+}
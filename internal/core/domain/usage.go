@@ -0,0 +1,23 @@
+package domain
+
+// Usage is the storage used by a tenant or a user, with the limits that apply to it. A zero limit is unlimited.
+type Usage struct {
+	// BytesStored counts every completed version of the files that are not deleted
+	BytesStored int64
+	// BytesReserved counts uploads in progress, released once they complete, fail or expire
+	BytesReserved int64
+	FileCount     int64
+	MaxBytes      int64
+	MaxFiles      int64
+}
+
+// Allows reports whether bytes and files more fit in the limits
+func (u Usage) Allows(bytes int64, files int64) error {
+	if u.MaxBytes > 0 && u.BytesStored+u.BytesReserved+bytes > u.MaxBytes {
+		return ErrQuotaExceeded
+	}
+	if u.MaxFiles > 0 && u.FileCount+files > u.MaxFiles {
+		return ErrFileCountQuotaExceeded
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository represents the api key repository
+type APIKeyRepository interface {
+	Create(ctx context.Context, key domain.APIKey, hash string) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	FindByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	Expire(ctx context.Context, id uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// APIKeyService manages the api keys of a tenant and verifies them as bearer tokens
+type APIKeyService interface {
+	TokenVerifier
+	CreateAPIKey(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	RotateAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, string, error)
+}
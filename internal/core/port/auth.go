@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+)
+
+// TokenVerifier is an interface to verify bearer tokens (jwt, opaque, ...)
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
@@ -2,11 +2,18 @@ package port
 
 import (
 	"context"
+	"score-play/internal/core/domain"
 	"time"
 )
 
-// CleanupService is service that handles cleanup
+// CleanupService is service that handles cleanup.
+// Each run returns its summary, even when it stopped on an error.
 type CleanupService interface {
-	CleanupExpiredFiles(ctx context.Context, now time.Time) error
-	CleanupExpiredSessions(ctx context.Context, now time.Time) error
+	CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error)
+	CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error)
+}
+
+// CleanupRunRepository keeps the summaries of the cleanup runs
+type CleanupRunRepository interface {
+	Create(ctx context.Context, run domain.CleanupRun) error
 }
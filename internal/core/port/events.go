@@ -12,3 +12,8 @@ type EventConsumer interface {
 type MessageService interface {
 	HandleMessage(ctx context.Context, data []byte) error
 }
+
+// DeliveryObserver is implemented by message services interested in how their messages were acknowledged
+type DeliveryObserver interface {
+	ObserveDelivery(numDelivered uint64, acked bool)
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"io"
 	"score-play/internal/core/domain"
 	"time"
 
@@ -11,34 +12,83 @@ import (
 
 // FileRepository is an interface to define file repository interactions
 type FileRepository interface {
-	Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, storageKey string) error
+	Create(ctx context.Context, id uuid.UUID, fileName, mimeType string, mediaType domain.FileType, size int64, status domain.FileStatus, checksum string, bucket string, storageKey string, ownerID *string) error
+	CreateCopy(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, bucket string, storageKey string, ownerID *string) error
+	// CreateShared creates a completed file stored in the object of source, which has the same content
+	CreateShared(ctx context.Context, id uuid.UUID, source domain.FileMetadata, fileName string, ownerID *string) error
 	FindById(ctx context.Context, id uuid.UUID) (*domain.FileMetadata, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) error
+	UpdateStorageClass(ctx context.Context, id uuid.UUID, storageClass string) error
+	// UpdateChecksum sets the checksum of a file uploaded without one, once the api has computed it
+	UpdateChecksum(ctx context.Context, id uuid.UUID, checksum string) error
+	SetCurrentVersion(ctx context.Context, id uuid.UUID, version domain.FileVersion) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	FindExpired(ctx context.Context, expirationTime time.Time) ([]domain.FileMetadata, error)
+	FindExpired(ctx context.Context, expirationTime time.Time, after uuid.UUID, limit int) ([]domain.FileMetadata, error)
+	FindByStatus(ctx context.Context, status domain.FileStatus, after uuid.UUID, limit int) ([]domain.FileMetadata, error)
+	ExistsByStorageKey(ctx context.Context, bucket string, storageKey string) (bool, error)
+	// FindDuplicates finds up to limit completed files with the same content, the ones of ownerID first
+	FindDuplicates(ctx context.Context, checksum string, sizeBytes int64, mimeType string, ownerID *string, limit int) ([]domain.FileMetadata, error)
+	// CountReferences counts the live files other than excludeID still using the object at storageKey
+	CountReferences(ctx context.Context, bucket string, storageKey string, excludeID uuid.UUID) (int, error)
 }
 
 // FileStorage is an interface to define file storage interactions
 type FileStorage interface {
-	GeneratePresignedURLSimpleUpload(ctx context.Context, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error)
-	InitMultipartUpload(ctx context.Context, fileName string, checksum string) (string, error)
-	GeneratePresignedURLForPart(ctx context.Context, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error)
-	CompleteMultipartUpload(ctx context.Context, fileName string, uploadID string, parts []domain.UploadPart) error
-	GetObjectInfo(ctx context.Context, fileKey string) (*minio.ObjectInfo, error)
-	ListPartsPaginated(ctx context.Context, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error)
-	AbortMultipartUpload(ctx context.Context, fileKey string, uploadID string) error
-	DeleteObject(ctx context.Context, fileKey string) error
-	GeneratePresignedURLForDownload(ctx context.Context, fileKey string) (string, *time.Time, error)
-	GetHeaderBytes(ctx context.Context, fileKey string, n int64) ([]byte, error)
+	ResolveLocation(fileType domain.FileType, tenant string, fileID uuid.UUID, at time.Time) (bucket string, fileKey string)
+	GeneratePresignedURLSimpleUpload(ctx context.Context, bucket string, fileKey string, checksumSha256 string) (string, map[string]string, *time.Time, error)
+	// GeneratePresignedPostPolicy signs an html form upload of exactly sizeBytes bytes of contentType to fileKey.
+	// It returns the url to post to and the fields to send before the file.
+	GeneratePresignedPostPolicy(ctx context.Context, bucket string, fileKey string, contentType string, sizeBytes int64, checksumSha256 string) (string, map[string]string, *time.Time, error)
+	InitMultipartUpload(ctx context.Context, bucket string, fileName string, checksum string) (string, error)
+	GeneratePresignedURLForPart(ctx context.Context, bucket string, fileKey string, partNumber int, uploadID, mimeType string, contentLength int64, checksumSha256 string) (string, map[string]string, *time.Time, error)
+	CompleteMultipartUpload(ctx context.Context, bucket string, fileName string, uploadID string, parts []domain.UploadPart) error
+	GetObjectInfo(ctx context.Context, bucket string, fileKey string) (*minio.ObjectInfo, error)
+	ListPartsPaginated(ctx context.Context, bucket string, fileKey string, uploadID string, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error)
+	// UploadPart sends part partNumber of the multipart upload uploadID, the storage rejects data not matching checksumSha256
+	UploadPart(ctx context.Context, bucket string, fileKey string, uploadID string, partNumber int, data io.Reader, size int64, checksumSha256 string) (domain.UploadPart, error)
+	AbortMultipartUpload(ctx context.Context, bucket string, fileKey string, uploadID string) error
+	DeleteObject(ctx context.Context, bucket string, fileKey string) error
+	PutObject(ctx context.Context, bucket string, fileKey string, data io.Reader, size int64) error
+	GeneratePresignedURLForDownload(ctx context.Context, bucket string, fileKey string, opts domain.DownloadOptions) (string, map[string]string, *time.Time, error)
+	GetHeaderBytes(ctx context.Context, bucket string, fileKey string, n int64) ([]byte, error)
+	RestoreObject(ctx context.Context, bucket string, fileKey string) error
+	// CopyObject copies srcKey to dstKey, or only the clip of it when clip is not nil
+	CopyObject(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, clip *domain.Clip) error
+	// ListObjects calls fn for every object of bucket, until fn fails
+	ListObjects(ctx context.Context, bucket string, fn func(object domain.StoredObject) error) error
+	// ListIncompleteUploads calls fn for every multipart upload in progress in bucket, until fn fails
+	ListIncompleteUploads(ctx context.Context, bucket string, fn func(upload domain.IncompleteUpload) error) error
 }
 
 // FileService is an interface to define file service
 type FileService interface {
 	RequestUploadFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error)
+	// RequestUploadFilePost is RequestUploadFile for html forms, it returns the url to post to and the fields of the form
+	RequestUploadFilePost(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error)
 	RequestUploadMultipartFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error)
+	// DeduplicateUpload creates a completed file sharing the object of a completed file with the same content,
+	// it returns nil when there is none the caller can read and the content has to be uploaded
+	DeduplicateUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error)
 	GetPresignedParts(ctx context.Context, sessionID uuid.UUID, parts []domain.UploadPart) ([]domain.UploadPart, error)
 	ListParts(ctx context.Context, sessionID uuid.UUID, maxParts int, partNumberMarker int) ([]domain.UploadPart, int, error)
 	CompleteMultipartUpload(ctx context.Context, sessionID uuid.UUID, parts []domain.UploadPart) (*uuid.UUID, error)
-	GetFile(ctx context.Context, fileID uuid.UUID) (url *string, filename *string, tags []domain.Tag, expiresAt *time.Time, error error)
+	// RequestResumableUpload opens a multipart upload session for content sent through the api, e.g. with tus
+	RequestResumableUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error)
+	// GetUploadSession returns an open session and the file it uploads to
+	GetUploadSession(ctx context.Context, sessionID uuid.UUID) (*domain.UploadSession, *domain.FileMetadata, error)
+	AbortMultipartUpload(ctx context.Context, sessionID uuid.UUID) error
+	GetFile(ctx context.Context, fileID uuid.UUID, opts domain.DownloadOptions) (url *string, filename *string, tags []domain.Tag, headers map[string]string, expiresAt *time.Time, checksum string, error error)
 	FinalizeUpload(ctx context.Context, metadata domain.FileMetadata, err error, eventType domain.EventType) error
+	RequestUploadFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *string, map[string]string, *time.Time, error)
+	RequestUploadMultipartFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *uuid.UUID, int, error)
+	ListFileVersions(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error)
+	GetFileVersion(ctx context.Context, fileID uuid.UUID, version int, opts domain.DownloadOptions) (url *string, filename *string, headers map[string]string, expiresAt *time.Time, err error)
+	FinalizeVersionUpload(ctx context.Context, version domain.FileVersion, err error, eventType domain.EventType) error
+	// CopyFile copies the file sourceID, or only the clip of it when clip is not nil
+	CopyFile(ctx context.Context, sourceID uuid.UUID, fileName string, tags []string, clip *domain.Clip) (*uuid.UUID, error)
+	GetUsage(ctx context.Context) (tenant *domain.Usage, user *domain.Usage, err error)
+	// RequestImportFile opens a multipart upload session the worker fills with the content of sourceURL
+	RequestImportFile(ctx context.Context, sourceURL string, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error)
+	// GetFileImport returns the progress of the import of the file fileID and the status of the file
+	GetFileImport(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, domain.FileStatus, error)
 }
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"io"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileImportRepository is an interface to interact with file import repositories
+type FileImportRepository interface {
+	Create(ctx context.Context, fileImport domain.FileImport) error
+	FindByFileID(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, error)
+	// ClaimNext marks the oldest pending import, or running one not updated since staleBefore, as running for a new attempt.
+	// It returns domain.ErrImportNotFound when there is none.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.FileImport, error)
+	UpdateProgress(ctx context.Context, fileID uuid.UUID, bytesReceived int64) error
+	UpdateStatus(ctx context.Context, fileID uuid.UUID, status domain.ImportStatus, errMsg string) error
+}
+
+// RemoteSource fetches the content of remote urls
+type RemoteSource interface {
+	// Open starts reading rawURL. size is the announced length of the content, -1 when unknown.
+	Open(ctx context.Context, rawURL string) (body io.ReadCloser, size int64, err error)
+}
+
+// ImportService fetches the files imported from remote urls into the storage
+type ImportService interface {
+	// ImportNext imports the next pending file, it returns false when there is none
+	ImportNext(ctx context.Context) (bool, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// FileVersionRepository is an interface to define file version repository interactions
+type FileVersionRepository interface {
+	Create(ctx context.Context, version domain.FileVersion) error
+	NextVersion(ctx context.Context, fileID uuid.UUID) (int, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.FileVersion, error)
+	FindByFileIDAndVersion(ctx context.Context, fileID uuid.UUID, version int) (*domain.FileVersion, error)
+	ListByFileID(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.FileStatus) error
+}
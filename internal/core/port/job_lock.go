@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// JobLock makes sure a background job runs on a single instance at a time
+type JobLock interface {
+	// TryRun runs fn unless another instance holds the lock of job, it reports whether fn ran
+	TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (ran bool, err error)
+}
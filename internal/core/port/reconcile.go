@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+)
+
+// ReconcileService compares the storage with the database
+type ReconcileService interface {
+	Reconcile(ctx context.Context, opts domain.ReconcileOptions) (*domain.ReconcileReport, error)
+}
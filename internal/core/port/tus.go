@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// TusService receives resumable uploads over the multipart upload sessions of FileService, see https://tus.io
+type TusService interface {
+	// MaxSize is the size of the largest upload accepted
+	MaxSize() int64
+	// Create opens an upload, its id is the id of the upload session
+	Create(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error)
+	// Offset returns the number of bytes the upload id has received and its size
+	Offset(ctx context.Context, id uuid.UUID) (offset int64, size int64, err error)
+	// Write appends data to the upload id at offset and returns the new offset, the upload is completed once it is full.
+	// When checksumSha256 is set, data not matching it is rejected.
+	Write(ctx context.Context, id uuid.UUID, offset int64, data io.Reader, checksumSha256 string) (int64, error)
+	// Terminate aborts the upload id
+	Terminate(ctx context.Context, id uuid.UUID) error
+}
@@ -9,4 +9,8 @@ type UnitOfWork interface {
 	FileRepo() FileRepository
 	UploadSessionRepo() UploadSessionRepository
 	FileTagRepo() FileTagRepository
+	FileVersionRepo() FileVersionRepository
+	UsageRepo() UsageRepository
+	CleanupRunRepo() CleanupRunRepository
+	FileImportRepo() FileImportRepository
 }
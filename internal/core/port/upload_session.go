@@ -12,10 +12,17 @@ import (
 type UploadSessionRepository interface {
 	Create(ctx context.Context, session domain.UploadSession) error
 	UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	UpdateOffset(ctx context.Context, id uuid.UUID, offset int64, checksumState []byte) error
+	AcquireLease(ctx context.Context, id uuid.UUID, holder uuid.UUID, now time.Time, until time.Time) error
+	ReleaseLease(ctx context.Context, id uuid.UUID, holder uuid.UUID) error
 	FindByIDAndActive(ctx context.Context, id uuid.UUID) (*domain.UploadSession, error)
 	UpdateStatusByFileID(ctx context.Context, fileID uuid.UUID, status domain.UploadSessionStatus) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.UploadSession, error)
 	FindByFileID(ctx context.Context, fileID uuid.UUID) (*domain.UploadSession, error)
-	FindAllExpired(ctx context.Context, now time.Time) ([]domain.UploadSession, error)
+	FindByVersionID(ctx context.Context, versionID uuid.UUID) (*domain.UploadSession, error)
+	FindByProviderUploadID(ctx context.Context, providerUploadID string) (*domain.UploadSession, error)
+	FindAllExpired(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]domain.UploadSession, error)
+	ClaimExpired(ctx context.Context, id uuid.UUID, now time.Time) (*domain.UploadSession, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadSessionStatus) error
+	CountOpen(ctx context.Context) (int64, error)
 }
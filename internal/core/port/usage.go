@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+)
+
+// UsageRepository computes the storage used by the tenant of ctx
+type UsageRepository interface {
+	// Lock serializes quota checks of the tenant until the end of the transaction
+	Lock(ctx context.Context) error
+	// Get returns the usage of the tenant, or of one of its users when ownerID is not nil. Limits are left empty.
+	Get(ctx context.Context, ownerID *string) (*domain.Usage, error)
+}
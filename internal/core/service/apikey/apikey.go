@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+)
+
+// prefixLength is the number of characters of a secret kept to recognize the key, "sk_" included
+const prefixLength = len(domain.APIKeyPrefix) + 8
+
+type apiKeyService struct {
+	repo          port.APIKeyRepository
+	rotationGrace time.Duration
+	logger        *slog.Logger
+}
+
+// NewAPIKeyService creates a new api key service.
+// Rotated keys keep working for rotationGrace so that clients can switch to the new secret.
+func NewAPIKeyService(repo port.APIKeyRepository, rotationGrace time.Duration, logger *slog.Logger) port.APIKeyService {
+	return &apiKeyService{
+		repo:          repo,
+		rotationGrace: rotationGrace,
+		logger:        logger,
+	}
+}
+
+// authorize allows principals with the admin scope or role to manage api keys.
+// Requests without principal are allowed since authentication is optional.
+func authorize(ctx context.Context) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.HasScope(domain.ScopeAdmin) || principal.HasRole(domain.RoleAdmin) {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// newSecret generates a random api key secret
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret returns the hex sha256 of secret, the only form of it that is stored
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
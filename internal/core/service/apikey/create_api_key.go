@@ -0,0 +1,55 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAPIKey creates an api key for the tenant of ctx. The secret is returned once and never stored.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if !domain.ValidAPIKeyScope(scope) {
+			return nil, "", fmt.Errorf("%s: %w", scope, domain.ErrInvalidScope)
+		}
+	}
+
+	return s.issue(ctx, uuid.Nil, name, scopes, expiresAt)
+}
+
+// issue generates a secret and stores the key with its hash. A nil subjectID starts a new subject with the key id.
+func (s *apiKeyService) issue(ctx context.Context, subjectID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, "", fmt.Errorf("error generating api key: %w", err)
+	}
+
+	key := domain.APIKey{
+		ID:        uuid.New(),
+		TenantID:  domain.TenantOrDefault(ctx),
+		Name:      name,
+		Prefix:    secret[:prefixLength],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	key.SubjectID = subjectID
+	if subjectID == uuid.Nil {
+		key.SubjectID = key.ID
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		key.CreatedBy = &principal.Subject
+	}
+
+	if err := s.repo.Create(ctx, key, hashSecret(secret)); err != nil {
+		return nil, "", err
+	}
+
+	return &key, secret, nil
+}
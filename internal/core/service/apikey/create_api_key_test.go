@@ -0,0 +1,94 @@
+package apikey_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestCreateAPIKey_ok(t *testing.T) {
+
+	//Arrange
+	mockRepo := repository.NewMockAPIKeyRepository()
+	service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	ctx = domain.ContextWithPrincipal(ctx, domain.Principal{Subject: "user-1", Roles: []string{domain.RoleAdmin}})
+	expiresAt := time.Now().Add(24 * time.Hour)
+	var storedHash string
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(key domain.APIKey) bool {
+		return key.Name == "camera-1" && key.TenantID == "acme" && *key.CreatedBy == "user-1" && key.SubjectID == key.ID
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		storedHash = args.String(2)
+	}).Return(nil)
+
+	//Act
+	key, secret, err := service.CreateAPIKey(ctx, "camera-1", []string{domain.ScopeUpload}, &expiresAt)
+
+	//Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, domain.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.Len(t, key.Prefix, 11)
+	assert.Len(t, storedHash, 64)
+	assert.NotContains(t, storedHash, secret)
+	assert.Equal(t, []string{domain.ScopeUpload}, key.Scopes)
+	assert.Equal(t, &expiresAt, key.ExpiresAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAPIKey_ko(t *testing.T) {
+
+	t.Run("invalid scope", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+
+		//Act
+		_, _, err := service.CreateAPIKey(context.Background(), "camera-1", []string{"files:delete"}, nil)
+
+		//Assert
+		require.ErrorIs(t, err, domain.ErrInvalidScope)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("principal without admin scope or role", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "user-1", Scopes: []string{domain.ScopeUpload}})
+
+		//Act
+		_, _, err := service.CreateAPIKey(ctx, "camera-1", []string{domain.ScopeUpload}, nil)
+
+		//Assert
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		mockRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(assert.AnError)
+
+		//Act
+		_, _, err := service.CreateAPIKey(ctx, "camera-1", []string{domain.ScopeUpload}, nil)
+
+		//Assert
+		require.ErrorIs(t, err, assert.AnError)
+		mockRepo.AssertExpectations(t)
+	})
+}
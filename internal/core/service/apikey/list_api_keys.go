@@ -0,0 +1,14 @@
+package apikey
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+)
+
+// ListAPIKeys lists the api keys of the tenant of ctx, revoked and expired ones included
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
@@ -0,0 +1,47 @@
+package apikey_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAPIKeys_ok(t *testing.T) {
+
+	//Arrange
+	mockRepo := repository.NewMockAPIKeyRepository()
+	service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "apikey:1", Scopes: []string{domain.ScopeAdmin}})
+	keys := []domain.APIKey{{ID: uuid.New(), Name: "camera-1"}}
+	mockRepo.On("List", ctx).Return(keys, nil)
+
+	//Act
+	result, err := service.ListAPIKeys(ctx)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, keys, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListAPIKeys_ko(t *testing.T) {
+
+	//Arrange
+	mockRepo := repository.NewMockAPIKeyRepository()
+	service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "apikey:1", Scopes: []string{domain.ScopeRead}})
+
+	//Act
+	_, err := service.ListAPIKeys(ctx)
+
+	//Assert
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "List")
+}
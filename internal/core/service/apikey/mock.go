@@ -0,0 +1,40 @@
+package apikey
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyService is a mock implementation of APIKeyService
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	args := m.Called(ctx, name, scopes, expiresAt)
+	return args.Get(0).(*domain.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyService) RotateAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, string, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.APIKey), args.String(1), args.Error(2)
+}
@@ -0,0 +1,16 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokeAPIKey revokes an api key, it stops authenticating requests immediately
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx); err != nil {
+		return err
+	}
+	return s.repo.Revoke(ctx, id, time.Now())
+}
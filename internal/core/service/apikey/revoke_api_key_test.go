@@ -0,0 +1,48 @@
+package apikey_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeAPIKey_ok(t *testing.T) {
+
+	//Arrange
+	mockRepo := repository.NewMockAPIKeyRepository()
+	service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+	ctx := context.Background()
+	id := uuid.New()
+	mockRepo.On("Revoke", ctx, id, mock.AnythingOfType("time.Time")).Return(nil)
+
+	//Act
+	err := service.RevokeAPIKey(ctx, id)
+
+	//Assert
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevokeAPIKey_ko(t *testing.T) {
+
+	//Arrange
+	mockRepo := repository.NewMockAPIKeyRepository()
+	service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+	ctx := context.Background()
+	id := uuid.New()
+	mockRepo.On("Revoke", ctx, id, mock.AnythingOfType("time.Time")).Return(domain.ErrAPIKeyNotFound)
+
+	//Act
+	err := service.RevokeAPIKey(ctx, id)
+
+	//Assert
+	require.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	mockRepo.AssertExpectations(t)
+}
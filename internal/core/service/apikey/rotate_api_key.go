@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RotateAPIKey issues a new secret with the subject, name, scopes and expiry of an active key.
+// The old key expires after the rotation grace period, immediately when there is none.
+func (s *apiKeyService) RotateAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, string, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, "", err
+	}
+
+	old, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if !old.Active(now) {
+		return nil, "", domain.ErrAPIKeyNotFound
+	}
+
+	key, secret, err := s.issue(ctx, old.SubjectID, old.Name, old.Scopes, old.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.rotationGrace > 0 {
+		err = s.repo.Expire(ctx, old.ID, now.Add(s.rotationGrace))
+	} else {
+		err = s.repo.Revoke(ctx, old.ID, now)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, secret, nil
+}
@@ -0,0 +1,97 @@
+package apikey_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateAPIKey_ok(t *testing.T) {
+
+	t.Run("old key expires after the grace period", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		old := &domain.APIKey{ID: uuid.New(), SubjectID: uuid.New(), Name: "camera-1", Scopes: []string{domain.ScopeUpload, domain.ScopeRead}}
+		mockRepo.On("FindByID", ctx, old.ID).Return(old, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(key domain.APIKey) bool {
+			return key.ID != old.ID && key.SubjectID == old.SubjectID && key.Name == old.Name
+		}), mock.Anything).Return(nil)
+		mockRepo.On("Expire", ctx, old.ID, mock.MatchedBy(func(at time.Time) bool {
+			return at.After(time.Now().Add(59*time.Minute)) && at.Before(time.Now().Add(61*time.Minute))
+		})).Return(nil)
+
+		//Act
+		key, secret, err := service.RotateAPIKey(ctx, old.ID)
+
+		//Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, old.Scopes, key.Scopes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("old key is revoked without grace period", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, 0, discardLogger)
+		ctx := context.Background()
+		old := &domain.APIKey{ID: uuid.New(), Name: "camera-1", Scopes: []string{domain.ScopeUpload}}
+		mockRepo.On("FindByID", ctx, old.ID).Return(old, nil)
+		mockRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("Revoke", ctx, old.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		//Act
+		_, _, err := service.RotateAPIKey(ctx, old.ID)
+
+		//Assert
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Expire")
+	})
+}
+
+func TestRotateAPIKey_ko(t *testing.T) {
+
+	t.Run("revoked key", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		revokedAt := time.Now().Add(-time.Minute)
+		old := &domain.APIKey{ID: uuid.New(), Name: "camera-1", RevokedAt: &revokedAt}
+		mockRepo.On("FindByID", ctx, old.ID).Return(old, nil)
+
+		//Act
+		_, _, err := service.RotateAPIKey(ctx, old.ID)
+
+		//Assert
+		require.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		id := uuid.New()
+		mockRepo.On("FindByID", ctx, id).Return((*domain.APIKey)(nil), domain.ErrAPIKeyNotFound)
+
+		//Act
+		_, _, err := service.RotateAPIKey(ctx, id)
+
+		//Assert
+		require.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,41 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/core/domain"
+	"strings"
+	"time"
+)
+
+// lastUsedResolution limits the writes of last used times to one per key and minute
+const lastUsedResolution = time.Minute
+
+// Verify authenticates an api key secret and implements port.TokenVerifier
+func (s *apiKeyService) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	if !strings.HasPrefix(token, domain.APIKeyPrefix) {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	key, err := s.repo.FindByHash(ctx, hashSecret(token))
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		return nil, domain.ErrUnauthenticated
+	case err != nil:
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.logger.Warn("failed to record api key usage", "api_key_id", key.ID, "error", err)
+		}
+	}
+
+	principal := key.Principal()
+	return &principal, nil
+}
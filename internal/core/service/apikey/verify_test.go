@@ -0,0 +1,139 @@
+package apikey_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func hashOf(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerify_ok(t *testing.T) {
+
+	t.Run("active key records its usage", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		key := &domain.APIKey{ID: uuid.New(), SubjectID: uuid.New(), TenantID: "acme", Scopes: []string{domain.ScopeUpload}}
+		mockRepo.On("FindByHash", ctx, hashOf("sk_secret")).Return(key, nil)
+		mockRepo.On("TouchLastUsed", ctx, key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		//Act
+		principal, err := service.Verify(ctx, "sk_secret")
+
+		//Assert
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:"+key.SubjectID.String(), principal.Subject)
+		assert.Equal(t, domain.APIKeyIssuer, principal.Issuer)
+		assert.Equal(t, "acme", principal.Tenant)
+		assert.True(t, principal.HasScope(domain.ScopeUpload))
+		assert.Empty(t, principal.Roles)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("recently used key is not written again", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		lastUsedAt := time.Now().Add(-10 * time.Second)
+		key := &domain.APIKey{ID: uuid.New(), Scopes: []string{domain.ScopeAdmin}, LastUsedAt: &lastUsedAt}
+		mockRepo.On("FindByHash", ctx, hashOf("sk_secret")).Return(key, nil)
+
+		//Act
+		principal, err := service.Verify(ctx, "sk_secret")
+
+		//Assert
+		require.NoError(t, err)
+		assert.True(t, principal.HasRole(domain.RoleAdmin))
+		mockRepo.AssertNotCalled(t, "TouchLastUsed")
+	})
+
+	t.Run("usage recording errors do not fail authentication", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		key := &domain.APIKey{ID: uuid.New(), Scopes: []string{domain.ScopeRead}}
+		mockRepo.On("FindByHash", ctx, hashOf("sk_secret")).Return(key, nil)
+		mockRepo.On("TouchLastUsed", ctx, key.ID, mock.AnythingOfType("time.Time")).Return(assert.AnError)
+
+		//Act
+		_, err := service.Verify(ctx, "sk_secret")
+
+		//Assert
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestVerify_ko(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		key  *domain.APIKey
+		err  error
+	}{
+		{name: "unknown key", key: nil, err: domain.ErrAPIKeyNotFound},
+		{name: "expired key", key: &domain.APIKey{ID: uuid.New(), ExpiresAt: &past}},
+		{name: "revoked key", key: &domain.APIKey{ID: uuid.New(), RevokedAt: &past}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			mockRepo := repository.NewMockAPIKeyRepository()
+			service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+			ctx := context.Background()
+			mockRepo.On("FindByHash", ctx, hashOf("sk_secret")).Return(tt.key, tt.err)
+
+			//Act
+			_, err := service.Verify(ctx, "sk_secret")
+
+			//Assert
+			require.ErrorIs(t, err, domain.ErrUnauthenticated)
+			mockRepo.AssertNotCalled(t, "TouchLastUsed")
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+		ctx := context.Background()
+		mockRepo.On("FindByHash", ctx, hashOf("sk_secret")).Return((*domain.APIKey)(nil), assert.AnError)
+
+		//Act
+		_, err := service.Verify(ctx, "sk_secret")
+
+		//Assert
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("not an api key", func(t *testing.T) {
+		//Arrange
+		mockRepo := repository.NewMockAPIKeyRepository()
+		service := apikey.NewAPIKeyService(mockRepo, time.Hour, discardLogger)
+
+		//Act
+		_, err := service.Verify(context.Background(), "eyJhbGciOi")
+
+		//Assert
+		require.ErrorIs(t, err, domain.ErrUnauthenticated)
+		mockRepo.AssertNotCalled(t, "FindByHash")
+	})
+}
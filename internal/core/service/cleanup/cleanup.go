@@ -1,21 +1,70 @@
 package cleanup
 
 import (
+	"context"
 	"log/slog"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
 	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type cleanupService struct {
 	uow         port.UnitOfWork
 	fileStorage port.FileStorage
+	cfg         config.FileUploadConfig
 	logger      *slog.Logger
 }
 
 // NewCleanupService creates a new cleanup service
-func NewCleanupService(uow port.UnitOfWork, fileStorage port.FileStorage, logger *slog.Logger) port.CleanupService {
+func NewCleanupService(uow port.UnitOfWork, fileStorage port.FileStorage, cfg config.FileUploadConfig, logger *slog.Logger) port.CleanupService {
 	return &cleanupService{
 		uow:         uow,
 		fileStorage: fileStorage,
+		cfg:         cfg,
 		logger:      logger,
 	}
 }
+
+// startRun starts the summary of a run of job
+func startRun(job string) *domain.CleanupRun {
+	return &domain.CleanupRun{
+		ID:        uuid.New(),
+		Job:       job,
+		StartedAt: time.Now(),
+	}
+}
+
+// overBudget reports whether the run went past its time budget, it is then flagged so the rest is left to the next run
+func (c *cleanupService) overBudget(run *domain.CleanupRun) bool {
+	if c.cfg.CleanupTimeBudget > 0 && time.Since(run.StartedAt) > c.cfg.CleanupTimeBudget {
+		run.BudgetExceeded = true
+	}
+	return run.BudgetExceeded
+}
+
+// finishRun logs and saves the summary of run, a failure to save it does not fail the run
+func (c *cleanupService) finishRun(ctx context.Context, run *domain.CleanupRun, err error) (*domain.CleanupRun, error) {
+	run.FinishedAt = time.Now()
+	if err != nil {
+		message := err.Error()
+		run.Error = &message
+	}
+
+	c.logger.Info("cleanup run completed",
+		"job", run.Job,
+		"found", run.Found,
+		"cleaned", run.Cleaned,
+		"failed", run.Failed,
+		"budget_exceeded", run.BudgetExceeded,
+		"duration", run.FinishedAt.Sub(run.StartedAt),
+		"err", err,
+	)
+
+	if saveErr := c.uow.CleanupRunRepo().Create(ctx, *run); saveErr != nil {
+		c.logger.Error("Failed to save cleanup run", "job", run.Job, "err", saveErr)
+	}
+	return run, err
+}
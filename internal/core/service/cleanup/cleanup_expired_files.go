@@ -5,65 +5,86 @@ import (
 	"errors"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/port"
+	"score-play/internal/core/service/object"
 	"time"
-)
-
-func (c *cleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) error {
 
-	files, err := c.uow.FileRepo().FindExpired(ctx, now)
-	if err != nil {
-		return err
-	}
+	"github.com/google/uuid"
+)
 
-	for _, file := range files {
+// CleanupExpiredFiles fails the uploads not updated since now, batch by batch until none is left or the time budget is spent.
+// Files with an open session are left to CleanupExpiredSessions, which knows when the session expires.
+func (c *cleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	run := startRun(domain.CleanupJobExpiredFiles)
+	after := uuid.Nil
 
-		session, foundErr := c.uow.UploadSessionRepo().FindByFileID(ctx, file.ID)
-		if foundErr != nil {
-			if !errors.Is(foundErr, domain.ErrSessionNotFound) {
-				return foundErr
-			}
+	for !c.overBudget(run) {
+		files, err := c.uow.FileRepo().FindExpired(ctx, now, after, c.cfg.CleanupBatchSize)
+		if err != nil {
+			return c.finishRun(ctx, run, err)
 		}
-		//Check if multipart : find session
 
-		txErr := c.uow.Execute(ctx, func(uow port.UnitOfWork) error {
-
-			var executeErr error
-
-			executeErr = uow.FileRepo().UpdateStatus(ctx, file.ID, domain.FileStatusFailed)
-			if executeErr != nil {
-				return executeErr
+		for _, file := range files {
+			if c.overBudget(run) {
+				break
 			}
+			after = file.ID
+			run.Found++
 
-			executeErr = uow.FileRepo().Delete(ctx, file.ID)
-			if executeErr != nil {
-				return executeErr
+			// one file whose session cannot be read must not stop the cleanup of the others
+			session, foundErr := c.uow.UploadSessionRepo().FindByFileID(ctx, file.ID)
+			if foundErr != nil && !errors.Is(foundErr, domain.ErrSessionNotFound) {
+				run.Failed++
+				c.logger.Error("Failed to find the session of expired file", "file_id", file.ID, "err", foundErr)
+				continue
 			}
-
-			executeErr = uow.FileTagRepo().DeleteByFileID(ctx, file.ID)
-			if executeErr != nil {
-				return executeErr
+			if session != nil && session.Status == domain.UploadSessionStatusOpen {
+				continue
 			}
 
-			//Delete session if exists
-			if session != nil {
-				executeErr = uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted)
+			// failing the file releases the bytes it reserved in the quotas
+			txErr := c.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+				var executeErr error
+
+				executeErr = uow.FileRepo().UpdateStatus(ctx, file.ID, domain.FileStatusFailed)
 				if executeErr != nil {
 					return executeErr
 				}
-				executeErr = c.fileStorage.AbortMultipartUpload(ctx, file.StorageKey, session.ProviderUploadID)
+
+				executeErr = uow.FileRepo().Delete(ctx, file.ID)
+				if executeErr != nil {
+					return executeErr
+				}
+
+				executeErr = uow.FileTagRepo().DeleteByFileID(ctx, file.ID)
 				if executeErr != nil {
 					return executeErr
 				}
-			} else {
-				executeErr = c.fileStorage.DeleteObject(ctx, file.StorageKey)
+
+				//Delete session if exists
+				if session != nil {
+					executeErr = uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted)
+					if executeErr != nil {
+						return executeErr
+					}
+					return c.fileStorage.AbortMultipartUpload(ctx, file.Bucket, file.StorageKey, session.ProviderUploadID)
+				}
+
+				// the object stays while other files share it
+				return object.DeleteUnreferenced(ctx, uow, c.fileStorage, file.ID, file.Bucket, file.StorageKey)
+			})
+			if txErr != nil {
+				run.Failed++
+				c.logger.Error("Failed to update expired files", "file_id", file.ID, "err", txErr)
+				continue
 			}
-			return nil
-		})
-		if txErr != nil {
-			c.logger.Error("Failed to update expired sessions", "err", txErr)
+			run.Cleaned++
+		}
+
+		if len(files) < c.cfg.CleanupBatchSize {
+			break
 		}
 	}
-	c.logger.Info("update expired data completed")
-	return nil
 
+	return c.finishRun(ctx, run, nil)
 }
@@ -22,18 +22,20 @@ func TestCleanupService_CleanupExpiredFiles_NoExpiredFiles(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	mockFileRepo := mockUow.GetFileRepoMock()
 
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{}, nil)
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{}, nil)
 
 	// Act
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, 0, run.Found)
 	mockFileRepo.AssertExpectations(t)
 }
 
@@ -43,7 +45,8 @@ func TestCleanupService_CleanupExpiredFiles_SuccessWithSession(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID := uuid.New()
@@ -64,22 +67,23 @@ func TestCleanupService_CleanupExpiredFiles_SuccessWithSession(t *testing.T) {
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{file}, nil)
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{file}, nil)
 	mockUploadSessionRepo.On("FindByFileID", ctx, fileID).Return(&session, nil)
 
 	mockFileRepo.On("UpdateStatus", ctx, session.FileID, domain.FileStatusFailed).Return(nil)
 	mockFileRepo.On("Delete", ctx, session.FileID).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, session.FileID).Return(nil)
 	mockUploadSessionRepo.On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, file.StorageKey, session.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, file.Bucket, file.StorageKey, session.ProviderUploadID).Return(nil)
 
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
 
 	// Act
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, 1, run.Cleaned)
 	mockFileRepo.AssertExpectations(t)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileTagRepo.AssertExpectations(t)
@@ -91,7 +95,8 @@ func TestCleanupService_CleanupExpiredFiles_SuccessWithoutSession(t *testing.T)
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID := uuid.New()
@@ -101,7 +106,7 @@ func TestCleanupService_CleanupExpiredFiles_SuccessWithoutSession(t *testing.T)
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{file}, nil)
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{file}, nil)
 	mockUploadSessionRepo.On("FindByFileID", ctx, fileID).Return((*domain.UploadSession)(nil), domain.ErrSessionNotFound)
 
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
@@ -109,34 +114,72 @@ func TestCleanupService_CleanupExpiredFiles_SuccessWithoutSession(t *testing.T)
 	mockFileRepo.On("UpdateStatus", ctx, fileID, domain.FileStatusFailed).Return(nil)
 	mockFileRepo.On("Delete", ctx, fileID).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID).Return(nil)
-	mockStorage.On("DeleteObject", ctx, file.StorageKey).Return(nil)
+	mockFileRepo.On("CountReferences", ctx, file.Bucket, file.StorageKey, fileID).Return(0, nil)
+	mockStorage.On("DeleteObject", ctx, file.Bucket, file.StorageKey).Return(nil)
 
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 1, run.Cleaned)
 	mockStorage.AssertExpectations(t)
 	mockUploadSessionRepo.AssertExpectations(t)
 }
 
+func TestCleanupService_CleanupExpiredFiles_KeepsSharedObject(t *testing.T) {
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	logger := slog.Default()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+
+	now := time.Now()
+	fileID := uuid.New()
+	file := domain.FileMetadata{ID: fileID, StorageKey: "shared-key"}
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockFileTagRepo := mockUow.GetFileTagRepoMock()
+
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{file}, nil)
+	mockUploadSessionRepo.On("FindByFileID", ctx, fileID).Return((*domain.UploadSession)(nil), domain.ErrSessionNotFound)
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	mockFileRepo.On("UpdateStatus", ctx, fileID, domain.FileStatusFailed).Return(nil)
+	mockFileRepo.On("Delete", ctx, fileID).Return(nil)
+	mockFileTagRepo.On("DeleteByFileID", ctx, fileID).Return(nil)
+	mockFileRepo.On("CountReferences", ctx, file.Bucket, file.StorageKey, fileID).Return(2, nil)
+
+	run, err := service.CleanupExpiredFiles(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, run.Cleaned)
+	mockFileRepo.AssertExpectations(t)
+	mockStorage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCleanupService_CleanupExpiredFiles_FindExpiredError(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	expectedError := errors.New("database error")
 
 	mockFileRepo := mockUow.GetFileRepoMock()
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{}, expectedError)
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{}, expectedError)
 
 	// Act
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	// Assert
 	assert.Error(t, err)
+	assert.Equal(t, 0, run.Found)
 	assert.Equal(t, expectedError, err)
 	mockFileRepo.AssertExpectations(t)
 }
@@ -147,25 +190,37 @@ func TestCleanupService_CleanupExpiredFiles_FindByFileIDError(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
-	fileID := uuid.New()
-	file := domain.FileMetadata{ID: fileID}
-	expectedError := errors.New("unexpected error")
+	unreadable := domain.FileMetadata{ID: uuid.New()}
+	file := domain.FileMetadata{ID: uuid.New(), StorageKey: "simple-key"}
 
 	mockFileRepo := mockUow.GetFileRepoMock()
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockFileTagRepo := mockUow.GetFileTagRepoMock()
+
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{unreadable, file}, nil)
+	mockUploadSessionRepo.On("FindByFileID", ctx, unreadable.ID).Return((*domain.UploadSession)(nil), errors.New("unexpected error"))
+	mockUploadSessionRepo.On("FindByFileID", ctx, file.ID).Return((*domain.UploadSession)(nil), domain.ErrSessionNotFound)
 
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{file}, nil)
-	mockUploadSessionRepo.On("FindByFileID", ctx, fileID).Return((*domain.UploadSession)(nil), expectedError)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockFileRepo.On("UpdateStatus", ctx, file.ID, domain.FileStatusFailed).Return(nil)
+	mockFileRepo.On("Delete", ctx, file.ID).Return(nil)
+	mockFileTagRepo.On("DeleteByFileID", ctx, file.ID).Return(nil)
+	mockFileRepo.On("CountReferences", ctx, file.Bucket, file.StorageKey, file.ID).Return(0, nil)
+	mockStorage.On("DeleteObject", ctx, file.Bucket, file.StorageKey).Return(nil)
 
 	// Act
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.Found)
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, 1, run.Cleaned)
+	mockFileRepo.AssertNotCalled(t, "UpdateStatus", ctx, unreadable.ID, domain.FileStatusFailed)
 	mockFileRepo.AssertExpectations(t)
 	mockUploadSessionRepo.AssertExpectations(t)
 }
@@ -176,7 +231,8 @@ func TestCleanupService_CleanupExpiredFiles_PartialFailure(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID1 := uuid.New()
@@ -197,7 +253,7 @@ func TestCleanupService_CleanupExpiredFiles_PartialFailure(t *testing.T) {
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockFileRepo.On("FindExpired", ctx, now).Return([]domain.FileMetadata{file1, file2}, nil)
+	mockFileRepo.On("FindExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.FileMetadata{file1, file2}, nil)
 
 	// First file fails during transaction
 	mockUploadSessionRepo.On("FindByFileID", ctx, fileID1).Return(&session1, nil)
@@ -210,14 +266,17 @@ func TestCleanupService_CleanupExpiredFiles_PartialFailure(t *testing.T) {
 	mockFileRepo.On("Delete", ctx, session2.FileID).Return(nil).Once()
 	mockFileTagRepo.On("DeleteByFileID", ctx, session2.FileID).Return(nil).Once()
 	mockUploadSessionRepo.On("UpdateStatus", ctx, session2.ID, domain.UploadSessionStatusAborted).Return(nil).Once()
-	mockStorage.On("AbortMultipartUpload", ctx, file2.StorageKey, session2.ProviderUploadID).Return(nil).Once()
+	mockStorage.On("AbortMultipartUpload", ctx, file2.Bucket, file2.StorageKey, session2.ProviderUploadID).Return(nil).Once()
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil).Once()
 
 	// Act
-	err := service.CleanupExpiredFiles(ctx, now)
+	run, err := service.CleanupExpiredFiles(ctx, now)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, 2, run.Found)
+	assert.Equal(t, 1, run.Cleaned)
+	assert.Equal(t, 1, run.Failed)
 	mockFileRepo.AssertExpectations(t)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileTagRepo.AssertExpectations(t)
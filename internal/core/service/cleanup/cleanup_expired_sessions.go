@@ -2,56 +2,120 @@ package cleanup
 
 import (
 	"context"
+	"errors"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/port"
 	"time"
-)
-
-// CleanupExpiredSessions find sessions to be cleaned and cleans all data
-func (c *cleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) error {
 
-	sessions, err := c.uow.UploadSessionRepo().FindAllExpired(ctx, now)
-	if err != nil {
-		return err
-	}
+	"github.com/google/uuid"
+)
 
-	for _, session := range sessions {
+// CleanupExpiredSessions find sessions to be cleaned and cleans all data, batch by batch until none is left or the time budget is spent.
+// Each session is claimed in its transaction, sessions completed or claimed by another run meanwhile are skipped.
+func (c *cleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	run := startRun(domain.CleanupJobExpiredSessions)
+	after := uuid.Nil
 
-		metadata, foundErr := c.uow.FileRepo().FindById(ctx, session.FileID)
-		if foundErr != nil {
-			return foundErr
+	for !c.overBudget(run) {
+		sessions, err := c.uow.UploadSessionRepo().FindAllExpired(ctx, now, after, c.cfg.CleanupBatchSize)
+		if err != nil {
+			return c.finishRun(ctx, run, err)
 		}
 
-		txErr := c.uow.Execute(ctx, func(uow port.UnitOfWork) error {
-
-			executeErr := uow.FileRepo().UpdateStatus(ctx, session.FileID, domain.FileStatusFailed)
-			if executeErr != nil {
-				return executeErr
+		for _, session := range sessions {
+			if c.overBudget(run) {
+				break
 			}
+			after = session.ID
+			run.Found++
 
-			executeErr = uow.FileRepo().Delete(ctx, session.FileID)
-
-			executeErr = uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted)
-			if executeErr != nil {
-				return executeErr
+			if session.VersionID != nil {
+				c.countSession(run, session, c.cleanupExpiredVersionSession(ctx, session, now))
+				continue
 			}
 
-			executeErr = uow.FileTagRepo().DeleteByFileID(ctx, session.FileID)
-			if executeErr != nil {
-				return executeErr
+			// one unreadable file must not stop the cleanup of the others
+			metadata, foundErr := c.uow.FileRepo().FindById(ctx, session.FileID)
+			if foundErr != nil {
+				c.countSession(run, session, foundErr)
+				continue
 			}
 
-			executeErr = c.fileStorage.AbortMultipartUpload(ctx, metadata.StorageKey, session.ProviderUploadID)
-			if executeErr != nil {
-				return executeErr
-			}
-			return nil
+			// failing the file releases the bytes it reserved in the quotas
+			txErr := c.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+				if _, claimErr := uow.UploadSessionRepo().ClaimExpired(ctx, session.ID, now); claimErr != nil {
+					return claimErr
+				}
+
+				executeErr := uow.FileRepo().UpdateStatus(ctx, session.FileID, domain.FileStatusFailed)
+				if executeErr != nil {
+					return executeErr
+				}
+
+				executeErr = uow.FileRepo().Delete(ctx, session.FileID)
+
+				executeErr = uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted)
+				if executeErr != nil {
+					return executeErr
+				}
+
+				executeErr = uow.FileTagRepo().DeleteByFileID(ctx, session.FileID)
+				if executeErr != nil {
+					return executeErr
+				}
+
+				executeErr = c.fileStorage.AbortMultipartUpload(ctx, metadata.Bucket, metadata.StorageKey, session.ProviderUploadID)
+				if executeErr != nil {
+					return executeErr
+				}
+				return nil
+
+			})
+			c.countSession(run, session, txErr)
+		}
 
-		})
-		if txErr != nil {
-			c.logger.Error("Failed to update expired sessions", "err", txErr)
+		if len(sessions) < c.cfg.CleanupBatchSize {
+			break
 		}
 	}
-	c.logger.Info("update expired sessions completed")
-	return nil
+
+	return c.finishRun(ctx, run, nil)
+}
+
+// countSession adds the outcome of the cleanup of session to run
+func (c *cleanupService) countSession(run *domain.CleanupRun, session domain.UploadSession, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		c.logger.Info("Skipped expired session claimed elsewhere", "session_id", session.ID)
+	case err != nil:
+		run.Failed++
+		c.logger.Error("Failed to update expired sessions", "session_id", session.ID, "err", err)
+	default:
+		run.Cleaned++
+	}
+}
+
+// cleanupExpiredVersionSession aborts a version upload, the file and its current version stay untouched
+func (c *cleanupService) cleanupExpiredVersionSession(ctx context.Context, session domain.UploadSession, now time.Time) error {
+	version, err := c.uow.FileVersionRepo().FindByID(ctx, *session.VersionID)
+	if err != nil {
+		return err
+	}
+
+	return c.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+		if _, err := uow.UploadSessionRepo().ClaimExpired(ctx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err := uow.FileVersionRepo().UpdateStatus(ctx, version.ID, domain.FileStatusFailed); err != nil {
+			return err
+		}
+
+		if err := uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted); err != nil {
+			return err
+		}
+
+		return c.fileStorage.AbortMultipartUpload(ctx, version.Bucket, version.StorageKey, session.ProviderUploadID)
+	})
 }
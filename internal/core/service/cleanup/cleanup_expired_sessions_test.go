@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"score-play/internal/adapters/repository"
 	"score-play/internal/adapters/storage"
+	"score-play/internal/config"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/service/cleanup"
 	"testing"
@@ -16,23 +17,27 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+var cleanupConfig = config.FileUploadConfig{CleanupBatchSize: 100}
+
 func TestCleanupService_CleanupExpiredSessions_NoExpiredSessions(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{}, nil)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{}, nil)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 0, run.Cleaned)
 	assert.NoError(t, err)
 	mockUploadSessionRepo.AssertExpectations(t)
 }
@@ -43,7 +48,8 @@ func TestCleanupService_CleanupExpiredSessions_SingleSession(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID := uuid.New()
@@ -64,19 +70,21 @@ func TestCleanupService_CleanupExpiredSessions_SingleSession(t *testing.T) {
 	mockFileRepo := mockUow.GetFileRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{session}, nil)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session}, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session.ID, now).Return(&session, nil)
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 	mockFileRepo.On("UpdateStatus", ctx, fileID, domain.FileStatusFailed).Return(nil)
 	mockFileRepo.On("Delete", ctx, fileID).Return(nil)
 	mockUploadSessionRepo.On("UpdateStatus", ctx, sessionID, domain.UploadSessionStatusAborted).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata.StorageKey, session.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata.Bucket, metadata.StorageKey, session.ProviderUploadID).Return(nil)
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 1, run.Cleaned)
 	assert.NoError(t, err)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileRepo.AssertExpectations(t)
@@ -90,7 +98,8 @@ func TestCleanupService_CleanupExpiredSessions_MultipleSessions(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID1 := uuid.New()
@@ -124,7 +133,9 @@ func TestCleanupService_CleanupExpiredSessions_MultipleSessions(t *testing.T) {
 	mockFileRepo := mockUow.GetFileRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{session1, session2}, nil)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session1, session2}, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session1.ID, now).Return(&session1, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session2.ID, now).Return(&session2, nil)
 
 	// Session 1
 	mockFileRepo.On("FindById", ctx, fileID1).Return(&metadata1, nil)
@@ -132,7 +143,7 @@ func TestCleanupService_CleanupExpiredSessions_MultipleSessions(t *testing.T) {
 	mockFileRepo.On("Delete", ctx, fileID1).Return(nil)
 	mockUploadSessionRepo.On("UpdateStatus", ctx, sessionID1, domain.UploadSessionStatusAborted).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID1).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata1.StorageKey, session1.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata1.Bucket, metadata1.StorageKey, session1.ProviderUploadID).Return(nil)
 
 	// Session 2
 	mockFileRepo.On("FindById", ctx, fileID2).Return(&metadata2, nil)
@@ -140,14 +151,15 @@ func TestCleanupService_CleanupExpiredSessions_MultipleSessions(t *testing.T) {
 	mockFileRepo.On("Delete", ctx, fileID2).Return(nil)
 	mockUploadSessionRepo.On("UpdateStatus", ctx, sessionID2, domain.UploadSessionStatusAborted).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID2).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata2.StorageKey, session2.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata2.Bucket, metadata2.StorageKey, session2.ProviderUploadID).Return(nil)
 
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil).Times(2)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 2, run.Cleaned)
 	assert.NoError(t, err)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileRepo.AssertExpectations(t)
@@ -161,18 +173,20 @@ func TestCleanupService_CleanupExpiredSessions_FindAllExpiredError(t *testing.T)
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	expectedError := errors.New("database error")
 
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{}, expectedError)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{}, expectedError)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 0, run.Cleaned)
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 	mockUploadSessionRepo.AssertExpectations(t)
@@ -184,33 +198,41 @@ func TestCleanupService_CleanupExpiredSessions_FindByIdError(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
-	fileID := uuid.New()
-	sessionID := uuid.New()
-	expectedError := errors.New("file not found")
-
-	session := domain.UploadSession{
-		ID:               sessionID,
-		FileID:           fileID,
-		ProviderUploadID: "provider-upload-id",
-	}
+	fileID1 := uuid.New()
+	fileID2 := uuid.New()
+	session1 := domain.UploadSession{ID: uuid.New(), FileID: fileID1, ProviderUploadID: "provider-upload-id-1"}
+	session2 := domain.UploadSession{ID: uuid.New(), FileID: fileID2, ProviderUploadID: "provider-upload-id-2"}
+	metadata2 := domain.FileMetadata{ID: fileID2, StorageKey: "storage-key-2"}
 
 	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
 	mockFileRepo := mockUow.GetFileRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{session}, nil)
-	mockFileRepo.On("FindById", ctx, fileID).Return(&domain.FileMetadata{}, expectedError)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session1, session2}, nil)
+	mockFileRepo.On("FindById", ctx, fileID1).Return(&domain.FileMetadata{}, errors.New("connection reset"))
+	mockFileRepo.On("FindById", ctx, fileID2).Return(&metadata2, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session2.ID, now).Return(&session2, nil)
+	mockFileRepo.On("UpdateStatus", ctx, fileID2, domain.FileStatusFailed).Return(nil)
+	mockFileRepo.On("Delete", ctx, fileID2).Return(nil)
+	mockUploadSessionRepo.On("UpdateStatus", ctx, session2.ID, domain.UploadSessionStatusAborted).Return(nil)
+	mockUow.GetFileTagRepoMock().On("DeleteByFileID", ctx, fileID2).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata2.Bucket, metadata2.StorageKey, session2.ProviderUploadID).Return(nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.Found)
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, 1, run.Cleaned)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
 }
 
 func TestCleanupService_CleanupExpiredSessions_ExecuteError(t *testing.T) {
@@ -219,7 +241,8 @@ func TestCleanupService_CleanupExpiredSessions_ExecuteError(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID := uuid.New()
@@ -241,21 +264,23 @@ func TestCleanupService_CleanupExpiredSessions_ExecuteError(t *testing.T) {
 	mockFileRepo := mockUow.GetFileRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{session}, nil)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session}, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session.ID, now).Return(&session, nil)
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 
 	mockFileRepo.On("UpdateStatus", ctx, fileID, domain.FileStatusFailed).Return(nil)
 	mockFileRepo.On("Delete", ctx, fileID).Return(expectedError) // Ici on fait échouer
 	mockUploadSessionRepo.On("UpdateStatus", ctx, sessionID, domain.UploadSessionStatusAborted).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata.StorageKey, session.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata.Bucket, metadata.StorageKey, session.ProviderUploadID).Return(nil)
 
 	mockUow.On("Execute", ctx, mock.Anything).Return(expectedError)
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 0, run.Cleaned)
 	assert.NoError(t, err)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileRepo.AssertExpectations(t)
@@ -267,7 +292,8 @@ func TestCleanupService_CleanupExpiredSessions_PartialFailure(t *testing.T) {
 	mockUow := repository.NewMockUnitOfWork()
 	mockStorage := storage.NewMockStorage()
 	logger := slog.Default()
-	service := cleanup.NewCleanupService(mockUow, mockStorage, logger)
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
 
 	now := time.Now()
 	fileID1 := uuid.New()
@@ -301,7 +327,9 @@ func TestCleanupService_CleanupExpiredSessions_PartialFailure(t *testing.T) {
 	mockFileRepo := mockUow.GetFileRepoMock()
 	mockFileTagRepo := mockUow.GetFileTagRepoMock()
 
-	mockUploadSessionRepo.On("FindAllExpired", ctx, now).Return([]domain.UploadSession{session1, session2}, nil)
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session1, session2}, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session1.ID, now).Return(&session1, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session2.ID, now).Return(&session2, nil)
 
 	mockFileRepo.On("FindById", ctx, fileID1).Return(&metadata1, nil)
 	mockFileRepo.On("UpdateStatus", ctx, fileID1, domain.FileStatusFailed).Return(errors.New("update failed")).Once()
@@ -312,16 +340,175 @@ func TestCleanupService_CleanupExpiredSessions_PartialFailure(t *testing.T) {
 	mockFileRepo.On("Delete", ctx, fileID2).Return(nil)
 	mockUploadSessionRepo.On("UpdateStatus", ctx, sessionID2, domain.UploadSessionStatusAborted).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, fileID2).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata2.StorageKey, session2.ProviderUploadID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata2.Bucket, metadata2.StorageKey, session2.ProviderUploadID).Return(nil)
 	mockUow.On("Execute", ctx, mock.Anything).Return(nil).Once()
 
 	// Act
-	err := service.CleanupExpiredSessions(ctx, now)
+	run, err := service.CleanupExpiredSessions(ctx, now)
 
 	// Assert
+	assert.Equal(t, 1, run.Cleaned)
 	assert.NoError(t, err)
 	mockUploadSessionRepo.AssertExpectations(t)
 	mockFileRepo.AssertExpectations(t)
 	mockFileTagRepo.AssertExpectations(t)
 	mockStorage.AssertExpectations(t)
 }
+
+func TestCleanupService_CleanupExpiredSessions_VersionSession(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	logger := slog.Default()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+
+	now := time.Now()
+	fileID := uuid.New()
+	versionID := uuid.New()
+
+	session := domain.UploadSession{
+		ID:               uuid.New(),
+		FileID:           fileID,
+		VersionID:        &versionID,
+		ProviderUploadID: "provider-upload-id",
+	}
+	version := &domain.FileVersion{ID: versionID, FileID: fileID, Bucket: "bucket", StorageKey: "video/next"}
+
+	mockUow.GetUploadSessionRepoMock().On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session}, nil)
+	mockUow.GetUploadSessionRepoMock().On("ClaimExpired", ctx, session.ID, now).Return(&session, nil)
+	mockUow.GetFileVersionRepoMock().On("FindByID", ctx, versionID).Return(version, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("UpdateStatus", ctx, versionID, domain.FileStatusFailed).Return(nil)
+	mockUow.GetUploadSessionRepoMock().On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, "bucket", "video/next", "provider-upload-id").Return(nil)
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, now)
+
+	// Assert
+	assert.Equal(t, 1, run.Cleaned)
+	assert.NoError(t, err)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetFileVersionRepoMock().AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestCleanupService_CleanupExpiredSessions_ClaimedElsewhere(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	logger := slog.Default()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, logger)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+
+	now := time.Now()
+	fileID := uuid.New()
+	session := domain.UploadSession{
+		ID:               uuid.New(),
+		FileID:           fileID,
+		ProviderUploadID: "provider-upload-id",
+	}
+
+	mockUploadSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockFileRepo := mockUow.GetFileRepoMock()
+
+	mockUploadSessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{session}, nil)
+	mockFileRepo.On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID}, nil)
+	mockUploadSessionRepo.On("ClaimExpired", ctx, session.ID, now).Return((*domain.UploadSession)(nil), domain.ErrSessionNotFound)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, now)
+
+	// Assert
+	assert.Equal(t, 0, run.Cleaned)
+	assert.NoError(t, err)
+	mockUploadSessionRepo.AssertExpectations(t)
+	mockFileRepo.AssertNotCalled(t, "UpdateStatus", ctx, fileID, domain.FileStatusFailed)
+	mockStorage.AssertNotCalled(t, "AbortMultipartUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCleanupService_CleanupExpiredSessions_Batches(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, config.FileUploadConfig{CleanupBatchSize: 1}, slog.Default())
+
+	now := time.Now()
+	versionID := uuid.New()
+	version := &domain.FileVersion{ID: versionID, Bucket: "bucket", StorageKey: "video/next"}
+	first := domain.UploadSession{ID: uuid.New(), VersionID: &versionID, ProviderUploadID: "first"}
+	second := domain.UploadSession{ID: uuid.New(), VersionID: &versionID, ProviderUploadID: "second"}
+
+	sessionRepo := mockUow.GetUploadSessionRepoMock()
+	sessionRepo.On("FindAllExpired", ctx, now, uuid.Nil, 1).Return([]domain.UploadSession{first}, nil)
+	sessionRepo.On("FindAllExpired", ctx, now, first.ID, 1).Return([]domain.UploadSession{second}, nil)
+	sessionRepo.On("FindAllExpired", ctx, now, second.ID, 1).Return([]domain.UploadSession{}, nil)
+	for _, session := range []domain.UploadSession{first, second} {
+		sessionRepo.On("ClaimExpired", ctx, session.ID, now).Return(&session, nil)
+		sessionRepo.On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
+		mockStorage.On("AbortMultipartUpload", ctx, version.Bucket, version.StorageKey, session.ProviderUploadID).Return(nil)
+	}
+	mockUow.GetFileVersionRepoMock().On("FindByID", ctx, versionID).Return(version, nil)
+	mockUow.GetFileVersionRepoMock().On("UpdateStatus", ctx, versionID, domain.FileStatusFailed).Return(nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.MatchedBy(func(run domain.CleanupRun) bool {
+		return run.Job == domain.CleanupJobExpiredSessions && run.Found == 2 && run.Cleaned == 2 && run.Error == nil
+	})).Return(nil)
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.Found)
+	assert.Equal(t, 2, run.Cleaned)
+	assert.False(t, run.BudgetExceeded)
+	sessionRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetCleanupRunRepoMock().AssertExpectations(t)
+}
+
+func TestCleanupService_CleanupExpiredSessions_TimeBudgetSpent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, config.FileUploadConfig{CleanupBatchSize: 1, CleanupTimeBudget: time.Nanosecond}, slog.Default())
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.MatchedBy(func(run domain.CleanupRun) bool {
+		return run.BudgetExceeded
+	})).Return(nil)
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, run.BudgetExceeded)
+	assert.Equal(t, 0, run.Found)
+	mockUow.GetUploadSessionRepoMock().AssertNotCalled(t, "FindAllExpired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUow.GetCleanupRunRepoMock().AssertExpectations(t)
+}
+
+func TestCleanupService_CleanupExpiredSessions_SaveRunError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := cleanup.NewCleanupService(mockUow, mockStorage, cleanupConfig, slog.Default())
+	now := time.Now()
+	mockUow.GetUploadSessionRepoMock().On("FindAllExpired", ctx, now, uuid.Nil, cleanupConfig.CleanupBatchSize).Return([]domain.UploadSession{}, nil)
+	mockUow.GetCleanupRunRepoMock().On("Create", ctx, mock.Anything).Return(errors.New("database error"))
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, run)
+	mockUow.GetCleanupRunRepoMock().AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package cleanup
+
+import (
+	"context"
+	"log/slog"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+)
+
+type leaderCleanupService struct {
+	next   port.CleanupService
+	lock   port.JobLock
+	logger *slog.Logger
+}
+
+// NewLeaderCleanupService runs the cleanups of next on a single instance at a time.
+// The other instances skip their run, which returns no summary.
+func NewLeaderCleanupService(next port.CleanupService, lock port.JobLock, logger *slog.Logger) port.CleanupService {
+	return &leaderCleanupService{
+		next:   next,
+		lock:   lock,
+		logger: logger,
+	}
+}
+
+func (l *leaderCleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	return l.run(ctx, domain.CleanupJobExpiredFiles, func(ctx context.Context) (*domain.CleanupRun, error) {
+		return l.next.CleanupExpiredFiles(ctx, now)
+	})
+}
+
+func (l *leaderCleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	return l.run(ctx, domain.CleanupJobExpiredSessions, func(ctx context.Context) (*domain.CleanupRun, error) {
+		return l.next.CleanupExpiredSessions(ctx, now)
+	})
+}
+
+// run runs cleanup under the lock of job
+func (l *leaderCleanupService) run(ctx context.Context, job string, cleanup func(ctx context.Context) (*domain.CleanupRun, error)) (*domain.CleanupRun, error) {
+	var run *domain.CleanupRun
+	ran, err := l.lock.TryRun(ctx, job, func(ctx context.Context) error {
+		var runErr error
+		run, runErr = cleanup(ctx)
+		return runErr
+	})
+	if err == nil && !ran {
+		l.logger.Info("cleanup skipped, another instance is running it", "job", job)
+	}
+	return run, err
+}
@@ -0,0 +1,102 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/cleanup"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCleanupService struct {
+	sessionRuns int
+	fileRuns    int
+	err         error
+}
+
+func (s *stubCleanupService) CleanupExpiredFiles(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	s.fileRuns++
+	return &domain.CleanupRun{Job: domain.CleanupJobExpiredFiles}, s.err
+}
+
+func (s *stubCleanupService) CleanupExpiredSessions(ctx context.Context, now time.Time) (*domain.CleanupRun, error) {
+	s.sessionRuns++
+	return &domain.CleanupRun{Job: domain.CleanupJobExpiredSessions, Cleaned: 3}, s.err
+}
+
+func TestLeaderCleanupService_CleanupExpiredSessions_Leader(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &stubCleanupService{}
+	lock := repository.NewMockJobLock()
+	lock.On("TryRun", ctx, domain.CleanupJobExpiredSessions).Return(true, nil)
+	service := cleanup.NewLeaderCleanupService(next, lock, slog.Default())
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, run.Cleaned)
+	assert.Equal(t, 1, next.sessionRuns)
+	lock.AssertExpectations(t)
+}
+
+func TestLeaderCleanupService_CleanupExpiredSessions_HeldElsewhere(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &stubCleanupService{}
+	lock := repository.NewMockJobLock()
+	lock.On("TryRun", ctx, domain.CleanupJobExpiredSessions).Return(false, nil)
+	service := cleanup.NewLeaderCleanupService(next, lock, slog.Default())
+
+	// Act
+	run, err := service.CleanupExpiredSessions(ctx, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+	assert.Equal(t, 0, next.sessionRuns)
+	lock.AssertExpectations(t)
+}
+
+func TestLeaderCleanupService_CleanupExpiredFiles_LockError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &stubCleanupService{}
+	lock := repository.NewMockJobLock()
+	expectedError := errors.New("database down")
+	lock.On("TryRun", ctx, domain.CleanupJobExpiredFiles).Return(false, expectedError)
+	service := cleanup.NewLeaderCleanupService(next, lock, slog.Default())
+
+	// Act
+	_, err := service.CleanupExpiredFiles(ctx, time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, 0, next.fileRuns)
+	lock.AssertExpectations(t)
+}
+
+func TestLeaderCleanupService_CleanupExpiredFiles_RunError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	expectedError := errors.New("storage down")
+	next := &stubCleanupService{err: expectedError}
+	lock := repository.NewMockJobLock()
+	lock.On("TryRun", ctx, domain.CleanupJobExpiredFiles).Return(true, nil)
+	service := cleanup.NewLeaderCleanupService(next, lock, slog.Default())
+
+	// Act
+	_, err := service.CleanupExpiredFiles(ctx, time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, 1, next.fileRuns)
+	lock.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// AbortMultipartUpload aborts the open session sessionID. The file it uploads is failed, a version leaves its file untouched.
+func (f *fileService) AbortMultipartUpload(ctx context.Context, sessionID uuid.UUID) error {
+
+	session, err := f.uow.UploadSessionRepo().FindByIDAndActive(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	fileMetadata, err := f.uploadTarget(ctx, session)
+	if err != nil {
+		return err
+	}
+	if err := f.authorize(ctx, domain.RouteAbortUpload, fileMetadata); err != nil {
+		return err
+	}
+
+	// failing the file releases the bytes it reserved in the quotas
+	return f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+		if err := uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, domain.UploadSessionStatusAborted); err != nil {
+			return err
+		}
+
+		if session.VersionID != nil {
+			if err := uow.FileVersionRepo().UpdateStatus(ctx, *session.VersionID, domain.FileStatusFailed); err != nil {
+				return err
+			}
+		} else {
+			if err := uow.FileRepo().UpdateStatus(ctx, session.FileID, domain.FileStatusFailed); err != nil {
+				return err
+			}
+			if err := uow.FileRepo().Delete(ctx, session.FileID); err != nil {
+				return err
+			}
+			if err := uow.FileTagRepo().DeleteByFileID(ctx, session.FileID); err != nil {
+				return err
+			}
+		}
+
+		return f.fileStorage.AbortMultipartUpload(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, session.ProviderUploadID)
+	})
+}
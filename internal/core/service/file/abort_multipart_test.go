@@ -0,0 +1,75 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFileService_AbortMultipartUpload_File(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New(), ProviderUploadID: "provider_123"}
+	metadata := &domain.FileMetadata{ID: session.FileID, Bucket: "bucket", StorageKey: "key"}
+	mockSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockSessionRepo.On("FindByIDAndActive", ctx, session.ID).Return(session, nil)
+	mockFileRepo.On("FindById", ctx, session.FileID).Return(metadata, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockSessionRepo.On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
+	mockFileRepo.On("UpdateStatus", ctx, session.FileID, domain.FileStatusFailed).Return(nil)
+	mockFileRepo.On("Delete", ctx, session.FileID).Return(nil)
+	mockUow.GetFileTagRepoMock().On("DeleteByFileID", ctx, session.FileID).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, "bucket", "key", "provider_123").Return(nil)
+
+	// Act
+	err := service.AbortMultipartUpload(ctx, session.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	mockSessionRepo.AssertExpectations(t)
+	mockFileRepo.AssertExpectations(t)
+	mockUow.GetFileTagRepoMock().AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestFileService_AbortMultipartUpload_Version(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	versionID := uuid.New()
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New(), VersionID: &versionID, ProviderUploadID: "provider_123"}
+	mockSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockVersionRepo := mockUow.GetFileVersionRepoMock()
+	mockSessionRepo.On("FindByIDAndActive", ctx, session.ID).Return(session, nil)
+	mockFileRepo.On("FindById", ctx, session.FileID).Return(&domain.FileMetadata{ID: session.FileID, Bucket: "bucket", StorageKey: "key"}, nil)
+	mockVersionRepo.On("FindByID", ctx, versionID).Return(&domain.FileVersion{ID: versionID, Bucket: "bucket", StorageKey: "key-v2"}, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockSessionRepo.On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
+	mockVersionRepo.On("UpdateStatus", ctx, versionID, domain.FileStatusFailed).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, "bucket", "key-v2", "provider_123").Return(nil)
+
+	// Act
+	err := service.AbortMultipartUpload(ctx, session.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	mockVersionRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+	mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,44 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"slices"
+	"strings"
+)
+
+// parseRoleOverrides turns the configured route:roles pairs into a lookup table
+func parseRoleOverrides(overrides map[string]string) map[string][]string {
+	roles := make(map[string][]string, len(overrides))
+	for route, value := range overrides {
+		roles[strings.TrimSpace(route)] = strings.Fields(value)
+	}
+	return roles
+}
+
+// ownerOf returns the subject of the authenticated principal, nil when auth is disabled
+func ownerOf(ctx context.Context) *string {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &principal.Subject
+}
+
+// authorize checks that the principal owns the file or has an override role for the route.
+// Requests without principal are allowed since authentication is optional.
+func (f *fileService) authorize(ctx context.Context, route string, file *domain.FileMetadata) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if file.OwnerID != nil && *file.OwnerID == principal.Subject {
+		return nil
+	}
+	for _, role := range slices.Concat(f.roleOverrides[domain.RouteAll], f.roleOverrides[route]) {
+		if principal.HasRole(role) {
+			return nil
+		}
+	}
+	return domain.ErrForbidden
+}
@@ -0,0 +1,219 @@
+package file_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/apikey"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var accessCfg = config.FileUploadConfig{
+	SingleUploadMaxSize: 10000,
+	SessionTTL:          time.Hour,
+	Access: config.AccessConfig{
+		RoleOverrides: map[string]string{
+			domain.RouteAll:     "admin",
+			domain.RouteGetFile: "reviewer support",
+		},
+	},
+}
+
+func withPrincipal(subject string, roles ...string) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: subject, Roles: roles})
+}
+
+func TestFileService_GetFile_Ownership(t *testing.T) {
+	owner := "user-1"
+	fileID := uuid.New()
+	metadata := &domain.FileMetadata{
+		ID:         fileID,
+		Filename:   "match.mp4",
+		StorageKey: "key",
+		Status:     domain.FileStatusCompleted,
+		OwnerID:    &owner,
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed bool
+	}{
+		{"owner", withPrincipal("user-1"), true},
+		{"other user", withPrincipal("user-2"), false},
+		{"admin override on every route", withPrincipal("user-2", "admin"), true},
+		{"reviewer override on get_file", withPrincipal("user-2", "reviewer"), true},
+		{"unknown role", withPrincipal("user-2", "viewer"), false},
+		{"auth disabled", context.Background(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockUow := repository.NewMockUnitOfWork()
+			mockStorage := storage.NewMockStorage()
+			service := file.NewFileService(mockUow, mockStorage, accessCfg)
+			expiresAt := time.Now().Add(time.Hour)
+
+			mockUow.GetFileRepoMock().On("FindById", mock.Anything, fileID).Return(metadata, nil)
+			mockUow.GetFileTagRepoMock().On("FindByFileID", mock.Anything, fileID).Return([]domain.FileTag{}, nil)
+			mockUow.GetTagRepoMock().On("FindByIDs", mock.Anything, mock.Anything).Return([]domain.Tag{}, nil)
+			mockStorage.On("GeneratePresignedURLForDownload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return("https://example.com/download", map[string]string(nil), &expiresAt, nil)
+
+			// Act
+			_, _, _, _, _, _, err := service.GetFile(tt.ctx, fileID, domain.DownloadOptions{})
+
+			// Assert
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrForbidden)
+				mockStorage.AssertNotCalled(t, "GeneratePresignedURLForDownload")
+			}
+		})
+	}
+}
+
+func TestFileService_GetFile_UnownedFileRequiresOverride(t *testing.T) {
+	// Arrange
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), accessCfg)
+	fileID := uuid.New()
+	mockUow.GetFileRepoMock().On("FindById", mock.Anything, fileID).
+		Return(&domain.FileMetadata{ID: fileID, Status: domain.FileStatusCompleted}, nil)
+
+	// Act
+	_, _, _, _, _, _, err := service.GetFile(withPrincipal("user-1"), fileID, domain.DownloadOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestFileService_GetFile_RotatedAPIKeyKeepsOwnership(t *testing.T) {
+	// Arrange
+	keyRepo := repository.NewMockAPIKeyRepository()
+	keyService := apikey.NewAPIKeyService(keyRepo, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	adminCtx := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "user-1", Scopes: []string{domain.ScopeAdmin}})
+	oldID := uuid.New()
+	old := &domain.APIKey{ID: oldID, SubjectID: oldID, Name: "camera-1", Scopes: []string{domain.ScopeRead}}
+	var rotated domain.APIKey
+	keyRepo.On("FindByID", adminCtx, oldID).Return(old, nil)
+	keyRepo.On("Create", adminCtx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		rotated = args.Get(1).(domain.APIKey)
+	}).Return(nil)
+	keyRepo.On("Expire", adminCtx, oldID, mock.Anything).Return(nil)
+	_, _, err := keyService.RotateAPIKey(adminCtx, oldID)
+	require.NoError(t, err)
+
+	owner := old.Principal().Subject
+	fileID := uuid.New()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, accessCfg)
+	expiresAt := time.Now().Add(time.Hour)
+	mockUow.GetFileRepoMock().On("FindById", mock.Anything, fileID).
+		Return(&domain.FileMetadata{ID: fileID, Status: domain.FileStatusCompleted, OwnerID: &owner}, nil)
+	mockUow.GetFileTagRepoMock().On("FindByFileID", mock.Anything, fileID).Return([]domain.FileTag{}, nil)
+	mockUow.GetTagRepoMock().On("FindByIDs", mock.Anything, mock.Anything).Return([]domain.Tag{}, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return("https://example.com/download", map[string]string(nil), &expiresAt, nil)
+
+	// Act
+	ctx := domain.ContextWithPrincipal(context.Background(), rotated.Principal())
+	_, _, _, _, _, _, err = service.GetFile(ctx, fileID, domain.DownloadOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, oldID, rotated.ID)
+	assert.Equal(t, owner, rotated.Principal().Subject)
+}
+
+func TestFileService_GetPresignedParts_Ownership(t *testing.T) {
+	owner := "user-1"
+	sessionID := uuid.New()
+	fileID := uuid.New()
+
+	t.Run("other user is forbidden and the session is not extended", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		service := file.NewFileService(mockUow, mockStorage, accessCfg)
+
+		mockUow.GetUploadSessionRepoMock().On("FindByIDAndActive", mock.Anything, sessionID).
+			Return(&domain.UploadSession{ID: sessionID, FileID: fileID}, nil)
+		mockUow.GetFileRepoMock().On("FindById", mock.Anything, fileID).
+			Return(&domain.FileMetadata{ID: fileID, OwnerID: &owner}, nil)
+
+		// Act
+		parts, err := service.GetPresignedParts(withPrincipal("user-2", "reviewer"), sessionID, []domain.UploadPart{{PartNumber: 1}})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, parts)
+		mockUow.GetUploadSessionRepoMock().AssertNotCalled(t, "UpdateExpiresAt")
+		mockStorage.AssertNotCalled(t, "GeneratePresignedURLForPart")
+	})
+}
+
+func TestFileService_RequestUploadFile_SetsOwner(t *testing.T) {
+	// Arrange
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, accessCfg)
+	ctx := withPrincipal("user-1")
+	expiresAt := time.Now().Add(time.Hour)
+	owner := "user-1"
+
+	mockStorage.On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).Return("bucket", "key")
+	mockUow.GetFileRepoMock().On("Create", ctx, mock.Anything, "match.mp4", "video/mp4", domain.FileTypeVideo, int64(100),
+		domain.FileStatusUploading, "sum", "bucket", "key", &owner).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, []string{}).Return(map[string]uuid.UUID{}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, mock.Anything).Return(0, nil)
+	mockStorage.On("GeneratePresignedURLSimpleUpload", ctx, "bucket", "key", "sum").
+		Return("https://example.com/upload", map[string]string{}, &expiresAt, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	fileID, _, _, _, err := service.RequestUploadFile(ctx, "match.mp4", "video/mp4", 100, "sum", []string{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, fileID)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_RequestUploadFile_UsesTenantInStorageKey(t *testing.T) {
+	// Arrange
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, accessCfg)
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockStorage.On("ResolveLocation", domain.FileTypeVideo, "acme", mock.Anything, mock.Anything).Return("bucket", "acme/video/key")
+	mockUow.GetFileRepoMock().On("Create", ctx, mock.Anything, "match.mp4", "video/mp4", domain.FileTypeVideo, int64(100),
+		domain.FileStatusUploading, "sum", "bucket", "acme/video/key", (*string)(nil)).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, []string{}).Return(map[string]uuid.UUID{}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, mock.Anything).Return(0, nil)
+	mockStorage.On("GeneratePresignedURLSimpleUpload", ctx, "bucket", "acme/video/key", "sum").
+		Return("https://example.com/upload", map[string]string{}, &expiresAt, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	_, _, _, _, err := service.RequestUploadFile(ctx, "match.mp4", "video/mp4", 100, "sum", []string{})
+
+	// Assert
+	require.NoError(t, err)
+	mockStorage.AssertExpectations(t)
+}
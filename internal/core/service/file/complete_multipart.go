@@ -17,12 +17,15 @@ func (f *fileService) CompleteMultipartUpload(ctx context.Context, sessionID uui
 		return nil, err
 	}
 
-	err = f.uow.UploadSessionRepo().UpdateExpiresAt(ctx, sessionID, time.Now().Add(f.fileUploadCfg.SessionTTL))
+	fileMetadata, err := f.uploadTarget(ctx, session)
 	if err != nil {
 		return nil, err
 	}
+	if err := f.authorize(ctx, domain.RouteCompleteMultipart, fileMetadata); err != nil {
+		return nil, err
+	}
 
-	fileMetadata, err := f.uow.FileRepo().FindById(ctx, session.FileID)
+	err = f.uow.UploadSessionRepo().UpdateExpiresAt(ctx, sessionID, time.Now().Add(f.fileUploadCfg.SessionTTL))
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +41,7 @@ func (f *fileService) CompleteMultipartUpload(ctx context.Context, sessionID uui
 	marker := 0
 	listed := 0
 	for {
-		parts, next, err := f.fileStorage.ListPartsPaginated(ctx, fileMetadata.StorageKey, session.ProviderUploadID, 1000, marker)
+		parts, next, err := f.fileStorage.ListPartsPaginated(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, session.ProviderUploadID, 1000, marker)
 		if err != nil {
 			return nil, err
 		}
@@ -59,7 +62,7 @@ func (f *fileService) CompleteMultipartUpload(ctx context.Context, sessionID uui
 		return nil, domain.ErrMismatchNBParts
 	}
 
-	if err := f.fileStorage.CompleteMultipartUpload(ctx, fileMetadata.StorageKey, session.ProviderUploadID, parts); err != nil {
+	if err := f.fileStorage.CompleteMultipartUpload(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, session.ProviderUploadID, parts); err != nil {
 		return nil, err
 	}
 	return &fileMetadata.ID, nil
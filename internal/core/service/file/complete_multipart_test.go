@@ -36,8 +36,8 @@ func TestFileService_CompleteMultipartUpload_Success(t *testing.T) {
 	mockUow.GetUploadSessionRepoMock().On("FindByIDAndActive", ctx, sessionID).Return(session, nil)
 	mockUow.GetUploadSessionRepoMock().On("UpdateExpiresAt", ctx, sessionID, mock.Anything).Return(nil)
 	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(fileMetadata, nil)
-	mockStorage.On("ListPartsPaginated", ctx, storageKey, uploadID, 1000, 0).Return(parts, 0, nil)
-	mockStorage.On("CompleteMultipartUpload", ctx, storageKey, uploadID, parts).Return(nil)
+	mockStorage.On("ListPartsPaginated", ctx, mock.Anything, storageKey, uploadID, 1000, 0).Return(parts, 0, nil)
+	mockStorage.On("CompleteMultipartUpload", ctx, mock.Anything, storageKey, uploadID, parts).Return(nil)
 
 	// Act
 	id, err := service.CompleteMultipartUpload(ctx, sessionID, parts)
@@ -92,7 +92,7 @@ func TestFileService_CompleteMultipartUpload_ETagMismatch(t *testing.T) {
 		Return(nil)
 	mockUow.GetFileRepoMock().On("FindById", ctx, mock.Anything).
 		Return(&domain.FileMetadata{StorageKey: storageKey}, nil)
-	mockStorage.On("ListPartsPaginated", ctx, storageKey, uploadID, 1000, 0).
+	mockStorage.On("ListPartsPaginated", ctx, mock.Anything, storageKey, uploadID, 1000, 0).
 		Return([]domain.UploadPart{{PartNumber: 1, ETag: "wrong-etag"}}, 0, nil)
 
 	// Act
@@ -119,7 +119,7 @@ func TestFileService_CompleteMultipartUpload_CountMismatch(t *testing.T) {
 	mockUow.GetUploadSessionRepoMock().On("FindByIDAndActive", ctx, sessionID).Return(&domain.UploadSession{}, nil)
 	mockUow.GetUploadSessionRepoMock().On("UpdateExpiresAt", ctx, sessionID, mock.Anything).Return(nil)
 	mockUow.GetFileRepoMock().On("FindById", ctx, mock.Anything).Return(&domain.FileMetadata{}, nil)
-	mockStorage.On("ListPartsPaginated", ctx, mock.Anything, mock.Anything, 1000, 0).
+	mockStorage.On("ListPartsPaginated", ctx, mock.Anything, mock.Anything, mock.Anything, 1000, 0).
 		Return([]domain.UploadPart{{PartNumber: 1, ETag: "etag1"}}, 0, nil)
 
 	// Act
@@ -0,0 +1,100 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CopyFile creates a new file from the current version of a source file with a server-side copy.
+// The copy has its own tags and keeps the source id for lineage. A clip copies only a byte range of the source,
+// its checksum is unknown until it is downloaded.
+func (f *fileService) CopyFile(ctx context.Context, sourceID uuid.UUID, fileName string, tags []string, clip *domain.Clip) (*uuid.UUID, error) {
+
+	source, err := f.uow.FileRepo().FindById(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.authorize(ctx, domain.RouteCopyFile, source); err != nil {
+		return nil, err
+	}
+
+	if source.Status == domain.FileStatusUploading {
+		return nil, domain.ErrFileNotReady
+	}
+	if source.Status == domain.FileStatusFailed {
+		return nil, domain.ErrFileUploadFailed
+	}
+
+	if clip != nil && (clip.Offset < 0 || clip.Length <= 0 || clip.Offset+clip.Length > source.SizeBytes) {
+		return nil, domain.ErrInvalidClip
+	}
+
+	if fileName == "" {
+		fileName = source.Filename
+	} else if _, _, err := f.validateMediaFile(fileName, source.MimeType); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidFileType, err)
+	}
+
+	if source.StorageClass != "" && source.StorageClass != domain.StorageClassStandard {
+		if err := f.ensureRestored(ctx, source); err != nil {
+			return nil, err
+		}
+	}
+
+	copied := *source
+	if clip != nil {
+		copied.SizeBytes = clip.Length
+		copied.Checksum = ""
+	}
+
+	fileID := uuid.New()
+	bucket, storageKey := f.fileStorage.ResolveLocation(domain.FileType(source.MediaType), domain.TenantOrDefault(ctx), fileID, time.Now())
+
+	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+		if err := f.checkQuota(ctx, uow, ownerOf(ctx), copied.SizeBytes, 1); err != nil {
+			return err
+		}
+
+		if err := uow.FileRepo().CreateCopy(ctx, fileID, copied, fileName, bucket, storageKey, ownerOf(ctx)); err != nil {
+			return err
+		}
+
+		if err := uow.FileVersionRepo().Create(ctx, domain.FileVersion{
+			ID:         fileID,
+			FileID:     fileID,
+			Version:    1,
+			Filename:   fileName,
+			MimeType:   copied.MimeType,
+			SizeBytes:  copied.SizeBytes,
+			Bucket:     bucket,
+			StorageKey: storageKey,
+			Checksum:   copied.Checksum,
+			Status:     domain.FileStatusCompleted,
+		}); err != nil {
+			return err
+		}
+
+		validated, err := f.validateAndGetTagIDs(ctx, uow, tags)
+		if err != nil {
+			return err
+		}
+
+		if _, err := uow.FileTagRepo().CreateMany(ctx, fileID, validated); err != nil {
+			return err
+		}
+
+		// copied last so that a database error never leaves an orphan object
+		return f.fileStorage.CopyObject(ctx, source.Bucket, source.StorageKey, bucket, storageKey, clip)
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("could not copy file: %w", txErr)
+	}
+
+	return &fileID, nil
+}
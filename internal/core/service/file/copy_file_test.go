@@ -0,0 +1,185 @@
+package file_test
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_CopyFile_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	source := &domain.FileMetadata{
+		ID:         uuid.New(),
+		Filename:   "match.mp4",
+		MimeType:   "video/mp4",
+		MediaType:  string(domain.FileTypeVideo),
+		Bucket:     "videos",
+		StorageKey: "video/source",
+		Status:     domain.FileStatusCompleted,
+	}
+	tags := []string{"highlights"}
+	tagID := uuid.New()
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+	mockStorage.
+		On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("videos", "video/copy")
+	mockUow.GetFileRepoMock().On("CreateCopy", ctx, mock.Anything, *source, "clip.mp4", "videos", "video/copy", mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, tags).Return(map[string]uuid.UUID{"highlights": tagID}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, []uuid.UUID{tagID}).Return(1, nil)
+	mockStorage.On("CopyObject", ctx, "videos", "video/source", "videos", "video/copy", (*domain.Clip)(nil)).Return(nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	fileID, err := service.CopyFile(ctx, source.ID, "clip.mp4", tags, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, source.ID, *fileID)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+	mockUow.GetFileTagRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_CopyFile_Clip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	source := &domain.FileMetadata{
+		ID:         uuid.New(),
+		Filename:   "match.mp4",
+		MimeType:   "video/mp4",
+		MediaType:  string(domain.FileTypeVideo),
+		SizeBytes:  1000,
+		Bucket:     "videos",
+		StorageKey: "video/source",
+		Checksum:   "source-checksum",
+		Status:     domain.FileStatusCompleted,
+	}
+	clip := &domain.Clip{Offset: 100, Length: 400}
+	clipped := *source
+	clipped.SizeBytes = 400
+	clipped.Checksum = ""
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+	mockStorage.
+		On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("videos", "video/clip")
+	mockUow.GetFileRepoMock().On("CreateCopy", ctx, mock.Anything, clipped, "match.mp4", "videos", "video/clip", mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("Create", ctx, mock.MatchedBy(func(v domain.FileVersion) bool {
+		return v.SizeBytes == 400 && v.Checksum == ""
+	})).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, mock.Anything).Return(map[string]uuid.UUID{"highlights": uuid.New()}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, mock.Anything).Return(1, nil)
+	mockStorage.On("CopyObject", ctx, "videos", "video/source", "videos", "video/clip", clip).Return(nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	fileID, err := service.CopyFile(ctx, source.ID, "", []string{"highlights"}, clip)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, fileID)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+	mockUow.GetFileVersionRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_CopyFile_ClipOutOfRange(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	source := &domain.FileMetadata{ID: uuid.New(), SizeBytes: 1000, Status: domain.FileStatusCompleted}
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+
+	// Act
+	_, err := service.CopyFile(ctx, source.ID, "", []string{"football"}, &domain.Clip{Offset: 900, Length: 200})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidClip)
+}
+
+func TestFileService_CopyFile_SourceNotReady(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	source := &domain.FileMetadata{ID: uuid.New(), Status: domain.FileStatusUploading}
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+
+	// Act
+	_, err := service.CopyFile(ctx, source.ID, "", []string{"football"}, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileNotReady)
+}
+
+func TestFileService_CopyFile_ExtensionMismatch(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	source := &domain.FileMetadata{ID: uuid.New(), MimeType: "video/mp4", Status: domain.FileStatusCompleted}
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+
+	// Act
+	_, err := service.CopyFile(ctx, source.ID, "clip.jpg", []string{"football"}, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidFileType)
+}
+
+func TestFileService_CopyFile_CopyFails(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	source := &domain.FileMetadata{
+		ID:        uuid.New(),
+		Filename:  "match.mp4",
+		MimeType:  "video/mp4",
+		MediaType: string(domain.FileTypeVideo),
+		Status:    domain.FileStatusCompleted,
+	}
+	copyErr := errors.New("copy failed")
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, source.ID).Return(source, nil)
+	mockStorage.On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).Return("", "video/copy")
+	mockUow.GetFileRepoMock().On("CreateCopy", ctx, mock.Anything, *source, "match.mp4", "", "video/copy", mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, mock.Anything).Return(map[string]uuid.UUID{"football": uuid.New()}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, mock.Anything).Return(1, nil)
+	mockStorage.On("CopyObject", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(copyErr)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	fileID, err := service.CopyFile(ctx, source.ID, "", []string{"football"}, nil)
+
+	// Assert
+	assert.Nil(t, fileID)
+	assert.ErrorIs(t, err, copyErr)
+}
@@ -0,0 +1,81 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// duplicateCandidates is the number of files with the same content checked for one the caller can read
+const duplicateCandidates = 10
+
+// DeduplicateUpload creates a completed file sharing the object of a completed file with the same content,
+// so that the content is not sent again. It returns nil when no such file is readable by the caller.
+func (f *fileService) DeduplicateUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+
+	_, mimeType, err := f.validateMediaFile(fileName, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidFileType, err)
+	}
+
+	candidates, err := f.uow.FileRepo().FindDuplicates(ctx, checksumSha256, sizeBytes, mimeType, ownerOf(ctx), duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// knowing the checksum of a file must not grant access to it
+	var source *domain.FileMetadata
+	for i := range candidates {
+		if f.authorize(ctx, domain.RouteGetFile, &candidates[i]) == nil {
+			source = &candidates[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, nil
+	}
+
+	fileID := uuid.New()
+
+	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+		if err := f.checkQuota(ctx, uow, ownerOf(ctx), source.SizeBytes, 1); err != nil {
+			return err
+		}
+
+		if err := uow.FileRepo().CreateShared(ctx, fileID, *source, fileName, ownerOf(ctx)); err != nil {
+			return err
+		}
+
+		if err := uow.FileVersionRepo().Create(ctx, domain.FileVersion{
+			ID:         fileID,
+			FileID:     fileID,
+			Version:    1,
+			Filename:   fileName,
+			MimeType:   source.MimeType,
+			SizeBytes:  source.SizeBytes,
+			Bucket:     source.Bucket,
+			StorageKey: source.StorageKey,
+			Checksum:   source.Checksum,
+			Status:     domain.FileStatusCompleted,
+		}); err != nil {
+			return err
+		}
+
+		validated, err := f.validateAndGetTagIDs(ctx, uow, tags)
+		if err != nil {
+			return err
+		}
+
+		_, err = uow.FileTagRepo().CreateMany(ctx, fileID, validated)
+		return err
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("could not deduplicate upload: %w", txErr)
+	}
+
+	return &fileID, nil
+}
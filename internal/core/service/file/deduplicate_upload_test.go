@@ -0,0 +1,127 @@
+package file_test
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_DeduplicateUpload_SharesObject(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	source := domain.FileMetadata{
+		ID:         uuid.New(),
+		Filename:   "match.mp4",
+		MimeType:   "video/mp4",
+		MediaType:  string(domain.FileTypeVideo),
+		SizeBytes:  1000,
+		Bucket:     "videos",
+		StorageKey: "video/source",
+		Checksum:   "sum",
+		Status:     domain.FileStatusCompleted,
+	}
+	tagID := uuid.New()
+
+	mockUow.GetFileRepoMock().On("FindDuplicates", ctx, "sum", int64(1000), "video/mp4", (*string)(nil), 10).Return([]domain.FileMetadata{source}, nil)
+	mockUow.GetFileRepoMock().On("CreateShared", ctx, mock.Anything, source, "replay.mp4", (*string)(nil)).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("Create", ctx, mock.MatchedBy(func(v domain.FileVersion) bool {
+		return v.Version == 1 && v.Bucket == "videos" && v.StorageKey == "video/source" && v.Status == domain.FileStatusCompleted
+	})).Return(nil)
+	mockUow.GetTagRepoMock().On("FindByNames", ctx, []string{"football"}).Return(map[string]uuid.UUID{"football": tagID}, nil)
+	mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, []uuid.UUID{tagID}).Return(1, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	fileID, err := service.DeduplicateUpload(ctx, "replay.mp4", "video/mp4", 1000, "sum", []string{"football"})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, fileID)
+	assert.NotEqual(t, source.ID, *fileID)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+	mockUow.GetFileVersionRepoMock().AssertExpectations(t)
+	mockStorage.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_DeduplicateUpload_NoDuplicate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	mockUow.GetFileRepoMock().On("FindDuplicates", ctx, "sum", int64(1000), "video/mp4", (*string)(nil), 10).Return([]domain.FileMetadata{}, nil)
+
+	// Act
+	fileID, err := service.DeduplicateUpload(ctx, "replay.mp4", "video/mp4", 1000, "sum", []string{"football"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, fileID)
+	mockUow.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestFileService_DeduplicateUpload_SkipsFilesOfOthers(t *testing.T) {
+	// Arrange
+	ctx := withPrincipal("user-1")
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), accessCfg)
+
+	owner := "user-2"
+	other := domain.FileMetadata{ID: uuid.New(), MimeType: "video/mp4", Status: domain.FileStatusCompleted, OwnerID: &owner}
+	mockUow.GetFileRepoMock().On("FindDuplicates", ctx, "sum", int64(1000), "video/mp4", mock.Anything, 10).Return([]domain.FileMetadata{other}, nil)
+
+	// Act
+	fileID, err := service.DeduplicateUpload(ctx, "replay.mp4", "video/mp4", 1000, "sum", []string{"football"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, fileID)
+	mockUow.GetFileRepoMock().AssertNotCalled(t, "CreateShared", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_DeduplicateUpload_InvalidType(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	// Act
+	_, err := service.DeduplicateUpload(ctx, "replay.exe", "application/octet-stream", 1000, "sum", []string{"football"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidFileType)
+	mockUow.GetFileRepoMock().AssertNotCalled(t, "FindDuplicates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_DeduplicateUpload_TransactionFails(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	source := domain.FileMetadata{ID: uuid.New(), MimeType: "video/mp4", Status: domain.FileStatusCompleted}
+	dbErr := errors.New("db error")
+	mockUow.GetFileRepoMock().On("FindDuplicates", ctx, "sum", int64(1000), "video/mp4", (*string)(nil), 10).Return([]domain.FileMetadata{source}, nil)
+	mockUow.GetFileRepoMock().On("CreateShared", ctx, mock.Anything, source, "replay.mp4", (*string)(nil)).Return(dbErr)
+	mockUow.On("Execute", ctx, mock.Anything).Return(dbErr)
+
+	// Act
+	fileID, err := service.DeduplicateUpload(ctx, "replay.mp4", "video/mp4", 1000, "sum", []string{"football"})
+
+	// Assert
+	assert.Nil(t, fileID)
+	assert.ErrorIs(t, err, dbErr)
+}
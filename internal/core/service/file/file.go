@@ -17,11 +17,12 @@ type fileService struct {
 	fileStorage   port.FileStorage
 	uow           port.UnitOfWork
 	fileUploadCfg config.FileUploadConfig
+	roleOverrides map[string][]string
 }
 
 // NewFileService creates a new file service
 func NewFileService(uow port.UnitOfWork, storage port.FileStorage, cfg config.FileUploadConfig) port.FileService {
-	return &fileService{uow: uow, fileStorage: storage, fileUploadCfg: cfg}
+	return &fileService{uow: uow, fileStorage: storage, fileUploadCfg: cfg, roleOverrides: parseRoleOverrides(cfg.Access.RoleOverrides)}
 }
 
 func (f *fileService) validateAndGetTagIDs(ctx context.Context, uow port.UnitOfWork, tags []string) ([]uuid.UUID, error) {
@@ -53,6 +54,31 @@ func (f *fileService) validateAndGetTagIDs(ctx context.Context, uow port.UnitOfW
 	return tagIDs, nil
 }
 
+// uploadTarget returns the metadata an upload session writes to. Version sessions write to the version object, not the current one.
+func (f *fileService) uploadTarget(ctx context.Context, session *domain.UploadSession) (*domain.FileMetadata, error) {
+	fileMetadata, err := f.uow.FileRepo().FindById(ctx, session.FileID)
+	if err != nil {
+		return nil, err
+	}
+	if session.VersionID == nil {
+		return fileMetadata, nil
+	}
+
+	version, err := f.uow.FileVersionRepo().FindByID(ctx, *session.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := *fileMetadata
+	target.Filename = version.Filename
+	target.MimeType = version.MimeType
+	target.SizeBytes = version.SizeBytes
+	target.Bucket = version.Bucket
+	target.StorageKey = version.StorageKey
+	target.Checksum = version.Checksum
+	return &target, nil
+}
+
 // AllowedMediaMimeTypes is a whitelist of supported media MIME types and their extensions.
 // This is deterministic and does NOT rely on OS mime databases (Docker-safe).
 var AllowedMediaMimeTypes = map[string][]string{
@@ -5,6 +5,7 @@ import (
 	"log"
 	"score-play/internal/core/domain"
 	"score-play/internal/core/port"
+	"score-play/internal/core/service/object"
 )
 
 func (f *fileService) FinalizeUpload(ctx context.Context, metadata domain.FileMetadata, uploadErr error, eventType domain.EventType) error {
@@ -37,6 +38,24 @@ func (f *fileService) FinalizeUpload(ctx context.Context, metadata domain.FileMe
 			return err
 		}
 
+		// the first validation of a file records it as its own first version
+		if fileStatus == domain.FileStatusCompleted && metadata.Status == domain.FileStatusUploading {
+			if err := uow.FileVersionRepo().Create(ctx, domain.FileVersion{
+				ID:         metadata.ID,
+				FileID:     metadata.ID,
+				Version:    1,
+				Filename:   metadata.Filename,
+				MimeType:   metadata.MimeType,
+				SizeBytes:  metadata.SizeBytes,
+				Bucket:     metadata.Bucket,
+				StorageKey: metadata.StorageKey,
+				Checksum:   metadata.Checksum,
+				Status:     domain.FileStatusCompleted,
+			}); err != nil {
+				return err
+			}
+		}
+
 		if fileStatus == domain.FileStatusFailed {
 			if err := uow.FileTagRepo().DeleteByFileID(ctx, metadata.ID); err != nil {
 				return err
@@ -47,11 +66,11 @@ func (f *fileService) FinalizeUpload(ctx context.Context, metadata domain.FileMe
 			}
 
 			if eventType == domain.EventTypeMultipartUploadComplete && session != nil {
-				if err := f.fileStorage.AbortMultipartUpload(ctx, metadata.StorageKey, session.ProviderUploadID); err != nil {
+				if err := f.fileStorage.AbortMultipartUpload(ctx, metadata.Bucket, metadata.StorageKey, session.ProviderUploadID); err != nil {
 					return err
 				}
 			} else if eventType == domain.EventTypeSimpleUploadComplete {
-				if err := f.fileStorage.DeleteObject(ctx, metadata.StorageKey); err != nil {
+				if err := object.DeleteUnreferenced(ctx, uow, f.fileStorage, metadata.ID, metadata.Bucket, metadata.StorageKey); err != nil {
 					return err
 				}
 			}
@@ -65,7 +65,7 @@ func TestCleanupService_FinalizeUpload_MultipartFailed(t *testing.T) {
 	mockFileRepo.On("UpdateStatus", ctx, metadata.ID, domain.FileStatusFailed).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, metadata.ID).Return(nil)
 	mockFileRepo.On("Delete", ctx, metadata.ID).Return(nil)
-	mockStorage.On("AbortMultipartUpload", ctx, metadata.StorageKey, "provider-id").Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, metadata.Bucket, metadata.StorageKey, "provider-id").Return(nil)
 
 	// Act
 	err := service.FinalizeUpload(ctx, metadata, uploadErr, eventType)
@@ -93,7 +93,8 @@ func TestCleanupService_FinalizeUpload_SimpleFailed(t *testing.T) {
 	mockFileRepo.On("UpdateStatus", ctx, metadata.ID, domain.FileStatusFailed).Return(nil)
 	mockFileTagRepo.On("DeleteByFileID", ctx, metadata.ID).Return(nil)
 	mockFileRepo.On("Delete", ctx, metadata.ID).Return(nil)
-	mockStorage.On("DeleteObject", ctx, metadata.StorageKey).Return(nil)
+	mockFileRepo.On("CountReferences", ctx, metadata.Bucket, metadata.StorageKey, metadata.ID).Return(0, nil)
+	mockStorage.On("DeleteObject", ctx, metadata.Bucket, metadata.StorageKey).Return(nil)
 
 	// Act
 	err := service.FinalizeUpload(ctx, metadata, uploadErr, domain.EventTypeSimpleUploadComplete)
@@ -103,3 +104,68 @@ func TestCleanupService_FinalizeUpload_SimpleFailed(t *testing.T) {
 	mockFileRepo.AssertExpectations(t)
 	mockStorage.AssertExpectations(t)
 }
+
+func TestCleanupService_FinalizeUpload_SimpleFailedKeepsSharedObject(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, config.FileUploadConfig{})
+
+	metadata := domain.FileMetadata{ID: uuid.New(), StorageKey: "key"}
+	uploadErr := errors.New("network error")
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileTagRepo := mockUow.GetFileTagRepoMock()
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockFileRepo.On("UpdateStatus", ctx, metadata.ID, domain.FileStatusFailed).Return(nil)
+	mockFileTagRepo.On("DeleteByFileID", ctx, metadata.ID).Return(nil)
+	mockFileRepo.On("Delete", ctx, metadata.ID).Return(nil)
+	mockFileRepo.On("CountReferences", ctx, metadata.Bucket, metadata.StorageKey, metadata.ID).Return(1, nil)
+
+	// Act
+	err := service.FinalizeUpload(ctx, metadata, uploadErr, domain.EventTypeSimpleUploadComplete)
+
+	// Assert
+	assert.NoError(t, err)
+	mockFileRepo.AssertExpectations(t)
+	mockStorage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCleanupService_FinalizeUpload_RecordsFirstVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, config.FileUploadConfig{})
+
+	metadata := domain.FileMetadata{
+		ID:         uuid.New(),
+		Filename:   "video.mp4",
+		MimeType:   "video/mp4",
+		SizeBytes:  1000,
+		Bucket:     "bucket",
+		StorageKey: "video/key",
+		Checksum:   "sha",
+		Status:     domain.FileStatusUploading,
+	}
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileVersionRepo := mockUow.GetFileVersionRepoMock()
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockFileRepo.On("UpdateStatus", ctx, metadata.ID, domain.FileStatusCompleted).Return(nil)
+	mockFileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v domain.FileVersion) bool {
+		return v.ID == metadata.ID && v.FileID == metadata.ID && v.Version == 1 &&
+			v.StorageKey == metadata.StorageKey && v.Status == domain.FileStatusCompleted
+	})).Return(nil)
+
+	// Act
+	err := service.FinalizeUpload(ctx, metadata, nil, domain.EventTypeSimpleUploadComplete)
+
+	// Assert
+	assert.NoError(t, err)
+	mockFileRepo.AssertExpectations(t)
+	mockFileVersionRepo.AssertExpectations(t)
+}
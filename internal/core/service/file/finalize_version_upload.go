@@ -0,0 +1,61 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"score-play/internal/core/service/object"
+)
+
+// FinalizeVersionUpload records the validation result of a version upload and makes a valid version the current one
+func (f *fileService) FinalizeVersionUpload(ctx context.Context, version domain.FileVersion, uploadErr error, eventType domain.EventType) error {
+	sessionStatus := domain.UploadSessionStatusCompleted
+	versionStatus := domain.FileStatusCompleted
+	if uploadErr != nil {
+		sessionStatus = domain.UploadSessionStatusAborted
+		versionStatus = domain.FileStatusFailed
+	}
+
+	var session *domain.UploadSession
+	var err error
+
+	if eventType == domain.EventTypeMultipartUploadComplete {
+		session, err = f.uow.UploadSessionRepo().FindByVersionID(ctx, version.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+		if session != nil {
+			if err := uow.UploadSessionRepo().UpdateStatus(ctx, session.ID, sessionStatus); err != nil {
+				return err
+			}
+		}
+
+		if err := uow.FileVersionRepo().UpdateStatus(ctx, version.ID, versionStatus); err != nil {
+			return err
+		}
+
+		if versionStatus == domain.FileStatusFailed {
+			if session != nil {
+				return f.fileStorage.AbortMultipartUpload(ctx, version.Bucket, version.StorageKey, session.ProviderUploadID)
+			}
+			if eventType == domain.EventTypeSimpleUploadComplete {
+				return object.DeleteUnreferenced(ctx, uow, f.fileStorage, version.FileID, version.Bucket, version.StorageKey)
+			}
+			return nil
+		}
+
+		metadata, err := uow.FileRepo().FindById(ctx, version.FileID)
+		if err != nil {
+			return err
+		}
+
+		// versions can be validated out of order, an older one never replaces a newer current version
+		if version.Version <= metadata.CurrentVersion {
+			return nil
+		}
+		return uow.FileRepo().SetCurrentVersion(ctx, version.FileID, version)
+	})
+}
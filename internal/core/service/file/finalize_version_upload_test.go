@@ -0,0 +1,86 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFileService_FinalizeVersionUpload_SetsCurrentVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), config.FileUploadConfig{})
+
+	fileID := uuid.New()
+	version := domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2}
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("UpdateStatus", ctx, version.ID, domain.FileStatusCompleted).Return(nil)
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID, CurrentVersion: 1}, nil)
+	mockUow.GetFileRepoMock().On("SetCurrentVersion", ctx, fileID, version).Return(nil)
+
+	// Act
+	err := service.FinalizeVersionUpload(ctx, version, nil, domain.EventTypeSimpleUploadComplete)
+
+	// Assert
+	assert.NoError(t, err)
+	mockUow.GetFileVersionRepoMock().AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_FinalizeVersionUpload_OlderVersionKeepsCurrent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), config.FileUploadConfig{})
+
+	fileID := uuid.New()
+	version := domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2}
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("UpdateStatus", ctx, version.ID, domain.FileStatusCompleted).Return(nil)
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID, CurrentVersion: 3}, nil)
+
+	// Act
+	err := service.FinalizeVersionUpload(ctx, version, nil, domain.EventTypeSimpleUploadComplete)
+
+	// Assert
+	assert.NoError(t, err)
+	mockUow.GetFileRepoMock().AssertNotCalled(t, "SetCurrentVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_FinalizeVersionUpload_MultipartFailed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, config.FileUploadConfig{})
+
+	fileID := uuid.New()
+	version := domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2, Bucket: "bucket", StorageKey: "video/version-key"}
+	session := &domain.UploadSession{ID: uuid.New(), ProviderUploadID: "provider-id"}
+
+	mockUow.GetUploadSessionRepoMock().On("FindByVersionID", ctx, version.ID).Return(session, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetUploadSessionRepoMock().On("UpdateStatus", ctx, session.ID, domain.UploadSessionStatusAborted).Return(nil)
+	mockUow.GetFileVersionRepoMock().On("UpdateStatus", ctx, version.ID, domain.FileStatusFailed).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, "bucket", "video/version-key", "provider-id").Return(nil)
+
+	// Act
+	err := service.FinalizeVersionUpload(ctx, version, domain.ErrMismatchChecksum, domain.EventTypeMultipartUploadComplete)
+
+	// Assert
+	assert.NoError(t, err)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetUploadSessionRepoMock().AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
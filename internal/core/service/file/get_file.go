@@ -9,23 +9,32 @@ import (
 	"github.com/google/uuid"
 )
 
-func (f *fileService) GetFile(ctx context.Context, fileID uuid.UUID) (*string, *string, []domain.Tag, *time.Time, error) {
+func (f *fileService) GetFile(ctx context.Context, fileID uuid.UUID, opts domain.DownloadOptions) (*string, *string, []domain.Tag, map[string]string, *time.Time, string, error) {
 
 	metadata, err := f.uow.FileRepo().FindById(ctx, fileID)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, "", err
+	}
+	if err := f.authorize(ctx, domain.RouteGetFile, metadata); err != nil {
+		return nil, nil, nil, nil, nil, "", err
 	}
 
 	if metadata.Status == domain.FileStatusUploading {
-		return nil, nil, nil, nil, domain.ErrFileNotReady
+		return nil, nil, nil, nil, nil, "", domain.ErrFileNotReady
 	}
 	if metadata.Status == domain.FileStatusFailed {
-		return nil, nil, nil, nil, domain.ErrFileUploadFailed
+		return nil, nil, nil, nil, nil, "", domain.ErrFileUploadFailed
+	}
+
+	if metadata.StorageClass != "" && metadata.StorageClass != domain.StorageClassStandard {
+		if err := f.ensureRestored(ctx, metadata); err != nil {
+			return nil, nil, nil, nil, nil, "", err
+		}
 	}
 
 	fileTags, err := f.uow.FileTagRepo().FindByFileID(ctx, metadata.ID)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, "", err
 	}
 
 	var tagsToFind []uuid.UUID
@@ -35,17 +44,39 @@ func (f *fileService) GetFile(ctx context.Context, fileID uuid.UUID) (*string, *
 
 	tags, err := f.uow.TagRepo().FindByIDs(ctx, tagsToFind)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, "", err
 	}
 
-	download, expiresAt, err := f.fileStorage.GeneratePresignedURLForDownload(ctx, metadata.StorageKey)
+	opts.Filename = metadata.Filename
+	opts.ContentType = metadata.MimeType
+	download, headers, expiresAt, err := f.fileStorage.GeneratePresignedURLForDownload(ctx, metadata.Bucket, metadata.StorageKey, opts)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, "", err
 	}
 	if download == "" {
-		return nil, nil, nil, nil, errors.New("no download url found")
+		return nil, nil, nil, nil, nil, "", errors.New("no download url found")
+	}
+
+	return &download, &metadata.Filename, tags, headers, expiresAt, metadata.Checksum, nil
+
+}
+
+// ensureRestored checks that an archived object has a restored copy available, and requests one if needed
+func (f *fileService) ensureRestored(ctx context.Context, metadata *domain.FileMetadata) error {
+	info, err := f.fileStorage.GetObjectInfo(ctx, metadata.Bucket, metadata.StorageKey)
+	if err != nil {
+		return err
 	}
 
-	return &download, &metadata.Filename, tags, expiresAt, nil
+	if info.Restore != nil && !info.Restore.OngoingRestore {
+		return nil
+	}
+
+	if info.Restore == nil {
+		if err := f.fileStorage.RestoreObject(ctx, metadata.Bucket, metadata.StorageKey); err != nil {
+			return err
+		}
+	}
 
+	return domain.ErrFileRestoring
 }
@@ -0,0 +1,31 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// GetFileImport returns the import of the file fileID and the status of the file.
+// Failed files are deleted, their status is then reported as failed.
+func (f *fileService) GetFileImport(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, domain.FileStatus, error) {
+
+	fileImport, err := f.uow.FileImportRepo().FindByFileID(ctx, fileID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := f.authorize(ctx, domain.RouteGetFile, &domain.FileMetadata{OwnerID: fileImport.OwnerID}); err != nil {
+		return nil, "", err
+	}
+
+	fileMetadata, err := f.uow.FileRepo().FindById(ctx, fileID)
+	if errors.Is(err, domain.ErrFileMetadataNotFound) {
+		return fileImport, domain.FileStatusFailed, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return fileImport, fileMetadata.Status, nil
+}
@@ -0,0 +1,50 @@
+package file_test
+
+import (
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileService_GetFileImport(t *testing.T) {
+	owner := "user-1"
+	tests := []struct {
+		name       string
+		caller     string
+		file       *domain.FileMetadata
+		fileErr    error
+		wantStatus domain.FileStatus
+		wantErr    error
+	}{
+		{"uploading", "user-1", &domain.FileMetadata{Status: domain.FileStatusUploading}, nil, domain.FileStatusUploading, nil},
+		{"failed file deleted", "user-1", nil, domain.ErrFileMetadataNotFound, domain.FileStatusFailed, nil},
+		{"not the owner", "user-2", nil, nil, "", domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := withPrincipal(tt.caller)
+			mockUow := repository.NewMockUnitOfWork()
+			service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+			fileImport := &domain.FileImport{FileID: uuid.New(), OwnerID: &owner, Status: domain.ImportStatusRunning, BytesReceived: 10}
+			mockUow.GetFileImportRepoMock().On("FindByFileID", ctx, fileImport.FileID).Return(fileImport, nil)
+			mockUow.GetFileRepoMock().On("FindById", ctx, fileImport.FileID).Return(tt.file, tt.fileErr)
+
+			// Act
+			found, status, err := service.GetFileImport(ctx, fileImport.FileID)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantErr == nil {
+				assert.Equal(t, fileImport, found)
+			}
+		})
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFileService_GetFile_Success(t *testing.T) {
@@ -33,6 +35,7 @@ func TestFileService_GetFile_Success(t *testing.T) {
 		Filename:   "test-file.pdf",
 		StorageKey: "storage-key",
 		Status:     domain.FileStatusCompleted,
+		Checksum:   "checksum",
 	}
 
 	fileTags := []domain.FileTag{
@@ -55,10 +58,10 @@ func TestFileService_GetFile_Success(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 	mockFileTagRepo.On("FindByFileID", ctx, fileID).Return(fileTags, nil)
 	mockTagRepo.On("FindByIDs", ctx, []uuid.UUID{tagID1, tagID2}).Return(tags, nil)
-	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.StorageKey).Return(downloadURL, &expiresAt, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.Bucket, metadata.StorageKey, mock.Anything).Return(downloadURL, map[string]string(nil), &expiresAt, nil)
 
 	// Act
-	download, filename, resultTags, resultExpiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, resultTags, _, resultExpiresAt, checksum, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -69,6 +72,7 @@ func TestFileService_GetFile_Success(t *testing.T) {
 	assert.Equal(t, tags, resultTags)
 	assert.NotNil(t, resultExpiresAt)
 	assert.Equal(t, expiresAt, *resultExpiresAt)
+	assert.Equal(t, "checksum", checksum)
 	mockFileRepo.AssertExpectations(t)
 	mockFileTagRepo.AssertExpectations(t)
 	mockTagRepo.AssertExpectations(t)
@@ -90,7 +94,7 @@ func TestFileService_GetFile_FileNotFound(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&domain.FileMetadata{}, expectedError)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -123,7 +127,7 @@ func TestFileService_GetFile_FileUploading(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -156,7 +160,7 @@ func TestFileService_GetFile_FileFailed(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -193,7 +197,7 @@ func TestFileService_GetFile_FindFileTagsError(t *testing.T) {
 	mockFileTagRepo.On("FindByFileID", ctx, fileID).Return([]domain.FileTag{}, expectedError)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -238,7 +242,7 @@ func TestFileService_GetFile_FindTagsByIDsError(t *testing.T) {
 	mockTagRepo.On("FindByIDs", ctx, []uuid.UUID{tagID}).Return([]domain.Tag{}, expectedError)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -277,10 +281,10 @@ func TestFileService_GetFile_GeneratePresignedURLError(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 	mockFileTagRepo.On("FindByFileID", ctx, fileID).Return([]domain.FileTag{}, nil)
 	mockTagRepo.On("FindByIDs", ctx, mock.Anything).Return([]domain.Tag{}, nil)
-	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.StorageKey).Return("", &time.Time{}, expectedError)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.Bucket, metadata.StorageKey, mock.Anything).Return("", map[string]string(nil), &time.Time{}, expectedError)
 
 	// Act
-	download, filename, tags, expiresAt, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -321,10 +325,10 @@ func TestFileService_GetFile_EmptyDownloadURL(t *testing.T) {
 	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
 	mockFileTagRepo.On("FindByFileID", ctx, fileID).Return([]domain.FileTag{}, nil)
 	mockTagRepo.On("FindByIDs", ctx, mock.Anything).Return([]domain.Tag{}, nil)
-	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.StorageKey).Return("", &expiresAt, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.Bucket, metadata.StorageKey, mock.Anything).Return("", map[string]string(nil), &expiresAt, nil)
 
 	// Act
-	download, filename, tags, resTime, err := service.GetFile(ctx, fileID)
+	download, filename, tags, _, resTime, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -337,3 +341,155 @@ func TestFileService_GetFile_EmptyDownloadURL(t *testing.T) {
 	mockTagRepo.AssertExpectations(t)
 	mockStorage.AssertExpectations(t)
 }
+
+func TestFileService_GetFile_ArchivedRequestsRestore(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	cfg := config.FileUploadConfig{}
+	service := file.NewFileService(mockUow, mockStorage, cfg)
+
+	fileID := uuid.New()
+
+	metadata := domain.FileMetadata{
+		ID:           fileID,
+		Filename:     "test-file.mp4",
+		StorageKey:   "storage-key",
+		Status:       domain.FileStatusCompleted,
+		StorageClass: "GLACIER",
+	}
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
+	mockStorage.On("GetObjectInfo", ctx, metadata.Bucket, metadata.StorageKey).Return(&minio.ObjectInfo{StorageClass: "GLACIER"}, nil)
+	mockStorage.On("RestoreObject", ctx, metadata.Bucket, metadata.StorageKey).Return(nil)
+
+	// Act
+	download, filename, tags, _, expiresAt, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileRestoring)
+	assert.Nil(t, download)
+	assert.Nil(t, filename)
+	assert.Nil(t, tags)
+	assert.Nil(t, expiresAt)
+	mockFileRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestFileService_GetFile_ArchivedRestoreOngoing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	cfg := config.FileUploadConfig{}
+	service := file.NewFileService(mockUow, mockStorage, cfg)
+
+	fileID := uuid.New()
+
+	metadata := domain.FileMetadata{
+		ID:           fileID,
+		StorageKey:   "storage-key",
+		Status:       domain.FileStatusCompleted,
+		StorageClass: "GLACIER",
+	}
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
+	mockStorage.On("GetObjectInfo", ctx, metadata.Bucket, metadata.StorageKey).
+		Return(&minio.ObjectInfo{StorageClass: "GLACIER", Restore: &minio.RestoreInfo{OngoingRestore: true}}, nil)
+
+	// Act
+	_, _, _, _, _, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileRestoring)
+	mockStorage.AssertNotCalled(t, "RestoreObject", mock.Anything, mock.Anything)
+	mockFileRepo.AssertExpectations(t)
+}
+
+func TestFileService_GetFile_ArchivedRestored(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	cfg := config.FileUploadConfig{}
+	service := file.NewFileService(mockUow, mockStorage, cfg)
+
+	fileID := uuid.New()
+
+	metadata := domain.FileMetadata{
+		ID:           fileID,
+		Filename:     "test-file.mp4",
+		StorageKey:   "storage-key",
+		Status:       domain.FileStatusCompleted,
+		StorageClass: "GLACIER",
+	}
+	downloadURL := "https://example.com/download"
+	expiresAt := time.Now().Add(1 * time.Hour)
+
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileTagRepo := mockUow.GetFileTagRepoMock()
+	mockTagRepo := mockUow.GetTagRepoMock()
+
+	mockFileRepo.On("FindById", ctx, fileID).Return(&metadata, nil)
+	mockStorage.On("GetObjectInfo", ctx, metadata.Bucket, metadata.StorageKey).
+		Return(&minio.ObjectInfo{StorageClass: "GLACIER", Restore: &minio.RestoreInfo{ExpiryTime: expiresAt}}, nil)
+	mockFileTagRepo.On("FindByFileID", ctx, fileID).Return([]domain.FileTag{}, nil)
+	mockTagRepo.On("FindByIDs", ctx, mock.Anything).Return([]domain.Tag{}, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, metadata.Bucket, metadata.StorageKey, mock.Anything).Return(downloadURL, map[string]string(nil), &expiresAt, nil)
+
+	// Act
+	download, _, _, _, _, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, downloadURL, *download)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestFileService_GetFile_DownloadOptions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, config.FileUploadConfig{})
+
+	fileID := uuid.New()
+	metadata := domain.FileMetadata{
+		ID:         fileID,
+		Filename:   "match.mp4",
+		MimeType:   "video/mp4",
+		Bucket:     "videos",
+		StorageKey: "storage-key",
+		Status:     domain.FileStatusCompleted,
+	}
+	downloadURL := "https://example.com/download"
+	expiresAt := time.Now().Add(1 * time.Hour)
+	headers := map[string]string{"Range": "bytes=0-99"}
+	expectedOpts := domain.DownloadOptions{
+		Disposition: domain.DispositionAttachment,
+		Filename:    "match.mp4",
+		ContentType: "video/mp4",
+		TTL:         time.Minute,
+		Range:       "bytes=0-99",
+	}
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&metadata, nil)
+	mockUow.GetFileTagRepoMock().On("FindByFileID", ctx, fileID).Return([]domain.FileTag{}, nil)
+	mockUow.GetTagRepoMock().On("FindByIDs", ctx, mock.Anything).Return([]domain.Tag{}, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, "videos", "storage-key", expectedOpts).Return(downloadURL, headers, &expiresAt, nil)
+
+	// Act
+	_, _, _, resultHeaders, _, _, err := service.GetFile(ctx, fileID, domain.DownloadOptions{
+		Disposition: domain.DispositionAttachment,
+		TTL:         time.Minute,
+		Range:       "bytes=0-99",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, headers, resultHeaders)
+	mockStorage.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetFileVersion returns a download url for a specific version of a file
+func (f *fileService) GetFileVersion(ctx context.Context, fileID uuid.UUID, versionNumber int, opts domain.DownloadOptions) (*string, *string, map[string]string, *time.Time, error) {
+
+	metadata, err := f.uow.FileRepo().FindById(ctx, fileID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := f.authorize(ctx, domain.RouteGetVersion, metadata); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	version, err := f.uow.FileVersionRepo().FindByFileIDAndVersion(ctx, fileID, versionNumber)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if version.Status == domain.FileStatusUploading {
+		return nil, nil, nil, nil, domain.ErrFileNotReady
+	}
+	if version.Status == domain.FileStatusFailed {
+		return nil, nil, nil, nil, domain.ErrFileUploadFailed
+	}
+
+	// only the current version tracks its storage class
+	if version.Version == metadata.CurrentVersion && metadata.StorageClass != "" && metadata.StorageClass != domain.StorageClassStandard {
+		if err := f.ensureRestored(ctx, metadata); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	opts.Filename = version.Filename
+	opts.ContentType = version.MimeType
+	download, headers, expiresAt, err := f.fileStorage.GeneratePresignedURLForDownload(ctx, version.Bucket, version.StorageKey, opts)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if download == "" {
+		return nil, nil, nil, nil, errors.New("no download url found")
+	}
+
+	return &download, &version.Filename, headers, expiresAt, nil
+}
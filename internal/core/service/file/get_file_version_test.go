@@ -0,0 +1,98 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_GetFileVersion_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	fileID := uuid.New()
+	version := &domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 1, Filename: "old.mp4", Bucket: "bucket", StorageKey: "video/old", Status: domain.FileStatusCompleted}
+	downloadURL := "https://example.com/old.mp4"
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID, CurrentVersion: 2}, nil)
+	mockUow.GetFileVersionRepoMock().On("FindByFileIDAndVersion", ctx, fileID, 1).Return(version, nil)
+	mockStorage.On("GeneratePresignedURLForDownload", ctx, "bucket", "video/old", mock.Anything).Return(downloadURL, map[string]string(nil), &expiresAt, nil)
+
+	// Act
+	url, filename, _, resultExpiresAt, err := service.GetFileVersion(ctx, fileID, 1, domain.DownloadOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, downloadURL, *url)
+	assert.Equal(t, "old.mp4", *filename)
+	assert.Equal(t, expiresAt, *resultExpiresAt)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestFileService_GetFileVersion_NotReady(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	version := &domain.FileVersion{ID: uuid.New(), FileID: fileID, Version: 2, Status: domain.FileStatusUploading}
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID, CurrentVersion: 1}, nil)
+	mockUow.GetFileVersionRepoMock().On("FindByFileIDAndVersion", ctx, fileID, 2).Return(version, nil)
+
+	// Act
+	_, _, _, _, err := service.GetFileVersion(ctx, fileID, 2, domain.DownloadOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileNotReady)
+}
+
+func TestFileService_ListFileVersions_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	versions := []domain.FileVersion{{FileID: fileID, Version: 2}, {FileID: fileID, Version: 1}}
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(&domain.FileMetadata{ID: fileID}, nil)
+	mockUow.GetFileVersionRepoMock().On("ListByFileID", ctx, fileID).Return(versions, nil)
+
+	// Act
+	result, err := service.ListFileVersions(ctx, fileID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, versions, result)
+}
+
+func TestFileService_ListFileVersions_FileNotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return((*domain.FileMetadata)(nil), domain.ErrFileMetadataNotFound)
+
+	// Act
+	_, err := service.ListFileVersions(ctx, fileID)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileMetadataNotFound)
+}
@@ -18,19 +18,22 @@ func (f *fileService) GetPresignedParts(ctx context.Context, sessionID uuid.UUID
 		return nil, err
 	}
 
-	err = f.uow.UploadSessionRepo().UpdateExpiresAt(ctx, sessionID, time.Now().Add(f.fileUploadCfg.SessionTTL))
+	fileMetadata, err := f.uploadTarget(ctx, session)
 	if err != nil {
 		return nil, err
 	}
+	if err := f.authorize(ctx, domain.RouteSignParts, fileMetadata); err != nil {
+		return nil, err
+	}
 
-	fileMetadata, err := f.uow.FileRepo().FindById(ctx, session.FileID)
+	err = f.uow.UploadSessionRepo().UpdateExpiresAt(ctx, sessionID, time.Now().Add(f.fileUploadCfg.SessionTTL))
 	if err != nil {
 		return nil, err
 	}
 
 	//TODO routines
 	for _, part := range parts {
-		presignedPartURL, headers, expiresAt, err := f.fileStorage.GeneratePresignedURLForPart(ctx, fileMetadata.StorageKey, part.PartNumber, session.ProviderUploadID, fileMetadata.MimeType, part.ContentLength, part.ChecksumSHA256)
+		presignedPartURL, headers, expiresAt, err := f.fileStorage.GeneratePresignedURLForPart(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, part.PartNumber, session.ProviderUploadID, fileMetadata.MimeType, part.ContentLength, part.ChecksumSHA256)
 		if err != nil {
 			return nil, err
 		}
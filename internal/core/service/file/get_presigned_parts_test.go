@@ -115,6 +115,7 @@ func TestFileService_GetPresignedParts_PresignedURLGenerationFails(t *testing.T)
 	mockStorage.
 		On("GeneratePresignedURLForPart",
 			ctx,
+			fileMetadata.Bucket,
 			fileMetadata.StorageKey,
 			1,
 			session.ProviderUploadID,
@@ -157,6 +158,10 @@ func TestFileService_GetPresignedParts_UpdateExpiresAtFails(t *testing.T) {
 		On("FindByIDAndActive", ctx, sessionID).
 		Return(session, nil)
 
+	mockUow.GetFileRepoMock().
+		On("FindById", ctx, fileID).
+		Return(&domain.FileMetadata{ID: fileID}, nil)
+
 	mockUow.GetUploadSessionRepoMock().
 		On("UpdateExpiresAt", ctx, sessionID, mock.Anything).
 		Return(updateErr)
@@ -257,6 +262,7 @@ func TestFileService_GetPresignedParts_LargeNumberOfParts(t *testing.T) {
 		mockStorage.
 			On("GeneratePresignedURLForPart",
 				ctx,
+				fileMetadata.Bucket,
 				fileMetadata.StorageKey,
 				i+1,
 				session.ProviderUploadID,
@@ -279,3 +285,67 @@ func TestFileService_GetPresignedParts_LargeNumberOfParts(t *testing.T) {
 	mockUow.AssertExpectations(t)
 	mockStorage.AssertExpectations(t)
 }
+
+func TestFileService_GetPresignedParts_VersionSession(t *testing.T) {
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	sessionID := uuid.New()
+	fileID := uuid.New()
+	versionID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+
+	session := &domain.UploadSession{
+		ID:               sessionID,
+		FileID:           fileID,
+		VersionID:        &versionID,
+		ProviderUploadID: "upload123",
+	}
+
+	fileMetadata := &domain.FileMetadata{
+		ID:         fileID,
+		Bucket:     "bucket",
+		StorageKey: "video/current",
+		MimeType:   "video/mp4",
+	}
+
+	version := &domain.FileVersion{
+		ID:         versionID,
+		FileID:     fileID,
+		Bucket:     "bucket",
+		StorageKey: "video/next",
+		MimeType:   "video/webm",
+	}
+
+	parts := []domain.UploadPart{
+		{PartNumber: 1, ContentLength: 1000, ChecksumSHA256: "checksum"},
+	}
+
+	mockUow.GetUploadSessionRepoMock().
+		On("FindByIDAndActive", ctx, sessionID).
+		Return(session, nil)
+
+	mockUow.GetUploadSessionRepoMock().
+		On("UpdateExpiresAt", ctx, sessionID, mock.Anything).
+		Return(nil)
+
+	mockUow.GetFileRepoMock().
+		On("FindById", ctx, fileID).
+		Return(fileMetadata, nil)
+
+	mockUow.GetFileVersionRepoMock().
+		On("FindByID", ctx, versionID).
+		Return(version, nil)
+
+	mockStorage.
+		On("GeneratePresignedURLForPart", ctx, "bucket", "video/next", 1, "upload123", "video/webm", int64(1000), "checksum").
+		Return("https://example.com/part1", map[string]string{}, &expiresAt, nil)
+
+	result, err := service.GetPresignedParts(ctx, sessionID, parts)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	mockStorage.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetUploadSession returns the open session sessionID and the file it uploads to, and refreshes its ttl
+func (f *fileService) GetUploadSession(ctx context.Context, sessionID uuid.UUID) (*domain.UploadSession, *domain.FileMetadata, error) {
+
+	session, err := f.uow.UploadSessionRepo().FindByIDAndActive(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileMetadata, err := f.uploadTarget(ctx, session)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := f.authorize(ctx, domain.RouteResumeUpload, fileMetadata); err != nil {
+		return nil, nil, err
+	}
+
+	err = f.uow.UploadSessionRepo().UpdateExpiresAt(ctx, sessionID, time.Now().Add(f.fileUploadCfg.SessionTTL))
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, fileMetadata, nil
+}
@@ -0,0 +1,57 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFileService_GetUploadSession_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New(), UploadOffset: 42}
+	metadata := &domain.FileMetadata{ID: session.FileID, Bucket: "bucket", StorageKey: "key", SizeBytes: 100}
+	mockUow.GetUploadSessionRepoMock().On("FindByIDAndActive", ctx, session.ID).Return(session, nil)
+	mockUow.GetFileRepoMock().On("FindById", ctx, session.FileID).Return(metadata, nil)
+	mockUow.GetUploadSessionRepoMock().On("UpdateExpiresAt", ctx, session.ID, mock.Anything).Return(nil)
+
+	// Act
+	found, target, err := service.GetUploadSession(ctx, session.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, session, found)
+	assert.Equal(t, metadata, target)
+	mockUow.GetUploadSessionRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_GetUploadSession_Forbidden(t *testing.T) {
+	// Arrange
+	ctx := withPrincipal("user-2")
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	owner := "user-1"
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New()}
+	mockUow.GetUploadSessionRepoMock().On("FindByIDAndActive", ctx, session.ID).Return(session, nil)
+	mockUow.GetFileRepoMock().On("FindById", ctx, session.FileID).Return(&domain.FileMetadata{ID: session.FileID, OwnerID: &owner}, nil)
+
+	// Act
+	_, _, err := service.GetUploadSession(ctx, session.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockUow.GetUploadSessionRepoMock().AssertNotCalled(t, "UpdateExpiresAt", mock.Anything, mock.Anything, mock.Anything)
+}
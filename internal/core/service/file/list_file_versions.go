@@ -0,0 +1,21 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ListFileVersions lists the versions of a file, newest first
+func (f *fileService) ListFileVersions(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error) {
+	metadata, err := f.uow.FileRepo().FindById(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.authorize(ctx, domain.RouteListVersions, metadata); err != nil {
+		return nil, err
+	}
+
+	return f.uow.FileVersionRepo().ListByFileID(ctx, fileID)
+}
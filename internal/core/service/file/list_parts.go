@@ -15,12 +15,15 @@ func (f *fileService) ListParts(ctx context.Context, sessionID uuid.UUID, maxPar
 		return nil, 0, err
 	}
 
-	fileMetadata, err := f.uow.FileRepo().FindById(ctx, session.FileID)
+	fileMetadata, err := f.uploadTarget(ctx, session)
 	if err != nil {
 		return nil, 0, err
 	}
+	if err := f.authorize(ctx, domain.RouteListParts, fileMetadata); err != nil {
+		return nil, 0, err
+	}
 
-	parts, newMarker, err := f.fileStorage.ListPartsPaginated(ctx, fileMetadata.StorageKey, session.ProviderUploadID, maxParts, partNumberMarker)
+	parts, newMarker, err := f.fileStorage.ListPartsPaginated(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, session.ProviderUploadID, maxParts, partNumberMarker)
 	if err != nil {
 		return nil, 0, err
 	}
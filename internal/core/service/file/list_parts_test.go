@@ -58,6 +58,7 @@ func TestFileService_ListParts_Success(t *testing.T) {
 		On(
 			"ListPartsPaginated",
 			ctx,
+			fileMetadata.Bucket,
 			fileMetadata.StorageKey,
 			session.ProviderUploadID,
 			maxParts,
@@ -180,6 +181,7 @@ func TestFileService_ListParts_StorageListPartsFails(t *testing.T) {
 	mockStorage.
 		On("ListPartsPaginated",
 			ctx,
+			fileMetadata.Bucket,
 			fileMetadata.StorageKey,
 			session.ProviderUploadID,
 			10,
@@ -232,6 +234,7 @@ func TestFileService_ListParts_UpdateExpiresAtFails(t *testing.T) {
 	mockStorage.
 		On("ListPartsPaginated",
 			ctx,
+			fileMetadata.Bucket,
 			fileMetadata.StorageKey,
 			session.ProviderUploadID,
 			10,
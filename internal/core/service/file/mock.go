@@ -28,11 +28,25 @@ func (m *MockFileService) RequestUploadFile(ctx context.Context, fileName string
 		args.Error(4)
 }
 
+func (m *MockFileService) RequestUploadFilePost(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	return args.Get(0).(*uuid.UUID),
+		args.Get(1).(*string),
+		args.Get(2).(map[string]string),
+		args.Get(3).(*time.Time),
+		args.Error(4)
+}
+
 func (m *MockFileService) RequestUploadMultipartFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error) {
 	args := m.Called(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
 	return args.Get(0).(*uuid.UUID), args.Int(1), args.Error(2)
 }
 
+func (m *MockFileService) DeduplicateUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	args := m.Called(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
 func (m *MockFileService) GetPresignedParts(ctx context.Context, sessionID uuid.UUID, parts []domain.UploadPart) ([]domain.UploadPart, error) {
 	args := m.Called(ctx, sessionID, parts)
 	return args.Get(0).([]domain.UploadPart), args.Error(1)
@@ -48,12 +62,76 @@ func (m *MockFileService) CompleteMultipartUpload(ctx context.Context, sessionID
 	return args.Get(0).(*uuid.UUID), args.Error(1)
 }
 
-func (m *MockFileService) GetFile(ctx context.Context, fileID uuid.UUID) (*string, *string, []domain.Tag, *time.Time, error) {
-	args := m.Called(ctx, fileID)
-	return args.Get(0).(*string), args.Get(1).(*string), args.Get(2).([]domain.Tag), args.Get(3).(*time.Time), args.Error(4)
+func (m *MockFileService) RequestResumableUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error) {
+	args := m.Called(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	return args.Get(0).(*uuid.UUID), args.Int(1), args.Error(2)
+}
+
+func (m *MockFileService) GetUploadSession(ctx context.Context, sessionID uuid.UUID) (*domain.UploadSession, *domain.FileMetadata, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(*domain.UploadSession), args.Get(1).(*domain.FileMetadata), args.Error(2)
+}
+
+func (m *MockFileService) AbortMultipartUpload(ctx context.Context, sessionID uuid.UUID) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockFileService) GetFile(ctx context.Context, fileID uuid.UUID, opts domain.DownloadOptions) (*string, *string, []domain.Tag, map[string]string, *time.Time, string, error) {
+	args := m.Called(ctx, fileID, opts)
+	return args.Get(0).(*string), args.Get(1).(*string), args.Get(2).([]domain.Tag), args.Get(3).(map[string]string), args.Get(4).(*time.Time), args.String(5), args.Error(6)
 }
 
 func (m *MockFileService) FinalizeUpload(ctx context.Context, metadata domain.FileMetadata, err error, eventType domain.EventType) error {
 	args := m.Called(ctx, metadata, err, eventType)
 	return args.Error(0)
 }
+
+func (m *MockFileService) RequestUploadFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	return args.Get(0).(*domain.FileVersion),
+		args.Get(1).(*string),
+		args.Get(2).(map[string]string),
+		args.Get(3).(*time.Time),
+		args.Error(4)
+}
+
+func (m *MockFileService) RequestUploadMultipartFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *uuid.UUID, int, error) {
+	args := m.Called(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	return args.Get(0).(*domain.FileVersion), args.Get(1).(*uuid.UUID), args.Int(2), args.Error(3)
+}
+
+func (m *MockFileService) ListFileVersions(ctx context.Context, fileID uuid.UUID) ([]domain.FileVersion, error) {
+	args := m.Called(ctx, fileID)
+	return args.Get(0).([]domain.FileVersion), args.Error(1)
+}
+
+func (m *MockFileService) GetFileVersion(ctx context.Context, fileID uuid.UUID, version int, opts domain.DownloadOptions) (*string, *string, map[string]string, *time.Time, error) {
+	args := m.Called(ctx, fileID, version, opts)
+	return args.Get(0).(*string), args.Get(1).(*string), args.Get(2).(map[string]string), args.Get(3).(*time.Time), args.Error(4)
+}
+
+func (m *MockFileService) CopyFile(ctx context.Context, sourceID uuid.UUID, fileName string, tags []string, clip *domain.Clip) (*uuid.UUID, error) {
+	args := m.Called(ctx, sourceID, fileName, tags, clip)
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
+func (m *MockFileService) FinalizeVersionUpload(ctx context.Context, version domain.FileVersion, err error, eventType domain.EventType) error {
+	args := m.Called(ctx, version, err, eventType)
+	return args.Error(0)
+}
+
+func (m *MockFileService) GetUsage(ctx context.Context) (*domain.Usage, *domain.Usage, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*domain.Usage), args.Get(1).(*domain.Usage), args.Error(2)
+}
+
+func (m *MockFileService) RequestImportFile(ctx context.Context, sourceURL string, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	args := m.Called(ctx, sourceURL, fileName, contentType, sizeBytes, checksumSha256, tags)
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
+func (m *MockFileService) GetFileImport(ctx context.Context, fileID uuid.UUID) (*domain.FileImport, domain.FileStatus, error) {
+	args := m.Called(ctx, fileID)
+	return args.Get(0).(*domain.FileImport), args.Get(1).(domain.FileStatus), args.Error(2)
+}
@@ -0,0 +1,77 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+)
+
+// checkQuota fails with domain.ErrQuotaExceeded when bytes and files more do not fit in the quotas of the tenant or of the owner.
+// It must run in the transaction creating the rows, which holds the tenant lock until commit.
+func (f *fileService) checkQuota(ctx context.Context, uow port.UnitOfWork, ownerID *string, bytes int64, files int64) error {
+	cfg := f.fileUploadCfg.Quota
+	tenantLimited := cfg.TenantMaxBytes > 0 || cfg.TenantMaxFiles > 0
+	userLimited := ownerID != nil && (cfg.UserMaxBytes > 0 || cfg.UserMaxFiles > 0)
+	if !tenantLimited && !userLimited {
+		return nil
+	}
+
+	if err := uow.UsageRepo().Lock(ctx); err != nil {
+		return err
+	}
+
+	if tenantLimited {
+		usage, err := f.usage(ctx, uow, nil)
+		if err != nil {
+			return err
+		}
+		if err := usage.Allows(bytes, files); err != nil {
+			return err
+		}
+	}
+
+	if userLimited {
+		usage, err := f.usage(ctx, uow, ownerID)
+		if err != nil {
+			return err
+		}
+		if err := usage.Allows(bytes, files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetUsage returns the usage of the tenant and, when authenticated, of the caller
+func (f *fileService) GetUsage(ctx context.Context) (*domain.Usage, *domain.Usage, error) {
+	tenant, err := f.usage(ctx, f.uow, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	owner := ownerOf(ctx)
+	if owner == nil {
+		return tenant, nil, nil
+	}
+
+	user, err := f.usage(ctx, f.uow, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, user, nil
+}
+
+// usage loads the usage of the tenant, or of ownerID, with its configured limits
+func (f *fileService) usage(ctx context.Context, uow port.UnitOfWork, ownerID *string) (*domain.Usage, error) {
+	usage, err := uow.UsageRepo().Get(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerID == nil {
+		usage.MaxBytes, usage.MaxFiles = f.fileUploadCfg.Quota.TenantMaxBytes, f.fileUploadCfg.Quota.TenantMaxFiles
+	} else {
+		usage.MaxBytes, usage.MaxFiles = f.fileUploadCfg.Quota.UserMaxBytes, f.fileUploadCfg.Quota.UserMaxFiles
+	}
+	return usage, nil
+}
@@ -0,0 +1,163 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var quotaCfg = config.FileUploadConfig{
+	SingleUploadMaxSize: 10000,
+	SessionTTL:          time.Hour,
+	Quota: config.QuotaConfig{
+		TenantMaxBytes: 1000,
+		UserMaxFiles:   2,
+	},
+}
+
+func TestFileService_RequestUploadFile_Quota(t *testing.T) {
+	owner := "user-1"
+
+	t.Run("error - tenant storage quota exceeded", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		service := file.NewFileService(mockUow, mockStorage, quotaCfg)
+		ctx := withPrincipal(owner)
+
+		mockStorage.On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).Return("bucket", "key")
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.GetUsageRepoMock().On("Lock", ctx).Return(nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).
+			Return(&domain.Usage{BytesStored: 600, BytesReserved: 300}, nil)
+
+		// Act
+		fileID, _, _, _, err := service.RequestUploadFile(ctx, "match.mp4", "video/mp4", 101, "sum", []string{})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+		assert.Nil(t, fileID)
+		mockUow.GetFileRepoMock().AssertNotCalled(t, "Create")
+		mockStorage.AssertNotCalled(t, "GeneratePresignedURLSimpleUpload")
+	})
+
+	t.Run("error - user file count quota exceeded", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		service := file.NewFileService(mockUow, mockStorage, quotaCfg)
+		ctx := withPrincipal(owner)
+
+		mockStorage.On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).Return("bucket", "key")
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.GetUsageRepoMock().On("Lock", ctx).Return(nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).Return(&domain.Usage{BytesStored: 100}, nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, &owner).Return(&domain.Usage{FileCount: 2}, nil)
+
+		// Act
+		_, _, _, _, err := service.RequestUploadFile(ctx, "match.mp4", "video/mp4", 100, "sum", []string{})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrFileCountQuotaExceeded)
+		mockUow.GetFileRepoMock().AssertNotCalled(t, "Create")
+	})
+
+	t.Run("success - within quota", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		service := file.NewFileService(mockUow, mockStorage, quotaCfg)
+		ctx := withPrincipal(owner)
+		expiresAt := time.Now().Add(time.Hour)
+
+		mockStorage.On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).Return("bucket", "key")
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.GetUsageRepoMock().On("Lock", ctx).Return(nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).Return(&domain.Usage{BytesStored: 900}, nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, &owner).Return(&domain.Usage{FileCount: 1}, nil)
+		mockUow.GetFileRepoMock().On("Create", ctx, mock.Anything, "match.mp4", "video/mp4", domain.FileTypeVideo, int64(100),
+			domain.FileStatusUploading, "sum", "bucket", "key", &owner).Return(nil)
+		mockUow.GetTagRepoMock().On("FindByNames", ctx, []string{}).Return(map[string]uuid.UUID{}, nil)
+		mockUow.GetFileTagRepoMock().On("CreateMany", ctx, mock.Anything, mock.Anything).Return(0, nil)
+		mockStorage.On("GeneratePresignedURLSimpleUpload", ctx, "bucket", "key", "sum").
+			Return("https://example.com/upload", map[string]string{}, &expiresAt, nil)
+
+		// Act
+		fileID, _, _, _, err := service.RequestUploadFile(ctx, "match.mp4", "video/mp4", 100, "sum", []string{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, fileID)
+		mockUow.GetUsageRepoMock().AssertExpectations(t)
+	})
+}
+
+func TestFileService_RequestUploadMultipartFile_QuotaExceeded(t *testing.T) {
+	// Arrange
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	cfg := quotaCfg
+	cfg.MultipartUploadMaxSize = 1 << 30
+	service := file.NewFileService(mockUow, mockStorage, cfg)
+	ctx := context.Background()
+
+	mockStorage.On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).Return("bucket", "key")
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.GetUsageRepoMock().On("Lock", ctx).Return(nil)
+	mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).Return(&domain.Usage{}, nil)
+
+	// Act
+	sessionID, _, err := service.RequestUploadMultipartFile(ctx, "match.mp4", "video/mp4", 20000, "sum", []string{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	assert.Nil(t, sessionID)
+	mockStorage.AssertNotCalled(t, "InitMultipartUpload")
+}
+
+func TestFileService_GetUsage(t *testing.T) {
+	owner := "user-1"
+
+	t.Run("success - tenant and caller with their limits", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		service := file.NewFileService(mockUow, storage.NewMockStorage(), quotaCfg)
+		ctx := withPrincipal(owner)
+		mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).Return(&domain.Usage{BytesStored: 500, FileCount: 3}, nil)
+		mockUow.GetUsageRepoMock().On("Get", ctx, &owner).Return(&domain.Usage{BytesStored: 200, FileCount: 1}, nil)
+
+		// Act
+		tenant, user, err := service.GetUsage(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &domain.Usage{BytesStored: 500, FileCount: 3, MaxBytes: 1000}, tenant)
+		assert.Equal(t, &domain.Usage{BytesStored: 200, FileCount: 1, MaxFiles: 2}, user)
+	})
+
+	t.Run("success - anonymous caller", func(t *testing.T) {
+		// Arrange
+		mockUow := repository.NewMockUnitOfWork()
+		service := file.NewFileService(mockUow, storage.NewMockStorage(), quotaCfg)
+		ctx := context.Background()
+		mockUow.GetUsageRepoMock().On("Get", ctx, (*string)(nil)).Return(&domain.Usage{}, nil)
+
+		// Act
+		tenant, user, err := service.GetUsage(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, tenant)
+		assert.Nil(t, user)
+	})
+}
@@ -0,0 +1,44 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// RequestImportFile validates the source url and opens a multipart upload session with a pending import,
+// the worker then fetches the content. It returns the id of the file.
+func (f *fileService) RequestImportFile(ctx context.Context, sourceURL string, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+
+	source, err := domain.CheckImportURL(sourceURL, f.fileUploadCfg.Import.AllowedHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	if sizeBytes <= 0 {
+		return nil, domain.ErrFileSizeTooSmall
+	}
+
+	if sizeBytes > f.fileUploadCfg.MultipartUploadMaxSize {
+		return nil, domain.ErrFileSizeTooBig
+	}
+
+	var fileID uuid.UUID
+	_, _, err = f.startMultipartUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags, func(uow port.UnitOfWork, id uuid.UUID, sessionID uuid.UUID) error {
+		fileID = id
+		return uow.FileImportRepo().Create(ctx, domain.FileImport{
+			FileID:    id,
+			SessionID: sessionID,
+			SourceURL: source.String(),
+			OwnerID:   ownerOf(ctx),
+			Status:    domain.ImportStatusPending,
+			SizeBytes: sizeBytes,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fileID, nil
+}
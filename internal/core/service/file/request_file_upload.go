@@ -11,6 +11,19 @@ import (
 )
 
 func (f *fileService) RequestUploadFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
+	return f.requestUploadFile(ctx, fileName, contentType, sizeBytes, checksumSha256, tags, func(bucket string, storageKey string, _ string) (string, map[string]string, *time.Time, error) {
+		return f.fileStorage.GeneratePresignedURLSimpleUpload(ctx, bucket, storageKey, checksumSha256)
+	})
+}
+
+func (f *fileService) RequestUploadFilePost(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
+	return f.requestUploadFile(ctx, fileName, contentType, sizeBytes, checksumSha256, tags, func(bucket string, storageKey string, mimeType string) (string, map[string]string, *time.Time, error) {
+		return f.fileStorage.GeneratePresignedPostPolicy(ctx, bucket, storageKey, mimeType, sizeBytes, checksumSha256)
+	})
+}
+
+// requestUploadFile creates the file uploaded in a single request, signed by presign from its location and mime type
+func (f *fileService) requestUploadFile(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string, presign func(bucket string, storageKey string, mimeType string) (string, map[string]string, *time.Time, error)) (*uuid.UUID, *string, map[string]string, *time.Time, error) {
 
 	if sizeBytes > f.fileUploadCfg.SingleUploadMaxSize+1 {
 		return nil, nil, nil, nil, domain.ErrFileSizeTooBig
@@ -27,11 +40,15 @@ func (f *fileService) RequestUploadFile(ctx context.Context, fileName string, co
 	var headers map[string]string
 	var expiresAt *time.Time
 
-	storageKey := fmt.Sprintf("%s/%s", fileType, fileID.String())
+	bucket, storageKey := f.fileStorage.ResolveLocation(fileType, domain.TenantOrDefault(ctx), fileID, time.Now())
 
 	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
 
-		createErr := uow.FileRepo().Create(ctx, fileID, fileName, mimeType, fileType, sizeBytes, domain.FileStatusUploading, checksumSha256, storageKey)
+		if err := f.checkQuota(ctx, uow, ownerOf(ctx), sizeBytes, 1); err != nil {
+			return err
+		}
+
+		createErr := uow.FileRepo().Create(ctx, fileID, fileName, mimeType, fileType, sizeBytes, domain.FileStatusUploading, checksumSha256, bucket, storageKey, ownerOf(ctx))
 		if createErr != nil {
 			return createErr
 		}
@@ -47,7 +64,7 @@ func (f *fileService) RequestUploadFile(ctx context.Context, fileName string, co
 		}
 
 		var storeErr error
-		presignedURL, headers, expiresAt, storeErr = f.fileStorage.GeneratePresignedURLSimpleUpload(ctx, storageKey, checksumSha256)
+		presignedURL, headers, expiresAt, storeErr = presign(bucket, storageKey, mimeType)
 		if storeErr != nil {
 			return storeErr
 		}
@@ -20,25 +20,35 @@ func (f *fileService) RequestUploadMultipartFile(ctx context.Context, fileName s
 		return nil, 0, domain.ErrFileSizeTooBig
 	}
 
+	return f.startMultipartUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags, nil)
+}
+
+// startMultipartUpload validates the file, reserves its quota and opens its upload session.
+// afterCreate, if not nil, runs in the transaction once the file and the session are created.
+func (f *fileService) startMultipartUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string, afterCreate func(uow port.UnitOfWork, fileID uuid.UUID, sessionID uuid.UUID) error) (*uuid.UUID, int, error) {
 	fileType, mimeType, err := f.validateMediaFile(fileName, contentType)
 	if err != nil {
 		return nil, 0, fmt.Errorf("%w: %w", domain.ErrInvalidFileType, err)
 	}
 
 	fileID := uuid.New()
-	storageKey := fmt.Sprintf("%s/%s", fileType, fileID.String())
+	bucket, storageKey := f.fileStorage.ResolveLocation(fileType, domain.TenantOrDefault(ctx), fileID, time.Now())
 	uploadSessionID := uuid.New()
 	uploadID := ""
 
 	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
 
+		if err := f.checkQuota(ctx, uow, ownerOf(ctx), sizeBytes, 1); err != nil {
+			return err
+		}
+
 		var storeErr error
-		uploadID, storeErr = f.fileStorage.InitMultipartUpload(ctx, storageKey, checksumSha256)
+		uploadID, storeErr = f.fileStorage.InitMultipartUpload(ctx, bucket, storageKey, checksumSha256)
 		if storeErr != nil {
 			return storeErr
 		}
 
-		metadataErr := uow.FileRepo().Create(ctx, fileID, fileName, mimeType, fileType, sizeBytes, domain.FileStatusUploading, checksumSha256, storageKey)
+		metadataErr := uow.FileRepo().Create(ctx, fileID, fileName, mimeType, fileType, sizeBytes, domain.FileStatusUploading, checksumSha256, bucket, storageKey, ownerOf(ctx))
 		if metadataErr != nil {
 			return metadataErr
 		}
@@ -64,6 +74,9 @@ func (f *fileService) RequestUploadMultipartFile(ctx context.Context, fileName s
 		if uploadSessionErr != nil {
 			return uploadSessionErr
 		}
+		if afterCreate != nil {
+			return afterCreate(uow, fileID, uploadSessionID)
+		}
 		return nil
 	})
 	if txErr != nil {
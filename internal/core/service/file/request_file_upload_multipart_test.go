@@ -21,6 +21,10 @@ func TestFileService_RequestUploadMultipartFile_Success(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(50000)
@@ -37,7 +41,7 @@ func TestFileService_RequestUploadMultipartFile_Success(t *testing.T) {
 	expectedUploadID := "provider_123"
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, checksum).
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, checksum).
 		Return(expectedUploadID, nil)
 
 	mockUow.GetFileRepoMock().
@@ -51,6 +55,8 @@ func TestFileService_RequestUploadMultipartFile_Success(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -98,6 +104,10 @@ func TestFileService_RequestUploadMultipartFile_Success_NoTags(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(50000)
@@ -107,7 +117,7 @@ func TestFileService_RequestUploadMultipartFile_Success_NoTags(t *testing.T) {
 	expectedUploadID := "provider_123"
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, checksum).
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, checksum).
 		Return(expectedUploadID, nil)
 
 	mockUow.GetFileRepoMock().
@@ -121,6 +131,8 @@ func TestFileService_RequestUploadMultipartFile_Success_NoTags(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -164,6 +176,10 @@ func TestFileService_RequestUploadMultipartFile_TagNotFound(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(50000)
@@ -176,7 +192,7 @@ func TestFileService_RequestUploadMultipartFile_TagNotFound(t *testing.T) {
 	}
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, checksum).
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, checksum).
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
@@ -190,6 +206,8 @@ func TestFileService_RequestUploadMultipartFile_TagNotFound(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -253,10 +271,14 @@ func TestFileService_RequestUploadMultipartFile_StorageInitFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	storageErr := errors.New("fileStorage down")
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, "sha").
 		Return("", storageErr)
 
 	mockUow.
@@ -287,16 +309,20 @@ func TestFileService_RequestUploadMultipartFile_FileRepoFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	repoErr := errors.New("db error")
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, "sha").
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything,
 			mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(repoErr)
 
 	mockUow.
@@ -325,17 +351,21 @@ func TestFileService_RequestUploadMultipartFile_FindByNamesFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	tags := []string{"football"}
 	findErr := errors.New("db error finding tags")
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, "sha").
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything,
 			mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	mockUow.GetTagRepoMock().
@@ -368,6 +398,10 @@ func TestFileService_RequestUploadMultipartFile_CreateManyFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	tags := []string{"football"}
 	tagID := uuid.New()
 	tagMap := map[string]uuid.UUID{
@@ -376,13 +410,13 @@ func TestFileService_RequestUploadMultipartFile_CreateManyFails(t *testing.T) {
 	createManyErr := errors.New("db error creating file tags")
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, "sha").
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything,
 			mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	mockUow.GetTagRepoMock().
@@ -419,6 +453,10 @@ func TestFileService_RequestUploadMultipartFile_UploadSessionRepoFails(t *testin
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	tags := []string{"football"}
 	tagID := uuid.New()
 	tagMap := map[string]uuid.UUID{
@@ -427,13 +465,13 @@ func TestFileService_RequestUploadMultipartFile_UploadSessionRepoFails(t *testin
 	sessionErr := errors.New("session error")
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, mock.Anything, mock.Anything, "sha").
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything,
 			mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	mockUow.GetTagRepoMock().
@@ -475,6 +513,10 @@ func TestFileService_RequestUploadMultipartFile_StorageKeyLogic(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", domain.FileTypeImage, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("images", "image/key")
+
 	tags := []string{"photo"}
 	tagID := uuid.New()
 	tagMap := map[string]uuid.UUID{
@@ -482,7 +524,7 @@ func TestFileService_RequestUploadMultipartFile_StorageKeyLogic(t *testing.T) {
 	}
 
 	mockStorage.
-		On("InitMultipartUpload", ctx, mock.Anything, "sha").
+		On("InitMultipartUpload", ctx, "images", "image/key", "sha").
 		Return("upload_id", nil)
 
 	mockUow.GetFileRepoMock().
@@ -493,6 +535,8 @@ func TestFileService_RequestUploadMultipartFile_StorageKeyLogic(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
+			"images",
+			"image/key",
 			mock.Anything,
 		).
 		Return(nil)
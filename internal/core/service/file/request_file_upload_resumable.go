@@ -0,0 +1,22 @@
+package file
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// RequestResumableUpload opens a multipart upload session the api receives the content of, whatever its size
+func (f *fileService) RequestResumableUpload(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, int, error) {
+
+	if sizeBytes <= 0 {
+		return nil, 0, domain.ErrFileSizeTooSmall
+	}
+
+	if sizeBytes > f.fileUploadCfg.MultipartUploadMaxSize {
+		return nil, 0, domain.ErrFileSizeTooBig
+	}
+
+	return f.startMultipartUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags, nil)
+}
@@ -0,0 +1,85 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFileService_RequestResumableUpload_SmallFile(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	sizeBytes := int64(100)
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+	mockStorage.
+		On("InitMultipartUpload", ctx, "bucket", "key", "sha256").
+		Return("provider_123", nil)
+	mockUow.GetFileRepoMock().
+		On("Create", ctx, mock.Anything, "photo.jpg", "image/jpeg", domain.FileTypeImage, sizeBytes, domain.FileStatusUploading, "sha256", "bucket", "key", mock.Anything).
+		Return(nil)
+	mockUow.GetTagRepoMock().
+		On("FindByNames", ctx, []string{"match"}).
+		Return(map[string]uuid.UUID{"match": uuid.New()}, nil)
+	mockUow.GetFileTagRepoMock().
+		On("CreateMany", ctx, mock.Anything, mock.Anything).
+		Return(1, nil)
+	mockUow.GetUploadSessionRepoMock().
+		On("Create", ctx, mock.MatchedBy(func(session domain.UploadSession) bool {
+			return session.ProviderUploadID == "provider_123" && session.PartSize == defaultCfg.PartSize
+		})).
+		Return(nil)
+	mockUow.
+		On("Execute", ctx, mock.Anything).
+		Return(nil)
+
+	// Act
+	sessionID, partSize, err := service.RequestResumableUpload(ctx, "photo.jpg", "image/jpeg", sizeBytes, "sha256", []string{"match"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, sessionID)
+	assert.Equal(t, defaultCfg.PartSize, partSize)
+	mockUow.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetUploadSessionRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_RequestResumableUpload_InvalidSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		sizeBytes int64
+		wantErr   error
+	}{
+		{"empty", 0, domain.ErrFileSizeTooSmall},
+		{"too big", defaultCfg.MultipartUploadMaxSize + 1, domain.ErrFileSizeTooBig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockUow := repository.NewMockUnitOfWork()
+			mockStorage := storage.NewMockStorage()
+			service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+			// Act
+			sessionID, _, err := service.RequestResumableUpload(context.Background(), "video.mp4", "video/mp4", tt.sizeBytes, "sha256", nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, sessionID)
+			mockUow.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
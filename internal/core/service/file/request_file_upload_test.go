@@ -24,6 +24,10 @@ func TestFileService_RequestUploadFile_Success_VideoFile(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(1000)
@@ -57,6 +61,8 @@ func TestFileService_RequestUploadFile_Success_VideoFile(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -74,6 +80,7 @@ func TestFileService_RequestUploadFile_Success_VideoFile(t *testing.T) {
 			"GeneratePresignedURLSimpleUpload",
 			ctx,
 			mock.Anything,
+			mock.Anything,
 			checksum,
 		).
 		Return(presignedURL, headers, &expiresAt, nil)
@@ -108,6 +115,10 @@ func TestFileService_RequestUploadFile_Success_ImageJPEG(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "photo.jpg"
 	contentType := "image/jpeg"
 	sizeBytes := int64(1000)
@@ -139,6 +150,8 @@ func TestFileService_RequestUploadFile_Success_ImageJPEG(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -156,6 +169,7 @@ func TestFileService_RequestUploadFile_Success_ImageJPEG(t *testing.T) {
 			"GeneratePresignedURLSimpleUpload",
 			ctx,
 			mock.Anything,
+			mock.Anything,
 			checksum,
 		).
 		Return(presignedURL, headers, &expiresAt, nil)
@@ -179,6 +193,10 @@ func TestFileService_RequestUploadFile_Success_NoTags(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(1000)
@@ -202,6 +220,8 @@ func TestFileService_RequestUploadFile_Success_NoTags(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -219,6 +239,7 @@ func TestFileService_RequestUploadFile_Success_NoTags(t *testing.T) {
 			"GeneratePresignedURLSimpleUpload",
 			ctx,
 			mock.Anything,
+			mock.Anything,
 			checksum,
 		).
 		Return(presignedURL, headers, &expiresAt, nil)
@@ -245,6 +266,10 @@ func TestFileService_RequestUploadFile_TagNotFound(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	fileName := "video.mp4"
 	contentType := "video/mp4"
 	sizeBytes := int64(1000)
@@ -268,6 +293,8 @@ func TestFileService_RequestUploadFile_TagNotFound(t *testing.T) {
 			sizeBytes,
 			domain.FileStatusUploading,
 			checksum,
+			"bucket",
+			mock.Anything,
 			mock.Anything,
 		).
 		Return(nil)
@@ -386,11 +413,15 @@ func TestFileService_RequestUploadFile_CreateRepoFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	createErr := errors.New("db error")
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(createErr)
 
 	mockUow.On("Execute", ctx, mock.Anything).Return(createErr)
@@ -414,12 +445,16 @@ func TestFileService_RequestUploadFile_FindByNamesFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	tags := []string{"football"}
 	findErr := errors.New("db error finding tags")
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	mockUow.GetTagRepoMock().
@@ -447,6 +482,10 @@ func TestFileService_RequestUploadFile_CreateManyFails(t *testing.T) {
 	mockStorage := storage.NewMockStorage()
 	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
 
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+
 	tags := []string{"football"}
 	tagID := uuid.New()
 	tagMap := map[string]uuid.UUID{
@@ -456,7 +495,7 @@ func TestFileService_RequestUploadFile_CreateManyFails(t *testing.T) {
 
 	mockUow.GetFileRepoMock().
 		On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	mockUow.GetTagRepoMock().
@@ -480,3 +519,70 @@ func TestFileService_RequestUploadFile_CreateManyFails(t *testing.T) {
 	assert.Nil(t, headers)
 	assert.Nil(t, expiresAt)
 }
+
+func TestFileService_RequestUploadFilePost_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	checksum := "abc123"
+	tags := []string{"football"}
+	postURL := "https://minio.example.com/bucket"
+	fields := map[string]string{
+		"key":          "key",
+		"Content-Type": "video/mp4",
+		"policy":       "eyJ...",
+	}
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	mockStorage.
+		On("ResolveLocation", mock.Anything, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "key")
+	mockUow.GetFileRepoMock().
+		On("Create", ctx, mock.Anything, "video.mp4", "video/mp4", domain.FileTypeVideo, int64(1000), domain.FileStatusUploading, checksum, "bucket", "key", mock.Anything).
+		Return(nil)
+	mockUow.GetTagRepoMock().
+		On("FindByNames", ctx, tags).
+		Return(map[string]uuid.UUID{"football": uuid.New()}, nil)
+	mockUow.GetFileTagRepoMock().
+		On("CreateMany", ctx, mock.Anything, mock.Anything).
+		Return(1, nil)
+	mockStorage.
+		On("GeneratePresignedPostPolicy", ctx, "bucket", "key", "video/mp4", int64(1000), checksum).
+		Return(postURL, fields, &expiresAt, nil)
+	mockUow.
+		On("Execute", ctx, mock.Anything).
+		Return(nil)
+
+	// Act
+	fileID, url, resultFields, resultExpiresAt, err :=
+		fileService.RequestUploadFilePost(ctx, "video.mp4", "video/mp4", 1000, checksum, tags)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, fileID)
+	assert.Equal(t, postURL, *url)
+	assert.Equal(t, fields, resultFields)
+	assert.Equal(t, &expiresAt, resultExpiresAt)
+	mockStorage.AssertNotCalled(t, "GeneratePresignedURLSimpleUpload")
+	mockUow.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetFileRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_RequestUploadFilePost_FileTooBig(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	fileService := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	// Act
+	_, _, _, _, err := fileService.RequestUploadFilePost(ctx, "video.mp4", "video/mp4", defaultCfg.SingleUploadMaxSize+2, "abc123", []string{"football"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileSizeTooBig)
+	mockStorage.AssertNotCalled(t, "GeneratePresignedPostPolicy")
+}
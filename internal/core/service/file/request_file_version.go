@@ -0,0 +1,100 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestUploadFileVersion starts a simple upload of a new version of an existing file
+func (f *fileService) RequestUploadFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *string, map[string]string, *time.Time, error) {
+
+	if sizeBytes > f.fileUploadCfg.SingleUploadMaxSize+1 {
+		return nil, nil, nil, nil, domain.ErrFileSizeTooBig
+	}
+
+	version, metadata, err := f.prepareVersion(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	var presignedURL string
+	var headers map[string]string
+	var expiresAt *time.Time
+
+	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+		if err := f.checkQuota(ctx, uow, metadata.OwnerID, sizeBytes, 0); err != nil {
+			return err
+		}
+
+		if err := assignVersionNumber(ctx, uow, version); err != nil {
+			return err
+		}
+
+		if err := uow.FileVersionRepo().Create(ctx, *version); err != nil {
+			return err
+		}
+
+		var storeErr error
+		presignedURL, headers, expiresAt, storeErr = f.fileStorage.GeneratePresignedURLSimpleUpload(ctx, version.Bucket, version.StorageKey, checksumSha256)
+		return storeErr
+	})
+
+	if txErr != nil {
+		return nil, nil, nil, nil, fmt.Errorf("could not generate version upload presigned url: %w", txErr)
+	}
+	return version, &presignedURL, headers, expiresAt, nil
+}
+
+// prepareVersion validates a new version against its file and picks its location
+func (f *fileService) prepareVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *domain.FileMetadata, error) {
+	metadata, err := f.uow.FileRepo().FindById(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := f.authorize(ctx, domain.RouteUploadVersion, metadata); err != nil {
+		return nil, nil, err
+	}
+	if metadata.Status != domain.FileStatusCompleted {
+		return nil, nil, domain.ErrFileNotReady
+	}
+
+	fileType, mimeType, err := f.validateMediaFile(fileName, contentType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", domain.ErrInvalidFileType, err)
+	}
+	if string(fileType) != metadata.MediaType {
+		return nil, nil, domain.ErrFileTypeMismatch
+	}
+
+	versionID := uuid.New()
+	bucket, storageKey := f.fileStorage.ResolveLocation(fileType, domain.TenantOrDefault(ctx), versionID, time.Now())
+
+	return &domain.FileVersion{
+		ID:         versionID,
+		FileID:     fileID,
+		Filename:   fileName,
+		MimeType:   mimeType,
+		SizeBytes:  sizeBytes,
+		Bucket:     bucket,
+		StorageKey: storageKey,
+		Checksum:   checksumSha256,
+		Status:     domain.FileStatusUploading,
+	}, metadata, nil
+}
+
+// assignVersionNumber numbers the version inside the transaction that creates it,
+// the file row stays locked until commit so concurrent requests get distinct numbers
+func assignVersionNumber(ctx context.Context, uow port.UnitOfWork, version *domain.FileVersion) error {
+	next, err := uow.FileVersionRepo().NextVersion(ctx, version.FileID)
+	if err != nil {
+		return err
+	}
+	version.Version = next
+	return nil
+}
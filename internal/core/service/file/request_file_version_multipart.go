@@ -0,0 +1,64 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestUploadMultipartFileVersion starts a multipart upload of a new version of an existing file
+func (f *fileService) RequestUploadMultipartFileVersion(ctx context.Context, fileID uuid.UUID, fileName string, contentType string, sizeBytes int64, checksumSha256 string) (*domain.FileVersion, *uuid.UUID, int, error) {
+
+	if sizeBytes <= f.fileUploadCfg.SingleUploadMaxSize {
+		return nil, nil, 0, domain.ErrFileSizeTooSmall
+	}
+
+	if sizeBytes > f.fileUploadCfg.MultipartUploadMaxSize {
+		return nil, nil, 0, domain.ErrFileSizeTooBig
+	}
+
+	version, metadata, err := f.prepareVersion(ctx, fileID, fileName, contentType, sizeBytes, checksumSha256)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	uploadSessionID := uuid.New()
+
+	txErr := f.uow.Execute(ctx, func(uow port.UnitOfWork) error {
+
+		if err := f.checkQuota(ctx, uow, metadata.OwnerID, sizeBytes, 0); err != nil {
+			return err
+		}
+
+		if err := assignVersionNumber(ctx, uow, version); err != nil {
+			return err
+		}
+
+		uploadID, storeErr := f.fileStorage.InitMultipartUpload(ctx, version.Bucket, version.StorageKey, checksumSha256)
+		if storeErr != nil {
+			return storeErr
+		}
+
+		if err := uow.FileVersionRepo().Create(ctx, *version); err != nil {
+			return err
+		}
+
+		return uow.UploadSessionRepo().Create(ctx, domain.UploadSession{
+			ID:               uploadSessionID,
+			FileID:           fileID,
+			VersionID:        &version.ID,
+			ProviderUploadID: uploadID,
+			PartSize:         f.fileUploadCfg.PartSize,
+			ExpiresAt:        time.Now().Add(f.fileUploadCfg.SessionTTL),
+			Status:           domain.UploadSessionStatusOpen,
+		})
+	})
+	if txErr != nil {
+		return nil, nil, 0, fmt.Errorf("could not start version multipart upload: %w", txErr)
+	}
+	return version, &uploadSessionID, f.fileUploadCfg.PartSize, nil
+}
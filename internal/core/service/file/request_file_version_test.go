@@ -0,0 +1,147 @@
+package file_test
+
+import (
+	"context"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/file"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_RequestUploadFileVersion_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	fileID := uuid.New()
+	metadata := &domain.FileMetadata{ID: fileID, MediaType: string(domain.FileTypeVideo), Status: domain.FileStatusCompleted}
+	presignedURL := "https://minio.example.com/bucket/video-id"
+	headers := map[string]string{"Content-Type": "video/mp4"}
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(metadata, nil)
+	mockUow.GetFileVersionRepoMock().On("NextVersion", ctx, fileID).Return(2, nil)
+	mockStorage.
+		On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "video/version-key")
+	mockUow.GetFileVersionRepoMock().
+		On("Create", ctx, mock.MatchedBy(func(v domain.FileVersion) bool {
+			return v.FileID == fileID && v.Version == 2 && v.StorageKey == "video/version-key" && v.Status == domain.FileStatusUploading
+		})).
+		Return(nil)
+	mockStorage.
+		On("GeneratePresignedURLSimpleUpload", ctx, "bucket", "video/version-key", "sha").
+		Return(presignedURL, headers, &expiresAt, nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	version, url, resultHeaders, resultExpiresAt, err :=
+		service.RequestUploadFileVersion(ctx, fileID, "video.mp4", "video/mp4", 1000, "sha")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.Version)
+	assert.Equal(t, presignedURL, *url)
+	assert.Equal(t, headers, resultHeaders)
+	assert.NotNil(t, resultExpiresAt)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetFileVersionRepoMock().AssertExpectations(t)
+}
+
+func TestFileService_RequestUploadFileVersion_FileNotReady(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	metadata := &domain.FileMetadata{ID: fileID, MediaType: string(domain.FileTypeVideo), Status: domain.FileStatusUploading}
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(metadata, nil)
+
+	// Act
+	_, _, _, _, err := service.RequestUploadFileVersion(ctx, fileID, "video.mp4", "video/mp4", 1000, "sha")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileNotReady)
+}
+
+func TestFileService_RequestUploadFileVersion_TypeMismatch(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	metadata := &domain.FileMetadata{ID: fileID, MediaType: string(domain.FileTypeVideo), Status: domain.FileStatusCompleted}
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(metadata, nil)
+
+	// Act
+	_, _, _, _, err := service.RequestUploadFileVersion(ctx, fileID, "photo.jpg", "image/jpeg", 1000, "sha")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileTypeMismatch)
+}
+
+func TestFileService_RequestUploadFileVersion_FileNotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	service := file.NewFileService(mockUow, storage.NewMockStorage(), defaultCfg)
+
+	fileID := uuid.New()
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return((*domain.FileMetadata)(nil), domain.ErrFileMetadataNotFound)
+
+	// Act
+	_, _, _, _, err := service.RequestUploadFileVersion(ctx, fileID, "video.mp4", "video/mp4", 1000, "sha")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrFileMetadataNotFound)
+}
+
+func TestFileService_RequestUploadMultipartFileVersion_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := file.NewFileService(mockUow, mockStorage, defaultCfg)
+
+	fileID := uuid.New()
+	metadata := &domain.FileMetadata{ID: fileID, MediaType: string(domain.FileTypeVideo), Status: domain.FileStatusCompleted}
+
+	mockUow.GetFileRepoMock().On("FindById", ctx, fileID).Return(metadata, nil)
+	mockUow.GetFileVersionRepoMock().On("NextVersion", ctx, fileID).Return(3, nil)
+	mockStorage.
+		On("ResolveLocation", domain.FileTypeVideo, domain.DefaultTenant, mock.Anything, mock.Anything).
+		Return("bucket", "video/version-key")
+	mockStorage.
+		On("InitMultipartUpload", ctx, "bucket", "video/version-key", "sha").
+		Return("provider_123", nil)
+	mockUow.GetFileVersionRepoMock().On("Create", ctx, mock.Anything).Return(nil)
+	mockUow.GetUploadSessionRepoMock().
+		On("Create", ctx, mock.MatchedBy(func(s domain.UploadSession) bool {
+			return s.FileID == fileID && s.VersionID != nil && s.ProviderUploadID == "provider_123"
+		})).
+		Return(nil)
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+
+	// Act
+	version, sessionID, partSize, err :=
+		service.RequestUploadMultipartFileVersion(ctx, fileID, "video.mp4", "video/mp4", 50000, "sha")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, version.Version)
+	assert.NotNil(t, sessionID)
+	assert.Equal(t, defaultCfg.PartSize, partSize)
+	mockStorage.AssertExpectations(t)
+	mockUow.GetUploadSessionRepoMock().AssertExpectations(t)
+}
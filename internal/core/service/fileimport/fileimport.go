@@ -0,0 +1,204 @@
+package fileimport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+)
+
+// sniffLength is the number of bytes content types are detected from
+const sniffLength = 512
+
+// permanentErrors fail an import without retrying it
+var permanentErrors = []error{
+	domain.ErrSourceNotAllowed,
+	domain.ErrSizeMismatch,
+	domain.ErrMismatchChecksum,
+	domain.ErrContentTypeMismatch,
+	domain.ErrSessionNotFound,
+	domain.ErrFileMetadataNotFound,
+	domain.ErrFileUploadFailed,
+}
+
+type importService struct {
+	uow         port.UnitOfWork
+	fileStorage port.FileStorage
+	files       port.FileService
+	source      port.RemoteSource
+	cfg         config.FileUploadConfig
+	logger      *slog.Logger
+}
+
+// NewImportService creates a service fetching the files imported from remote urls into their multipart upload sessions.
+// The content is streamed part by part, then the session is completed and the file finalized as any multipart upload.
+func NewImportService(uow port.UnitOfWork, fileStorage port.FileStorage, files port.FileService, source port.RemoteSource, cfg config.FileUploadConfig, logger *slog.Logger) port.ImportService {
+	return &importService{
+		uow:         uow,
+		fileStorage: fileStorage,
+		files:       files,
+		source:      source,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// ImportNext claims the next pending import and fetches it. A failed attempt is retried by a later call until
+// cfg.Import.MaxAttempts, errors that would fail again fail the import and the file at once.
+// The error is only set when the state of the import could not be saved.
+func (s *importService) ImportNext(ctx context.Context) (bool, error) {
+	fileImport, err := s.uow.FileImportRepo().ClaimNext(ctx, time.Now().Add(-s.cfg.Import.StaleAfter))
+	if errors.Is(err, domain.ErrImportNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	logger := s.logger.With("file_id", fileImport.FileID, "attempt", fileImport.Attempts)
+	if fileImport.Attempts > s.cfg.Import.MaxAttempts {
+		// the worker running the last attempt stopped before recording its outcome
+		return true, s.fail(ctx, fileImport, fmt.Errorf("gave up after %d attempts", s.cfg.Import.MaxAttempts))
+	}
+
+	logger.Info("importing file")
+	importErr := s.fetch(ctx, fileImport)
+	if importErr == nil {
+		logger.Info("file imported")
+		return true, s.uow.FileImportRepo().UpdateStatus(ctx, fileImport.FileID, domain.ImportStatusCompleted, "")
+	}
+
+	if !isPermanent(importErr) && fileImport.Attempts < s.cfg.Import.MaxAttempts {
+		logger.Warn("file import failed, retrying", "error", importErr)
+		return true, s.uow.FileImportRepo().UpdateStatus(ctx, fileImport.FileID, domain.ImportStatusPending, importErr.Error())
+	}
+	logger.Error("file import failed", "error", importErr)
+	return true, s.fail(ctx, fileImport, importErr)
+}
+
+// fetch streams the source of fileImport into its session, checking its size, content type and checksum on the way
+func (s *importService) fetch(ctx context.Context, fileImport *domain.FileImport) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Import.Timeout)
+	defer cancel()
+
+	session, err := s.uow.UploadSessionRepo().FindByIDAndActive(ctx, fileImport.SessionID)
+	if err != nil {
+		return err
+	}
+	target, err := s.uow.FileRepo().FindById(ctx, fileImport.FileID)
+	if err != nil {
+		return err
+	}
+	if target.Status != domain.FileStatusUploading {
+		return fmt.Errorf("%w: file is %s", domain.ErrFileUploadFailed, target.Status)
+	}
+	if err := s.refresh(ctx, session); err != nil {
+		return err
+	}
+
+	body, size, err := s.source.Open(ctx, fileImport.SourceURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if size >= 0 && size != target.SizeBytes {
+		return fmt.Errorf("%w: source is %d bytes, expected %d", domain.ErrSizeMismatch, size, target.SizeBytes)
+	}
+
+	// one more byte than the file size is read to detect a longer content
+	h := sha256.New()
+	content := io.TeeReader(io.LimitReader(body, target.SizeBytes+1), h)
+	buffer := make([]byte, session.PartSize)
+	parts := make([]domain.UploadPart, 0, (target.SizeBytes+int64(session.PartSize)-1)/int64(session.PartSize))
+	received := int64(0)
+	for partNumber := 1; received < target.SizeBytes; partNumber++ {
+		length := min(int64(session.PartSize), target.SizeBytes-received)
+		n, err := io.ReadFull(content, buffer[:length])
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("%w: source ended after %d bytes, expected %d", domain.ErrSizeMismatch, received+int64(n), target.SizeBytes)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", domain.ErrSourceUnavailable, err)
+		}
+		if partNumber == 1 {
+			if detected := http.DetectContentType(buffer[:min(n, sniffLength)]); detected != target.MimeType {
+				return fmt.Errorf("%w: source is %s, expected %s", domain.ErrContentTypeMismatch, detected, target.MimeType)
+			}
+		}
+
+		sum := sha256.Sum256(buffer[:n])
+		part, err := s.fileStorage.UploadPart(ctx, target.Bucket, target.StorageKey, session.ProviderUploadID, partNumber, bytes.NewReader(buffer[:n]), int64(n), base64.StdEncoding.EncodeToString(sum[:]))
+		if err != nil {
+			return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+		}
+		parts = append(parts, part)
+		received += int64(n)
+
+		if err := s.uow.FileImportRepo().UpdateProgress(ctx, fileImport.FileID, received); err != nil {
+			return err
+		}
+		if err := s.refresh(ctx, session); err != nil {
+			return err
+		}
+	}
+
+	if n, _ := io.ReadFull(content, make([]byte, 1)); n > 0 {
+		return fmt.Errorf("%w: source exceeds %d bytes", domain.ErrSizeMismatch, target.SizeBytes)
+	}
+	checksum := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if target.Checksum != "" && checksum != target.Checksum {
+		return fmt.Errorf("%w: source is %s, expected %s", domain.ErrMismatchChecksum, checksum, target.Checksum)
+	}
+
+	_, err = s.files.CompleteMultipartUpload(ctx, session.ID, parts)
+	return err
+}
+
+// refresh pushes back the expiry of session, so the cleanup leaves it alone while it is being imported
+func (s *importService) refresh(ctx context.Context, session *domain.UploadSession) error {
+	return s.uow.UploadSessionRepo().UpdateExpiresAt(ctx, session.ID, time.Now().Add(s.cfg.SessionTTL))
+}
+
+// fail records the failure of fileImport, and fails its file if it is still uploading
+func (s *importService) fail(ctx context.Context, fileImport *domain.FileImport, importErr error) error {
+	if err := s.uow.FileImportRepo().UpdateStatus(ctx, fileImport.FileID, domain.ImportStatusFailed, importErr.Error()); err != nil {
+		return err
+	}
+
+	target, err := s.uow.FileRepo().FindById(ctx, fileImport.FileID)
+	if errors.Is(err, domain.ErrFileMetadataNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if target.Status != domain.FileStatusUploading {
+		return nil
+	}
+
+	// the session may already be aborted by the cleanup
+	err = s.files.FinalizeUpload(ctx, *target, importErr, domain.EventTypeMultipartUploadComplete)
+	if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+		return err
+	}
+	return nil
+}
+
+// isPermanent reports whether err would fail the import again
+func isPermanent(err error) bool {
+	for _, permanent := range permanentErrors {
+		if errors.Is(err, permanent) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package fileimport_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"score-play/internal/adapters/remote"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/config"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	fileservice "score-play/internal/core/service/file"
+	"score-play/internal/core/service/fileimport"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// content is a 40 bytes png, imported in 3 parts of partSize bytes
+const (
+	content  = "\x89PNG\r\n\x1a\n0123456789abcdefghijklmnopqrstuv"
+	partSize = 16
+)
+
+type importMocks struct {
+	files   *fileservice.MockFileService
+	uow     *repository.MockUnitOfWork
+	storage *storage.MockStorage
+	cfg     config.FileUploadConfig
+	server  *httptest.Server
+}
+
+// newImportMocks serves body at /match.png of a local source the import is allowed to reach
+func newImportMocks(t *testing.T, body string) importMocks {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/match.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	return importMocks{
+		files:   fileservice.NewMockFileService(),
+		uow:     repository.NewMockUnitOfWork(),
+		storage: storage.NewMockStorage(),
+		cfg: config.FileUploadConfig{
+			SessionTTL: time.Hour,
+			Import: config.ImportConfig{
+				AllowedHosts:         []string{u.Hostname()},
+				AllowPrivateNetworks: true,
+				Timeout:              time.Minute,
+				StaleAfter:           time.Minute,
+				MaxAttempts:          3,
+			},
+		},
+		server: server,
+	}
+}
+
+func (m importMocks) service() port.ImportService {
+	return fileimport.NewImportService(m.uow, m.storage, m.files, remote.NewHTTPSource(m.cfg.Import), m.cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// claim expects the import of path to be claimed for attempt, into the session of a png of content's size
+func (m importMocks) claim(ctx context.Context, path string, attempt int, checksum string) (*domain.FileImport, *domain.UploadSession, *domain.FileMetadata) {
+	fileImport := &domain.FileImport{FileID: uuid.New(), SessionID: uuid.New(), SourceURL: m.server.URL + path, Status: domain.ImportStatusRunning, Attempts: attempt}
+	session := &domain.UploadSession{ID: fileImport.SessionID, FileID: fileImport.FileID, ProviderUploadID: "provider_123", PartSize: partSize}
+	target := &domain.FileMetadata{
+		ID:         fileImport.FileID,
+		MimeType:   "image/png",
+		SizeBytes:  int64(len(content)),
+		Checksum:   checksum,
+		Bucket:     "bucket",
+		StorageKey: "key",
+		Status:     domain.FileStatusUploading,
+	}
+	m.uow.GetFileImportRepoMock().On("ClaimNext", ctx, mock.Anything).Return(fileImport, nil)
+	m.uow.GetUploadSessionRepoMock().On("FindByIDAndActive", mock.Anything, session.ID).Return(session, nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateExpiresAt", mock.Anything, session.ID, mock.Anything).Return(nil)
+	m.uow.GetFileRepoMock().On("FindById", mock.Anything, target.ID).Return(target, nil)
+	return fileImport, session, target
+}
+
+func sum(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func TestImportService_ImportNext_NothingPending(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newImportMocks(t, content)
+	m.uow.GetFileImportRepoMock().On("ClaimNext", ctx, mock.Anything).Return((*domain.FileImport)(nil), domain.ErrImportNotFound)
+
+	// Act
+	imported, err := m.service().ImportNext(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, imported)
+}
+
+func TestImportService_ImportNext_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newImportMocks(t, content)
+	fileImport, session, _ := m.claim(ctx, "/match.png", 1, sum(content))
+	for i, progress := range []int64{16, 32, 40} {
+		part := content[i*partSize : progress]
+		m.storage.
+			On("UploadPart", mock.Anything, "bucket", "key", "provider_123", i+1, []byte(part), int64(len(part)), sum(part)).
+			Return(domain.UploadPart{PartNumber: i + 1, ETag: "etag"}, nil)
+		m.uow.GetFileImportRepoMock().On("UpdateProgress", mock.Anything, fileImport.FileID, progress).Return(nil)
+	}
+	m.files.
+		On("CompleteMultipartUpload", mock.Anything, session.ID, mock.MatchedBy(func(parts []domain.UploadPart) bool { return len(parts) == 3 })).
+		Return(&fileImport.FileID, nil)
+	m.uow.GetFileImportRepoMock().On("UpdateStatus", ctx, fileImport.FileID, domain.ImportStatusCompleted, "").Return(nil)
+
+	// Act
+	imported, err := m.service().ImportNext(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, imported)
+	m.storage.AssertExpectations(t)
+	m.files.AssertExpectations(t)
+	m.uow.GetFileImportRepoMock().AssertExpectations(t)
+}
+
+func TestImportService_ImportNext_FailsFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		checksum string
+		wantErr  error
+	}{
+		{"checksum mismatch", content, sum("other"), domain.ErrMismatchChecksum},
+		{"shorter source", content[:30], "", domain.ErrSizeMismatch},
+		{"content type mismatch", "GIF89a" + content[6:], "", domain.ErrContentTypeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			m := newImportMocks(t, tt.body)
+			fileImport, _, target := m.claim(ctx, "/match.png", 1, tt.checksum)
+			m.storage.On("UploadPart", mock.Anything, "bucket", "key", "provider_123", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(domain.UploadPart{ETag: "etag"}, nil).Maybe()
+			m.uow.GetFileImportRepoMock().On("UpdateProgress", mock.Anything, fileImport.FileID, mock.Anything).Return(nil).Maybe()
+			m.uow.GetFileImportRepoMock().
+				On("UpdateStatus", ctx, fileImport.FileID, domain.ImportStatusFailed, mock.Anything).
+				Return(nil)
+			m.files.
+				On("FinalizeUpload", ctx, *target, mock.MatchedBy(func(err error) bool { return errors.Is(err, tt.wantErr) }), domain.EventTypeMultipartUploadComplete).
+				Return(nil)
+
+			// Act
+			imported, err := m.service().ImportNext(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, imported)
+			m.files.AssertExpectations(t)
+			m.files.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+			m.uow.GetFileImportRepoMock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportService_ImportNext_RetriesUnavailableSource(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newImportMocks(t, content)
+	fileImport, _, _ := m.claim(ctx, "/missing.png", 1, "")
+	m.uow.GetFileImportRepoMock().
+		On("UpdateStatus", ctx, fileImport.FileID, domain.ImportStatusPending, mock.Anything).
+		Return(nil)
+
+	// Act
+	imported, err := m.service().ImportNext(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, imported)
+	m.uow.GetFileImportRepoMock().AssertExpectations(t)
+	m.files.AssertNotCalled(t, "FinalizeUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportService_ImportNext_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newImportMocks(t, content)
+	fileImport, _, target := m.claim(ctx, "/missing.png", 3, "")
+	m.uow.GetFileImportRepoMock().
+		On("UpdateStatus", ctx, fileImport.FileID, domain.ImportStatusFailed, mock.Anything).
+		Return(nil)
+	m.files.
+		On("FinalizeUpload", ctx, *target, mock.MatchedBy(func(err error) bool { return errors.Is(err, domain.ErrSourceUnavailable) }), domain.EventTypeMultipartUploadComplete).
+		Return(nil)
+
+	// Act
+	imported, err := m.service().ImportNext(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, imported)
+	m.uow.GetFileImportRepoMock().AssertExpectations(t)
+	m.files.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package minioevent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -34,6 +35,11 @@ func (m *minioEventService) HandleMessage(ctx context.Context, data []byte) erro
 		return err
 	}
 
+	// resumable uploads buffer the data not filling a part yet in objects of their own
+	if _, ok := domain.TusBufferOwner(decodedKey); ok {
+		return nil
+	}
+
 	index := strings.LastIndex(decodedKey, "/")
 	if index != -1 {
 		fileID = decodedKey[index+1:]
@@ -46,27 +52,51 @@ func (m *minioEventService) HandleMessage(ctx context.Context, data []byte) erro
 	m.logger.Info("handling event ", "eventtype", bucketNotif.EventName, "key", decodedKey, "fileID", fileUUID.String())
 
 	switch bucketNotif.EventName {
-	case "s3:ObjectCreated:Put":
+	case "s3:ObjectCreated:Put", "s3:ObjectCreated:Post":
 		eventType = domain.EventTypeSimpleUploadComplete
 	case "s3:ObjectCreated:CompleteMultipartUpload":
 		eventType = domain.EventTypeMultipartUploadComplete
+	case "s3:ObjectTransition:Complete":
+		eventType = domain.EventTypeTransitionComplete
+	case "s3:ObjectCreated:Copy":
+		eventType = domain.EventTypeCopyComplete
 	default:
 		eventType = domain.EventTypeUnknown
 	}
 
+	// copies are created completed by the API from an already validated source
+	if eventType == domain.EventTypeCopyComplete {
+		return nil
+	}
+
 	fileMetadata, err := m.uof.FileRepo().FindById(ctx, fileUUID)
+	if errors.Is(err, domain.ErrFileMetadataNotFound) {
+		// version objects are keyed by the version id
+		return m.handleVersionMessage(ctx, fileUUID, eventType)
+	}
 	if err != nil {
 		return err
 	}
 
-	info, err := m.storage.GetObjectInfo(ctx, fileMetadata.StorageKey)
+	// copies of large sources and clips are composed with a multipart copy, their rows are already completed
+	if eventType == domain.EventTypeMultipartUploadComplete && fileMetadata.SourceFileID != nil && fileMetadata.Status == domain.FileStatusCompleted {
+		return nil
+	}
+
+	info, err := m.storage.GetObjectInfo(ctx, fileMetadata.Bucket, fileMetadata.StorageKey)
 	if err != nil {
 		return err
 	}
 
+	// lifecycle transition: only the storage class changed, the object was validated on upload
+	if eventType == domain.EventTypeTransitionComplete {
+		return m.uof.FileRepo().UpdateStorageClass(ctx, fileMetadata.ID, info.StorageClass)
+	}
+
+	// uploads streamed through the api without checksum (tus) declare none, the api computed the one of the file
 	storageChecksum := info.UserMetadata["Checksum-Sha256"]
 
-	if storageChecksum != fileMetadata.Checksum {
+	if storageChecksum != "" && storageChecksum != fileMetadata.Checksum {
 		failedUploadErr = domain.ErrMismatchChecksum
 	}
 	if info.Size != fileMetadata.SizeBytes {
@@ -74,7 +104,7 @@ func (m *minioEventService) HandleMessage(ctx context.Context, data []byte) erro
 	}
 
 	//sniff header
-	bytes, err := m.storage.GetHeaderBytes(ctx, fileMetadata.StorageKey, 512)
+	bytes, err := m.storage.GetHeaderBytes(ctx, fileMetadata.Bucket, fileMetadata.StorageKey, 512)
 	if err != nil {
 		return err
 	}
@@ -90,3 +120,50 @@ func (m *minioEventService) HandleMessage(ctx context.Context, data []byte) erro
 	}
 	return failedUploadErr
 }
+
+// handleVersionMessage validates an uploaded file version
+func (m *minioEventService) handleVersionMessage(ctx context.Context, versionID uuid.UUID, eventType domain.EventType) error {
+	var failedUploadErr error
+
+	version, err := m.uof.FileVersionRepo().FindByID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+
+	info, err := m.storage.GetObjectInfo(ctx, version.Bucket, version.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	if eventType == domain.EventTypeTransitionComplete {
+		fileMetadata, err := m.uof.FileRepo().FindById(ctx, version.FileID)
+		if err != nil {
+			return err
+		}
+		if fileMetadata.CurrentVersion != version.Version {
+			return nil
+		}
+		return m.uof.FileRepo().UpdateStorageClass(ctx, fileMetadata.ID, info.StorageClass)
+	}
+
+	if info.UserMetadata["Checksum-Sha256"] != version.Checksum {
+		failedUploadErr = domain.ErrMismatchChecksum
+	}
+	if info.Size != version.SizeBytes {
+		failedUploadErr = domain.ErrSizeMismatch
+	}
+
+	bytes, err := m.storage.GetHeaderBytes(ctx, version.Bucket, version.StorageKey, 512)
+	if err != nil {
+		return err
+	}
+	if http.DetectContentType(bytes) != version.MimeType {
+		failedUploadErr = domain.ErrContentTypeMismatch
+	}
+
+	err = m.fileService.FinalizeVersionUpload(ctx, *version, failedUploadErr, eventType)
+	if err != nil {
+		failedUploadErr = fmt.Errorf("%w : %w", failedUploadErr, err)
+	}
+	return failedUploadErr
+}
@@ -0,0 +1,21 @@
+// Package object holds the rules on stored objects shared by the services, files sharing the object of an identical one.
+package object
+
+import (
+	"context"
+	"score-play/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUnreferenced deletes the object of the file fileID from fileStorage, unless another file or version still shares it
+func DeleteUnreferenced(ctx context.Context, uow port.UnitOfWork, fileStorage port.FileStorage, fileID uuid.UUID, bucket string, storageKey string) error {
+	references, err := uow.FileRepo().CountReferences(ctx, bucket, storageKey, fileID)
+	if err != nil {
+		return err
+	}
+	if references > 0 {
+		return nil
+	}
+	return fileStorage.DeleteObject(ctx, bucket, storageKey)
+}
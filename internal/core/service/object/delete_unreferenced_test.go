@@ -0,0 +1,65 @@
+package object_test
+
+import (
+	"context"
+	"errors"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/service/object"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteUnreferenced(t *testing.T) {
+	fileID := uuid.New()
+
+	t.Run("deletes an object no other file references", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		mockUow.GetFileRepoMock().On("CountReferences", ctx, "bucket", "key", fileID).Return(0, nil)
+		mockStorage.On("DeleteObject", ctx, "bucket", "key").Return(nil)
+
+		// Act
+		err := object.DeleteUnreferenced(ctx, mockUow, mockStorage, fileID, "bucket", "key")
+
+		// Assert
+		assert.NoError(t, err)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("keeps an object shared with another file", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		mockUow.GetFileRepoMock().On("CountReferences", ctx, "bucket", "key", fileID).Return(1, nil)
+
+		// Act
+		err := object.DeleteUnreferenced(ctx, mockUow, mockStorage, fileID, "bucket", "key")
+
+		// Assert
+		assert.NoError(t, err)
+		mockStorage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps the object when the references cannot be counted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockUow := repository.NewMockUnitOfWork()
+		mockStorage := storage.NewMockStorage()
+		countErr := errors.New("db down")
+		mockUow.GetFileRepoMock().On("CountReferences", ctx, "bucket", "key", fileID).Return(0, countErr)
+
+		// Act
+		err := object.DeleteUnreferenced(ctx, mockUow, mockStorage, fileID, "bucket", "key")
+
+		// Assert
+		assert.ErrorIs(t, err, countErr)
+		mockStorage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,163 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type reconcileService struct {
+	uow         port.UnitOfWork
+	fileStorage port.FileStorage
+	buckets     []string
+	batchSize   int
+	logger      *slog.Logger
+}
+
+// NewReconcileService creates a service comparing the objects and uploads of buckets with the database
+func NewReconcileService(uow port.UnitOfWork, fileStorage port.FileStorage, buckets []string, batchSize int, logger *slog.Logger) port.ReconcileService {
+	return &reconcileService{
+		uow:         uow,
+		fileStorage: fileStorage,
+		buckets:     buckets,
+		batchSize:   batchSize,
+		logger:      logger,
+	}
+}
+
+// Reconcile reports the objects without file, the completed files without object and the uploads without open session.
+// Unless opts.DryRun is set, orphan objects are deleted, files without object are failed and stray uploads are aborted.
+func (s *reconcileService) Reconcile(ctx context.Context, opts domain.ReconcileOptions) (*domain.ReconcileReport, error) {
+	report := &domain.ReconcileReport{StartedAt: time.Now(), DryRun: opts.DryRun}
+	olderThan := report.StartedAt.Add(-opts.MinAge)
+
+	for _, bucket := range s.buckets {
+		if err := s.reconcileObjects(ctx, bucket, olderThan, report); err != nil {
+			return report, err
+		}
+		if err := s.reconcileUploads(ctx, bucket, olderThan, report); err != nil {
+			return report, err
+		}
+	}
+	if err := s.reconcileFiles(ctx, report); err != nil {
+		return report, err
+	}
+
+	report.FinishedAt = time.Now()
+	s.logger.Info("reconciliation completed",
+		"dry_run", report.DryRun,
+		"orphan_objects", len(report.OrphanObjects),
+		"missing_objects", len(report.MissingObjects),
+		"stray_uploads", len(report.StrayUploads),
+		"repaired", report.Repaired,
+		"errors", len(report.Errors),
+	)
+	return report, nil
+}
+
+// reconcileObjects finds the objects of bucket no file or version is stored at, or buffering an upload to
+func (s *reconcileService) reconcileObjects(ctx context.Context, bucket string, olderThan time.Time, report *domain.ReconcileReport) error {
+	return s.fileStorage.ListObjects(ctx, bucket, func(object domain.StoredObject) error {
+		report.ObjectsScanned++
+		if object.LastModified.After(olderThan) {
+			return nil
+		}
+
+		// the buffers of resumable uploads live as long as the file they are uploaded to
+		key := object.Key
+		if owner, ok := domain.TusBufferOwner(key); ok {
+			key = owner
+		}
+		exists, err := s.uow.FileRepo().ExistsByStorageKey(ctx, object.Bucket, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		report.OrphanObjects = append(report.OrphanObjects, object)
+		s.repair(report, func() error {
+			return s.fileStorage.DeleteObject(ctx, object.Bucket, object.Key)
+		}, "delete orphan object %s/%s", object.Bucket, object.Key)
+		return nil
+	})
+}
+
+// reconcileUploads finds the multipart uploads of bucket without open session
+func (s *reconcileService) reconcileUploads(ctx context.Context, bucket string, olderThan time.Time, report *domain.ReconcileReport) error {
+	return s.fileStorage.ListIncompleteUploads(ctx, bucket, func(upload domain.IncompleteUpload) error {
+		report.UploadsScanned++
+		if upload.Initiated.After(olderThan) {
+			return nil
+		}
+
+		session, err := s.uow.UploadSessionRepo().FindByProviderUploadID(ctx, upload.UploadID)
+		if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+			return err
+		}
+		if session != nil && session.Status == domain.UploadSessionStatusOpen {
+			return nil
+		}
+
+		report.StrayUploads = append(report.StrayUploads, upload)
+		s.repair(report, func() error {
+			return s.fileStorage.AbortMultipartUpload(ctx, upload.Bucket, upload.Key, upload.UploadID)
+		}, "abort stray upload %s of %s/%s", upload.UploadID, upload.Bucket, upload.Key)
+		return nil
+	})
+}
+
+// reconcileFiles finds the completed files whose object is gone, page by page
+func (s *reconcileService) reconcileFiles(ctx context.Context, report *domain.ReconcileReport) error {
+	after := uuid.Nil
+	for {
+		files, err := s.uow.FileRepo().FindByStatus(ctx, domain.FileStatusCompleted, after, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			after = file.ID
+			report.FilesScanned++
+
+			_, err := s.fileStorage.GetObjectInfo(ctx, file.Bucket, file.StorageKey)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, domain.ErrObjectNotFound) {
+				report.Errors = append(report.Errors, fmt.Sprintf("check object of file %s: %v", file.ID, err))
+				continue
+			}
+
+			report.MissingObjects = append(report.MissingObjects, domain.MissingObject{FileID: file.ID, Bucket: file.Bucket, Key: file.StorageKey})
+			s.repair(report, func() error {
+				return s.uow.FileRepo().UpdateStatus(ctx, file.ID, domain.FileStatusFailed)
+			}, "fail file %s", file.ID)
+		}
+
+		if len(files) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// repair runs fix unless the report is a dry run, a failure is added to the report
+func (s *reconcileService) repair(report *domain.ReconcileReport, fix func() error, format string, args ...any) {
+	if report.DryRun {
+		return
+	}
+	if err := fix(); err != nil {
+		message := fmt.Sprintf(format, args...) + ": " + err.Error()
+		s.logger.Error("Failed to repair", "err", message)
+		report.Errors = append(report.Errors, message)
+		return
+	}
+	report.Repaired++
+}
@@ -0,0 +1,130 @@
+package reconcile_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/service/reconcile"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const bucket = "videos"
+
+func TestReconcileService_Reconcile_DryRun(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := reconcile.NewReconcileService(mockUow, mockStorage, []string{bucket}, 2, slog.Default())
+
+	old := time.Now().Add(-2 * time.Hour)
+	orphan := domain.StoredObject{Bucket: bucket, Key: "default/orphan", LastModified: old}
+	referenced := domain.StoredObject{Bucket: bucket, Key: "default/referenced", LastModified: old}
+	recent := domain.StoredObject{Bucket: bucket, Key: "default/recent", LastModified: time.Now()}
+	buffer := domain.StoredObject{Bucket: bucket, Key: domain.TusBufferKey("default/referenced", 2), LastModified: old}
+	stray := domain.IncompleteUpload{Bucket: bucket, Key: "default/stray", UploadID: "stray", Initiated: old}
+	open := domain.IncompleteUpload{Bucket: bucket, Key: "default/open", UploadID: "open", Initiated: old}
+	missing := domain.FileMetadata{ID: uuid.New(), Bucket: bucket, StorageKey: "default/missing"}
+	stored := domain.FileMetadata{ID: uuid.New(), Bucket: bucket, StorageKey: "default/referenced"}
+
+	mockStorage.On("ListObjects", ctx, bucket).Return([]domain.StoredObject{orphan, referenced, recent, buffer}, nil)
+	mockStorage.On("ListIncompleteUploads", ctx, bucket).Return([]domain.IncompleteUpload{stray, open}, nil)
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileRepo.On("ExistsByStorageKey", ctx, bucket, orphan.Key).Return(false, nil)
+	mockFileRepo.On("ExistsByStorageKey", ctx, bucket, referenced.Key).Return(true, nil)
+	mockSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockSessionRepo.On("FindByProviderUploadID", ctx, "stray").Return((*domain.UploadSession)(nil), domain.ErrSessionNotFound)
+	mockSessionRepo.On("FindByProviderUploadID", ctx, "open").Return(&domain.UploadSession{Status: domain.UploadSessionStatusOpen}, nil)
+	mockFileRepo.On("FindByStatus", ctx, domain.FileStatusCompleted, uuid.Nil, 2).Return([]domain.FileMetadata{missing, stored}, nil)
+	mockFileRepo.On("FindByStatus", ctx, domain.FileStatusCompleted, stored.ID, 2).Return([]domain.FileMetadata{}, nil)
+	mockStorage.On("GetObjectInfo", ctx, bucket, missing.StorageKey).Return((*minio.ObjectInfo)(nil), domain.ErrObjectNotFound)
+	mockStorage.On("GetObjectInfo", ctx, bucket, stored.StorageKey).Return(&minio.ObjectInfo{}, nil)
+
+	// Act
+	report, err := service.Reconcile(ctx, domain.ReconcileOptions{DryRun: true, MinAge: time.Hour})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.ObjectsScanned)
+	assert.Equal(t, 2, report.UploadsScanned)
+	assert.Equal(t, 2, report.FilesScanned)
+	assert.Equal(t, []domain.StoredObject{orphan}, report.OrphanObjects)
+	assert.Equal(t, []domain.IncompleteUpload{stray}, report.StrayUploads)
+	assert.Equal(t, []domain.MissingObject{{FileID: missing.ID, Bucket: bucket, Key: missing.StorageKey}}, report.MissingObjects)
+	assert.Equal(t, 0, report.Repaired)
+	mockStorage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything, mock.Anything)
+	mockStorage.AssertNotCalled(t, "AbortMultipartUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockFileRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockStorage.AssertExpectations(t)
+	mockFileRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestReconcileService_Reconcile_Repair(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := reconcile.NewReconcileService(mockUow, mockStorage, []string{bucket}, 10, slog.Default())
+
+	old := time.Now().Add(-2 * time.Hour)
+	orphan := domain.StoredObject{Bucket: bucket, Key: "default/orphan", LastModified: old}
+	aborted := domain.IncompleteUpload{Bucket: bucket, Key: "default/aborted", UploadID: "aborted", Initiated: old}
+	missing := domain.FileMetadata{ID: uuid.New(), Bucket: bucket, StorageKey: "default/missing"}
+
+	mockStorage.On("ListObjects", ctx, bucket).Return([]domain.StoredObject{orphan}, nil)
+	mockStorage.On("ListIncompleteUploads", ctx, bucket).Return([]domain.IncompleteUpload{aborted}, nil)
+	mockFileRepo := mockUow.GetFileRepoMock()
+	mockFileRepo.On("ExistsByStorageKey", ctx, bucket, orphan.Key).Return(false, nil)
+	mockSessionRepo := mockUow.GetUploadSessionRepoMock()
+	mockSessionRepo.On("FindByProviderUploadID", ctx, "aborted").Return(&domain.UploadSession{Status: domain.UploadSessionStatusAborted}, nil)
+	mockFileRepo.On("FindByStatus", ctx, domain.FileStatusCompleted, uuid.Nil, 10).Return([]domain.FileMetadata{missing}, nil)
+	mockStorage.On("GetObjectInfo", ctx, bucket, missing.StorageKey).Return((*minio.ObjectInfo)(nil), domain.ErrObjectNotFound)
+	mockStorage.On("DeleteObject", ctx, bucket, orphan.Key).Return(nil)
+	mockStorage.On("AbortMultipartUpload", ctx, bucket, aborted.Key, "aborted").Return(errors.New("unreachable"))
+	mockFileRepo.On("UpdateStatus", ctx, missing.ID, domain.FileStatusFailed).Return(nil)
+
+	// Act
+	report, err := service.Reconcile(ctx, domain.ReconcileOptions{MinAge: time.Hour})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, report.OrphanObjects, 1)
+	assert.Len(t, report.StrayUploads, 1)
+	assert.Len(t, report.MissingObjects, 1)
+	assert.Equal(t, 2, report.Repaired)
+	assert.Len(t, report.Errors, 1)
+	mockStorage.AssertExpectations(t)
+	mockFileRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestReconcileService_Reconcile_ListError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUow := repository.NewMockUnitOfWork()
+	mockStorage := storage.NewMockStorage()
+	service := reconcile.NewReconcileService(mockUow, mockStorage, []string{bucket}, 10, slog.Default())
+	listErr := errors.New("unreachable")
+
+	mockStorage.On("ListObjects", ctx, bucket).Return([]domain.StoredObject{}, listErr)
+
+	// Act
+	report, err := service.Reconcile(ctx, domain.ReconcileOptions{DryRun: true})
+
+	// Assert
+	assert.ErrorIs(t, err, listErr)
+	assert.NotNil(t, report)
+	mockStorage.AssertNotCalled(t, "ListIncompleteUploads", mock.Anything, mock.Anything)
+	mockStorage.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package tus
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTusService is a mock implementation of TusService
+type MockTusService struct {
+	mock.Mock
+}
+
+func (m *MockTusService) MaxSize() int64 {
+	args := m.Called()
+	return args.Get(0).(int64)
+}
+
+func (m *MockTusService) Create(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	args := m.Called(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
+func (m *MockTusService) Offset(ctx context.Context, id uuid.UUID) (int64, int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+// Write records the content of data so expectations can match it
+func (m *MockTusService) Write(ctx context.Context, id uuid.UUID, offset int64, data io.Reader, checksumSha256 string) (int64, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return 0, err
+	}
+	args := m.Called(ctx, id, offset, content, checksumSha256)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTusService) Terminate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,110 @@
+package tus
+
+import (
+	"context"
+	"log/slog"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type tusService struct {
+	files       port.FileService
+	uow         port.UnitOfWork
+	fileStorage port.FileStorage
+	maxSize     int64
+	logger      *slog.Logger
+}
+
+// NewTusService creates a tus service receiving the content of the multipart uploads of files.
+// Full parts are sent to the storage as they are received, the rest is buffered in an object until the part is full.
+// A single request writes to an upload at a time, holding a lease on its session.
+func NewTusService(files port.FileService, uow port.UnitOfWork, fileStorage port.FileStorage, maxSize int64, logger *slog.Logger) port.TusService {
+	return &tusService{
+		files:       files,
+		uow:         uow,
+		fileStorage: fileStorage,
+		maxSize:     maxSize,
+		logger:      logger,
+	}
+}
+
+// MaxSize is the size of the largest multipart upload
+func (s *tusService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Create opens a multipart upload session
+func (s *tusService) Create(ctx context.Context, fileName string, contentType string, sizeBytes int64, checksumSha256 string, tags []string) (*uuid.UUID, error) {
+	sessionID, _, err := s.files.RequestResumableUpload(ctx, fileName, contentType, sizeBytes, checksumSha256, tags)
+	if err != nil {
+		return nil, err
+	}
+	return sessionID, nil
+}
+
+// Offset returns the offset of the session id and the size of its file
+func (s *tusService) Offset(ctx context.Context, id uuid.UUID) (int64, int64, error) {
+	session, target, err := s.files.GetUploadSession(ctx, id)
+	if err != nil {
+		return 0, 0, err
+	}
+	return session.UploadOffset, target.SizeBytes, nil
+}
+
+// Terminate aborts the session id and deletes its buffer
+func (s *tusService) Terminate(ctx context.Context, id uuid.UUID) error {
+	return s.leased(ctx, id, func(holder uuid.UUID) error {
+		session, target, err := s.files.GetUploadSession(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.files.AbortMultipartUpload(ctx, id); err != nil {
+			return err
+		}
+		if session.UploadOffset%int64(session.PartSize) != 0 {
+			s.deleteBuffer(ctx, target, bufferedPart(session))
+		}
+		return nil
+	})
+}
+
+// leaseDuration is how long a request holds an upload, writes renew the lease after each part they store
+const leaseDuration = 5 * time.Minute
+
+// leased runs fn holding the lease of the upload id, it fails with domain.ErrUploadLocked while another request holds it
+func (s *tusService) leased(ctx context.Context, id uuid.UUID, fn func(holder uuid.UUID) error) error {
+	holder := uuid.New()
+	if err := s.renewLease(ctx, id, holder); err != nil {
+		return err
+	}
+	defer func() {
+		// released even when the request is canceled, the lease would otherwise lock the upload until it expires
+		if err := s.uow.UploadSessionRepo().ReleaseLease(context.WithoutCancel(ctx), id, holder); err != nil {
+			s.logger.Warn("failed to release upload lease", "session_id", id, "error", err)
+		}
+	}()
+	return fn(holder)
+}
+
+// renewLease gives the upload id to holder for leaseDuration
+func (s *tusService) renewLease(ctx context.Context, id uuid.UUID, holder uuid.UUID) error {
+	now := time.Now()
+	return s.uow.UploadSessionRepo().AcquireLease(ctx, id, holder, now, now.Add(leaseDuration))
+}
+
+// bufferedPart is the number of the part the offset of session is in
+func bufferedPart(session *domain.UploadSession) int {
+	return int(session.UploadOffset/int64(session.PartSize)) + 1
+}
+
+// deleteBuffer deletes the buffer of part partNumber. A failure is only logged, the buffer is not read again
+// and reconciliation deletes it once the file is gone.
+func (s *tusService) deleteBuffer(ctx context.Context, target *domain.FileMetadata, partNumber int) {
+	key := domain.TusBufferKey(target.StorageKey, partNumber)
+	if err := s.fileStorage.DeleteObject(ctx, target.Bucket, key); err != nil {
+		s.logger.Warn("failed to delete upload buffer", "bucket", target.Bucket, "key", key, "error", err)
+	}
+}
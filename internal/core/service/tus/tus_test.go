@@ -0,0 +1,59 @@
+package tus_test
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTusService_Create(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	sessionID := uuid.New()
+	m.files.On("RequestResumableUpload", ctx, "match.mp4", "video/mp4", int64(10), "sum", []string{"goal"}).Return(&sessionID, partSize, nil)
+
+	// Act
+	id, err := m.service().Create(ctx, "match.mp4", "video/mp4", 10, "sum", []string{"goal"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, *id)
+	m.files.AssertExpectations(t)
+}
+
+func TestTusService_Offset(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 6)
+
+	// Act
+	offset, size, err := m.service().Offset(ctx, session.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), offset)
+	assert.Equal(t, int64(10), size)
+}
+
+func TestTusService_Terminate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 6)
+	m.files.On("AbortMultipartUpload", ctx, session.ID).Return(nil)
+	m.storage.On("DeleteObject", ctx, "bucket", domain.TusBufferKey("key", 2)).Return(nil)
+
+	// Act
+	err := m.service().Terminate(ctx, session.ID)
+
+	// Assert
+	require.NoError(t, err)
+	m.files.AssertExpectations(t)
+	m.storage.AssertExpectations(t)
+}
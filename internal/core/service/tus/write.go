@@ -0,0 +1,245 @@
+package tus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"score-play/internal/core/domain"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// listPartsPageSize is the number of parts listed per storage call when completing an upload
+const listPartsPageSize = 1000
+
+// partBuffers holds the part buffers of the writes done, reused by the next ones rather than allocating a part per request
+var partBuffers = sync.Pool{New: func() any { return new([]byte) }}
+
+// getPartBuffer returns a buffer of size bytes from partBuffers, to give back with putPartBuffer
+func getPartBuffer(size int64) []byte {
+	buffer := partBuffers.Get().(*[]byte)
+	if int64(cap(*buffer)) < size {
+		*buffer = make([]byte, size)
+	}
+	return (*buffer)[:size]
+}
+
+// putPartBuffer gives buffer back to partBuffers
+func putPartBuffer(buffer []byte) {
+	partBuffers.Put(&buffer)
+}
+
+// Write appends data to the session id. The offset is committed after each part stored, so a client dropping
+// mid-request resumes from its last part. With checksumSha256 the offset is only committed once the whole data is stored
+// and matches it, the parts sent before a failure are sent again by the next write.
+func (s *tusService) Write(ctx context.Context, id uuid.UUID, offset int64, data io.Reader, checksumSha256 string) (int64, error) {
+	var newOffset int64
+	err := s.leased(ctx, id, func(holder uuid.UUID) error {
+		var err error
+		newOffset, err = s.write(ctx, id, holder, offset, data, checksumSha256)
+		return err
+	})
+	return newOffset, err
+}
+
+func (s *tusService) write(ctx context.Context, id uuid.UUID, holder uuid.UUID, offset int64, data io.Reader, checksumSha256 string) (int64, error) {
+	session, target, err := s.files.GetUploadSession(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if offset != session.UploadOffset {
+		return 0, fmt.Errorf("%w: upload is at %d, not %d", domain.ErrOffsetMismatch, session.UploadOffset, offset)
+	}
+
+	// one more byte than the upload misses is read to detect data beyond its size
+	h := sha256.New()
+	body := io.TeeReader(io.LimitReader(data, target.SizeBytes-offset+1), h)
+	if offset == target.SizeBytes {
+		if n, _ := io.ReadFull(body, make([]byte, 1)); n > 0 {
+			return 0, fmt.Errorf("%w: upload is already %d bytes", domain.ErrSizeMismatch, target.SizeBytes)
+		}
+		return offset, nil
+	}
+
+	partSize := int64(session.PartSize)
+	partNumber := bufferedPart(session)
+	start := int64(partNumber-1) * partSize
+	filled := offset - start
+	buffer := getPartBuffer(partSize)
+	defer putPartBuffer(buffer)
+	if filled > 0 {
+		head, err := s.fileStorage.GetHeaderBytes(ctx, target.Bucket, domain.TusBufferKey(target.StorageKey, partNumber), filled)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the buffer of part %d: %w", partNumber, err)
+		}
+		if int64(len(head)) != filled {
+			return 0, fmt.Errorf("buffer of part %d has %d bytes, expected %d", partNumber, len(head), filled)
+		}
+		copy(buffer, head)
+	}
+	previousBuffer := 0
+	if filled > 0 {
+		previousBuffer = partNumber
+	}
+	fileHash, err := resumeChecksum(session)
+	if err != nil {
+		return 0, err
+	}
+
+	// parts are sent once full, the last one once the upload is
+	received := int64(0)
+	committed := offset
+	var readErr error
+	for start < target.SizeBytes {
+		length := min(partSize, target.SizeBytes-start)
+		n, err := io.ReadFull(body, buffer[filled:length])
+		if fileHash != nil {
+			fileHash.Write(buffer[filled : filled+int64(n)])
+		}
+		filled += int64(n)
+		received += int64(n)
+		if filled < length {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				readErr = err
+			}
+			break
+		}
+		if err := s.uploadPart(ctx, session, target, partNumber, buffer[:filled]); err != nil {
+			return 0, err
+		}
+		if err := s.renewLease(ctx, id, holder); err != nil {
+			return 0, err
+		}
+		// the last part is committed by the completion
+		if checksumSha256 == "" && start+length < target.SizeBytes {
+			if err := s.commitOffset(ctx, id, start+length, fileHash); err != nil {
+				return 0, err
+			}
+			committed = start + length
+			if previousBuffer != 0 {
+				s.deleteBuffer(ctx, target, previousBuffer)
+				previousBuffer = 0
+			}
+		}
+		partNumber++
+		start += length
+		filled = 0
+	}
+
+	newOffset := offset + received
+	if newOffset == target.SizeBytes {
+		if n, _ := io.ReadFull(body, make([]byte, 1)); n > 0 {
+			return 0, fmt.Errorf("%w: data exceeds the %d bytes of the upload", domain.ErrSizeMismatch, target.SizeBytes)
+		}
+	}
+	if checksumSha256 != "" {
+		// the checksum of an interrupted request cannot be checked, none of its data is kept
+		if readErr != nil {
+			return 0, readErr
+		}
+		if got := base64.StdEncoding.EncodeToString(h.Sum(nil)); got != checksumSha256 {
+			return 0, fmt.Errorf("%w: data is %s, expected %s", domain.ErrMismatchChecksum, got, checksumSha256)
+		}
+	}
+	if readErr != nil {
+		s.logger.Warn("upload interrupted, keeping the data received", "session_id", id, "offset", newOffset, "error", readErr)
+	}
+
+	completed := newOffset == target.SizeBytes
+	if completed {
+		// files created without checksum get the one of the data received, before the worker validates them
+		if target.Checksum == "" && fileHash != nil {
+			checksum := base64.StdEncoding.EncodeToString(fileHash.Sum(nil))
+			if err := s.uow.FileRepo().UpdateChecksum(ctx, target.ID, checksum); err != nil {
+				return 0, fmt.Errorf("failed to set the checksum of the file: %w", err)
+			}
+		}
+		if err := s.complete(ctx, session, target); err != nil {
+			return 0, err
+		}
+	} else if filled > 0 && received > 0 {
+		// buffered even when the client is gone, the data received is kept
+		if err := s.fileStorage.PutObject(context.WithoutCancel(ctx), target.Bucket, domain.TusBufferKey(target.StorageKey, partNumber), bytes.NewReader(buffer[:filled]), filled); err != nil {
+			return 0, fmt.Errorf("failed to buffer part %d: %w", partNumber, err)
+		}
+	}
+
+	// the session of a completed upload may already be finalized
+	if newOffset != committed {
+		err = s.commitOffset(ctx, id, newOffset, fileHash)
+		if err != nil && !(completed && errors.Is(err, domain.ErrSessionNotFound)) {
+			return 0, err
+		}
+	}
+
+	if previousBuffer != 0 && (completed || previousBuffer != partNumber) {
+		s.deleteBuffer(ctx, target, previousBuffer)
+	}
+	return newOffset, nil
+}
+
+// commitOffset records that the session id has stored offset bytes, with the state of fileHash over them.
+// It is saved even when the client is gone, the data is already stored.
+func (s *tusService) commitOffset(ctx context.Context, id uuid.UUID, offset int64, fileHash hash.Hash) error {
+	var state []byte
+	if fileHash != nil {
+		var err error
+		if state, err = fileHash.(encoding.BinaryMarshaler).MarshalBinary(); err != nil {
+			return fmt.Errorf("failed to save the checksum state: %w", err)
+		}
+	}
+	return s.uow.UploadSessionRepo().UpdateOffset(context.WithoutCancel(ctx), id, offset, state)
+}
+
+// resumeChecksum returns the SHA-256 of the bytes session has received, to go on with the next ones.
+// It is nil for the sessions whose offset was saved without the state of their checksum.
+func resumeChecksum(session *domain.UploadSession) (hash.Hash, error) {
+	h := sha256.New()
+	if session.UploadOffset == 0 {
+		return h, nil
+	}
+	if len(session.ChecksumState) == 0 {
+		return nil, nil
+	}
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(session.ChecksumState); err != nil {
+		return nil, fmt.Errorf("failed to restore the checksum state: %w", err)
+	}
+	return h, nil
+}
+
+// uploadPart sends part partNumber with its checksum, the storage rejects it if it is altered on the way
+func (s *tusService) uploadPart(ctx context.Context, session *domain.UploadSession, target *domain.FileMetadata, partNumber int, data []byte) error {
+	sum := sha256.Sum256(data)
+	checksum := base64.StdEncoding.EncodeToString(sum[:])
+	if _, err := s.fileStorage.UploadPart(ctx, target.Bucket, target.StorageKey, session.ProviderUploadID, partNumber, bytes.NewReader(data), int64(len(data)), checksum); err != nil {
+		return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	return nil
+}
+
+// complete completes the session with the parts the storage has received, the file is then finalized as any multipart upload
+func (s *tusService) complete(ctx context.Context, session *domain.UploadSession, target *domain.FileMetadata) error {
+	var parts []domain.UploadPart
+	marker := 0
+	for {
+		page, next, err := s.fileStorage.ListPartsPaginated(ctx, target.Bucket, target.StorageKey, session.ProviderUploadID, listPartsPageSize, marker)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, page...)
+		if next == 0 || next == marker {
+			break
+		}
+		marker = next
+	}
+
+	_, err := s.files.CompleteMultipartUpload(ctx, session.ID, parts)
+	return err
+}
@@ -0,0 +1,265 @@
+package tus_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"score-play/internal/adapters/repository"
+	"score-play/internal/adapters/storage"
+	"score-play/internal/core/domain"
+	"score-play/internal/core/port"
+	fileservice "score-play/internal/core/service/file"
+	"score-play/internal/core/service/tus"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// partSize is small enough for the tests to span several parts
+const partSize = 4
+
+type tusMocks struct {
+	files   *fileservice.MockFileService
+	uow     *repository.MockUnitOfWork
+	storage *storage.MockStorage
+}
+
+func newTusMocks() tusMocks {
+	return tusMocks{
+		files:   fileservice.NewMockFileService(),
+		uow:     repository.NewMockUnitOfWork(),
+		storage: storage.NewMockStorage(),
+	}
+}
+
+func (m tusMocks) service() port.TusService {
+	return tus.NewTusService(m.files, m.uow, m.storage, 1000, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// openUpload expects the session of a 10 bytes upload at offset
+func (m tusMocks) openUpload(ctx context.Context, offset int64) (*domain.UploadSession, *domain.FileMetadata) {
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New(), ProviderUploadID: "provider_123", PartSize: partSize, UploadOffset: offset}
+	target := &domain.FileMetadata{ID: session.FileID, Bucket: "bucket", StorageKey: "key", SizeBytes: 10}
+	m.files.On("GetUploadSession", ctx, session.ID).Return(session, target, nil)
+	m.uow.GetUploadSessionRepoMock().On("AcquireLease", ctx, session.ID, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("ReleaseLease", mock.Anything, session.ID, mock.Anything).Return(nil)
+	return session, target
+}
+
+func sum(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// checksumState is the state of the SHA-256 of data saved with the offset
+func checksumState(t *testing.T, data string) []byte {
+	h := sha256.New()
+	h.Write([]byte(data))
+	state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	require.NoError(t, err)
+	return state
+}
+
+func TestTusService_Write_BuffersIncompletePart(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 0)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 1, []byte("abcd"), int64(4), sum("abcd")).Return(domain.UploadPart{PartNumber: 1, ETag: "e1"}, nil)
+	m.storage.On("PutObject", mock.Anything, "bucket", domain.TusBufferKey("key", 2), []byte("ef"), int64(2)).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, int64(6), mock.Anything).Return(nil)
+
+	// Act
+	offset, err := m.service().Write(ctx, session.ID, 0, strings.NewReader("abcdef"), sum("abcdef"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), offset)
+	m.storage.AssertExpectations(t)
+	m.uow.GetUploadSessionRepoMock().AssertExpectations(t)
+	m.files.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTusService_Write_CompletesFromBuffer(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 6)
+	session.ChecksumState = checksumState(t, "abcdef")
+	parts := []domain.UploadPart{{PartNumber: 1, ETag: "e1"}, {PartNumber: 2, ETag: "e2"}, {PartNumber: 3, ETag: "e3"}}
+	fileID := session.FileID
+	m.storage.On("GetHeaderBytes", ctx, "bucket", domain.TusBufferKey("key", 2), int64(2)).Return([]byte("ef"), nil)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 2, []byte("efgh"), int64(4), sum("efgh")).Return(domain.UploadPart{PartNumber: 2, ETag: "e2"}, nil)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 3, []byte("ij"), int64(2), sum("ij")).Return(domain.UploadPart{PartNumber: 3, ETag: "e3"}, nil)
+	m.storage.On("ListPartsPaginated", ctx, "bucket", "key", "provider_123", 1000, 0).Return(parts, 0, nil)
+	m.files.On("CompleteMultipartUpload", ctx, session.ID, parts).Return(&fileID, nil)
+	m.uow.GetFileRepoMock().On("UpdateChecksum", ctx, fileID, sum("abcdefghij")).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, int64(8), checksumState(t, "abcdefgh")).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, int64(10), mock.Anything).Return(domain.ErrSessionNotFound)
+	m.storage.On("DeleteObject", ctx, "bucket", domain.TusBufferKey("key", 2)).Return(nil)
+
+	// Act
+	offset, err := m.service().Write(ctx, session.ID, 6, strings.NewReader("ghij"), "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), offset)
+	m.storage.AssertExpectations(t)
+	m.files.AssertExpectations(t)
+	m.uow.GetFileRepoMock().AssertExpectations(t)
+	m.uow.GetUploadSessionRepoMock().AssertExpectations(t)
+}
+
+func TestTusService_Write_KeepsDeclaredChecksum(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, target := m.openUpload(ctx, 0)
+	target.Checksum = sum("abcdefghij")
+	parts := []domain.UploadPart{{PartNumber: 1, ETag: "e1"}, {PartNumber: 2, ETag: "e2"}, {PartNumber: 3, ETag: "e3"}}
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.UploadPart{}, nil)
+	m.storage.On("ListPartsPaginated", ctx, "bucket", "key", "provider_123", 1000, 0).Return(parts, 0, nil)
+	m.files.On("CompleteMultipartUpload", ctx, session.ID, parts).Return(&session.FileID, nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	_, err := m.service().Write(ctx, session.ID, 0, strings.NewReader("abcdefghij"), "")
+
+	// Assert
+	require.NoError(t, err)
+	m.files.AssertExpectations(t)
+	m.uow.GetFileRepoMock().AssertNotCalled(t, "UpdateChecksum", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTusService_Write_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   int64
+		data     string
+		checksum string
+		wantErr  error
+	}{
+		{"offset mismatch", 4, "abcd", "", domain.ErrOffsetMismatch},
+		{"checksum mismatch", 0, "abcdef", sum("other"), domain.ErrMismatchChecksum},
+		{"data beyond the size", 0, "abcdefghijk", sum("abcdefghijk"), domain.ErrSizeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			m := newTusMocks()
+			session, _ := m.openUpload(ctx, 0)
+			m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.UploadPart{}, nil)
+
+			// Act
+			_, err := m.service().Write(ctx, session.ID, tt.offset, strings.NewReader(tt.data), tt.checksum)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.storage.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			m.uow.GetUploadSessionRepoMock().AssertNotCalled(t, "UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			m.files.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTusService_Write_Locked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	id := uuid.New()
+	m.uow.GetUploadSessionRepoMock().On("AcquireLease", ctx, id, mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrUploadLocked)
+
+	// Act
+	_, err := m.service().Write(ctx, id, 0, strings.NewReader("abcd"), "")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadLocked)
+	m.files.AssertNotCalled(t, "GetUploadSession", mock.Anything, mock.Anything)
+	m.uow.GetUploadSessionRepoMock().AssertNotCalled(t, "ReleaseLease", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTusService_Write_RenewsLeaseAfterEachPart(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 0)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 1, []byte("abcd"), int64(4), sum("abcd")).Return(domain.UploadPart{PartNumber: 1, ETag: "e1"}, nil)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 2, []byte("efgh"), int64(4), sum("efgh")).Return(domain.UploadPart{PartNumber: 2, ETag: "e2"}, nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, int64(4), mock.Anything).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", mock.Anything, session.ID, int64(8), mock.Anything).Return(nil)
+
+	// Act
+	_, err := m.service().Write(ctx, session.ID, 0, strings.NewReader("abcdefgh"), "")
+
+	// Assert
+	require.NoError(t, err)
+	m.uow.GetUploadSessionRepoMock().AssertNumberOfCalls(t, "AcquireLease", 3)
+	m.uow.GetUploadSessionRepoMock().AssertNumberOfCalls(t, "UpdateOffset", 2)
+	m.uow.GetUploadSessionRepoMock().AssertNumberOfCalls(t, "ReleaseLease", 1)
+}
+
+// droppedClient reads data then fails as a client closing its connection, canceling the request
+type droppedClient struct {
+	data   io.Reader
+	cancel context.CancelFunc
+}
+
+func (c droppedClient) Read(p []byte) (int, error) {
+	n, err := c.data.Read(p)
+	if errors.Is(err, io.EOF) {
+		c.cancel()
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestTusService_Write_KeepsProgressOfDroppedClient(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	m := newTusMocks()
+	session, _ := m.openUpload(ctx, 0)
+	notCanceled := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil })
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 1, []byte("abcd"), int64(4), sum("abcd")).Return(domain.UploadPart{PartNumber: 1, ETag: "e1"}, nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", notCanceled, session.ID, int64(4), mock.Anything).Return(nil)
+	m.storage.On("PutObject", notCanceled, "bucket", domain.TusBufferKey("key", 2), []byte("ef"), int64(2)).Return(nil)
+	m.uow.GetUploadSessionRepoMock().On("UpdateOffset", notCanceled, session.ID, int64(6), mock.Anything).Return(nil)
+
+	// Act
+	offset, err := m.service().Write(ctx, session.ID, 0, droppedClient{data: strings.NewReader("abcdef"), cancel: cancel}, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), offset)
+	m.storage.AssertExpectations(t)
+	m.uow.GetUploadSessionRepoMock().AssertExpectations(t)
+}
+
+func TestTusService_Write_LeaseLost(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := newTusMocks()
+	session := &domain.UploadSession{ID: uuid.New(), FileID: uuid.New(), ProviderUploadID: "provider_123", PartSize: partSize}
+	target := &domain.FileMetadata{ID: session.FileID, Bucket: "bucket", StorageKey: "key", SizeBytes: 10}
+	m.files.On("GetUploadSession", ctx, session.ID).Return(session, target, nil)
+	m.uow.GetUploadSessionRepoMock().On("AcquireLease", ctx, session.ID, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.uow.GetUploadSessionRepoMock().On("AcquireLease", ctx, session.ID, mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrUploadLocked)
+	m.uow.GetUploadSessionRepoMock().On("ReleaseLease", mock.Anything, session.ID, mock.Anything).Return(nil)
+	m.storage.On("UploadPart", ctx, "bucket", "key", "provider_123", 1, []byte("abcd"), int64(4), sum("abcd")).Return(domain.UploadPart{PartNumber: 1, ETag: "e1"}, nil)
+
+	// Act
+	_, err := m.service().Write(ctx, session.ID, 0, strings.NewReader("abcdef"), "")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadLocked)
+	m.uow.GetUploadSessionRepoMock().AssertNotCalled(t, "UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.uow.GetUploadSessionRepoMock().AssertNumberOfCalls(t, "ReleaseLease", 1)
+}
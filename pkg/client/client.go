@@ -0,0 +1,195 @@
+// Package client is a Go SDK for the score-play api: tags, simple and multipart uploads, downloads.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the api of a local deployment
+const DefaultBaseURL = "http://localhost:8080/api/v1"
+
+// tenantHeader names the tenant of requests whose api key does not carry one
+const tenantHeader = "X-Tenant-ID"
+
+var (
+	// ErrFileRestoring is returned when an archived file is being restored, the download can be retried later
+	ErrFileRestoring = errors.New("file is being restored from archive")
+	// ErrChecksumMismatch is returned when downloaded content does not match the checksum of the file
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Error is a problem returned by the api or the storage
+type Error struct {
+	StatusCode int    `json:"status"`
+	Code       string `json:"code"`
+	Title      string `json:"title"`
+	Detail     string `json:"detail"`
+	RequestID  string `json:"request_id"`
+}
+
+func (e *Error) Error() string {
+	message := e.Title
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	if e.Code != "" {
+		message = e.Code + ": " + message
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, message)
+}
+
+// Client calls the score-play api. It is safe for concurrent use.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	apiKey      string
+	tenant      string
+	storageHost string
+	deduplicate bool
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with httpClient instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithAPIKey authenticates the requests with an api key
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) { c.apiKey = apiKey }
+}
+
+// WithTenant scopes the requests to a tenant, for api keys not bound to one
+func WithTenant(tenant string) Option {
+	return func(c *Client) { c.tenant = tenant }
+}
+
+// WithStorageHost sends the presigned requests to host, e.g. localhost:9000 when the api signs urls for minio:9000.
+// The signed host is kept in the Host header so the signatures stay valid.
+func WithStorageHost(host string) Option {
+	return func(c *Client) { c.storageHost = host }
+}
+
+// WithDeduplication asks the api to skip the uploads of content it already stores.
+// The file then shares the stored object and nothing is sent to the storage.
+func WithDeduplication() Option {
+	return func(c *Client) { c.deduplicate = true }
+}
+
+// New creates a Client for the api at baseURL, e.g. DefaultBaseURL
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// doJSON sends body as json to the api and decodes the response into out, unless the status is not expected
+func (c *Client) doJSON(ctx context.Context, method string, path string, query url.Values, body any, expectedStatus int, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.tenant != "" {
+		req.Header.Set(tenantHeader, c.tenant)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		if resp.StatusCode < 300 {
+			return resp.StatusCode, nil
+		}
+		return resp.StatusCode, readError(resp)
+	}
+	if out == nil {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// doStorage sends a presigned request with its signed headers, rewriting the host if WithStorageHost is set
+func (c *Client) doStorage(ctx context.Context, method string, presignedURL string, headers map[string]string, body io.Reader, contentLength int64) (*http.Response, error) {
+	target, err := url.Parse(presignedURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presigned url: %w", err)
+	}
+	signedHost := target.Host
+	if c.storageHost != "" {
+		target.Host = c.storageHost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = signedHost
+	if body != nil {
+		req.ContentLength = contentLength
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, readError(resp)
+	}
+	return resp, nil
+}
+
+// readError reads a problem details body, or keeps the raw body of other errors, e.g. storage xml errors
+func readError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	apiErr := &Error{}
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Title == "" {
+		apiErr = &Error{Title: http.StatusText(resp.StatusCode), Detail: strings.TrimSpace(string(data))}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return apiErr
+}
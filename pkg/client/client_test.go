@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"score-play/internal/adapters/handlers/http/chi"
+	"score-play/internal/adapters/handlers/http/chi/v1/file"
+	"score-play/internal/adapters/handlers/http/chi/v1/tag"
+	fileservice "score-play/internal/core/service/file"
+	tagservice "score-play/internal/core/service/tag"
+	"score-play/pkg/client"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// signedHost is the storage host the api signs urls for, only reachable through client.WithStorageHost
+const signedHost = "minio:9000"
+
+// fakeStorage keeps the objects sent to presigned urls and rejects the content not matching its checksum header
+type fakeStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    []string
+}
+
+func (s *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Host != signedHost {
+		http.Error(w, "signature does not match host "+r.Host, http.StatusForbidden)
+		return
+	}
+	key := r.URL.Path
+	if partNumber := r.URL.Query().Get("partNumber"); partNumber != "" {
+		key += "#" + partNumber
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if checksum := r.Header.Get("x-amz-checksum-sha256"); checksum != "" && checksum != sum(data) {
+			http.Error(w, "<Error><Code>BadDigest</Code></Error>", http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.objects[key] = data
+		s.puts = append(s.puts, key)
+		s.mu.Unlock()
+		w.Header().Set("ETag", `"etag-`+key+`"`)
+	case http.MethodGet:
+		s.mu.Lock()
+		data, ok := s.objects[key]
+		s.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// testEnv is the real router over mocked services, and a fake storage
+type testEnv struct {
+	tags    *tagservice.MockTagService
+	files   *fileservice.MockFileService
+	storage *fakeStorage
+	client  *client.Client
+}
+
+// newTestEnv creates the environment, with the client options opts
+func newTestEnv(t *testing.T, opts ...client.Option) *testEnv {
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	env := &testEnv{
+		tags:    &tagservice.MockTagService{},
+		files:   fileservice.NewMockFileService(),
+		storage: &fakeStorage{objects: map[string][]byte{}},
+	}
+	api := httptest.NewServer(chi.NewRouter(chi.RouterConfig{
+		Logger: discardLogger,
+		Tag:    tag.NewTagHandlerV1(env.tags, discardLogger),
+		File:   file.NewFileHandlerV1(env.files, discardLogger),
+	}))
+	t.Cleanup(api.Close)
+	storage := httptest.NewServer(env.storage)
+	t.Cleanup(storage.Close)
+
+	storageURL, err := url.Parse(storage.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.client = client.New(api.URL+"/api/v1", append([]client.Option{client.WithStorageHost(storageURL.Host)}, opts...)...)
+	return env
+}
+
+// presignedURL is the url the api would sign for key, with the query of a part if partNumber is not 0
+func presignedURL(key string, partNumber int) string {
+	signed := "http://" + signedHost + "/bucket/" + key + "?X-Amz-Signature=abc"
+	if partNumber != 0 {
+		signed += "&partNumber=" + strconv.Itoa(partNumber)
+	}
+	return signed
+}
+
+// sum is the base64 SHA-256 of data
+func sum(data []byte) string {
+	h := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// source is an in memory upload
+func source(content string) *client.Source {
+	return &client.Source{
+		Filename:    "match.mp4",
+		ContentType: "video/mp4",
+		Size:        int64(len(content)),
+		Reader:      bytes.NewReader([]byte(content)),
+	}
+}
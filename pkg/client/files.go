@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Source is the content of an upload. It is read more than once: to compute the checksums, then to send it.
+type Source struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Reader      io.ReaderAt
+}
+
+// OpenFile opens the file at path as a Source, with a content type guessed from its extension.
+// The caller closes it with Close.
+func OpenFile(path string) (*Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Source{Filename: filepath.Base(path), ContentType: contentType, Size: info.Size(), Reader: f}, nil
+}
+
+// Close closes the reader of s if it is an io.Closer
+func (s *Source) Close() error {
+	if closer, ok := s.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// checksum returns the base64 SHA-256 of length bytes at offset, as the api expects them
+func (s *Source) checksum(offset int64, length int64) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(s.Reader, offset, length)); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", s.Filename, err)
+	}
+	return encodeSum(h), nil
+}
+
+func encodeSum(h hash.Hash) string {
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// UploadRequest describes a file to upload
+type UploadRequest struct {
+	Filename       string   `json:"filename"`
+	ContentType    string   `json:"content_type"`
+	SizeBytes      int64    `json:"size_bytes"`
+	ChecksumSHA256 string   `json:"checksum_sha256"`
+	Tags           []string `json:"tags"`
+	Deduplicate    bool     `json:"deduplicate,omitempty"`
+}
+
+// PresignedUpload is where the content of a simple upload is sent.
+// A deduplicated upload has no url, the file is already completed.
+type PresignedUpload struct {
+	FileID       uuid.UUID         `json:"file_id"`
+	URL          string            `json:"presigned_url"`
+	Headers      map[string]string `json:"headers"`
+	ExpiresAt    *time.Time        `json:"expires_at"`
+	Deduplicated bool              `json:"deduplicated"`
+}
+
+// FileInfo is a file and the presigned url to download it
+type FileInfo struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	Tags           []string          `json:"tags"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	ChecksumSHA256 string            `json:"checksum_sha256,omitempty"`
+}
+
+// uploadRequest describes src for the api
+func (c *Client) uploadRequest(src *Source, tags []string) (UploadRequest, error) {
+	checksum, err := src.checksum(0, src.Size)
+	if err != nil {
+		return UploadRequest{}, err
+	}
+	return UploadRequest{
+		Filename:       src.Filename,
+		ContentType:    src.ContentType,
+		SizeBytes:      src.Size,
+		ChecksumSHA256: checksum,
+		Tags:           tags,
+		Deduplicate:    c.deduplicate,
+	}, nil
+}
+
+// RequestUpload requests a presigned url for a simple upload
+func (c *Client) RequestUpload(ctx context.Context, req UploadRequest) (*PresignedUpload, error) {
+	var upload PresignedUpload
+	if _, err := c.doJSON(ctx, http.MethodPost, "/file/upload", nil, req, http.StatusCreated, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// Upload uploads src in a single request and returns the id of the file.
+// The file is completed asynchronously, once the storage notifies the api, or at once when deduplicated.
+func (c *Client) Upload(ctx context.Context, src *Source, tags []string) (uuid.UUID, error) {
+	req, err := c.uploadRequest(src, tags)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	upload, err := c.RequestUpload(ctx, req)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if upload.Deduplicated {
+		return upload.FileID, nil
+	}
+
+	resp, err := c.doStorage(ctx, http.MethodPut, upload.URL, upload.Headers, io.NewSectionReader(src.Reader, 0, src.Size), src.Size)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to upload %s: %w", src.Filename, err)
+	}
+	_ = resp.Body.Close()
+	return upload.FileID, nil
+}
+
+// GetFile returns the file fileID with a presigned download url.
+// ErrFileRestoring is returned while an archived file is being restored.
+func (c *Client) GetFile(ctx context.Context, fileID uuid.UUID) (*FileInfo, error) {
+	var info FileInfo
+	status, err := c.doJSON(ctx, http.MethodGet, "/file/"+fileID.String(), nil, nil, http.StatusOK, &info)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		return nil, ErrFileRestoring
+	}
+	return &info, nil
+}
+
+// Download writes the content of the file fileID to w and checks it against the checksum of the file.
+// ErrChecksumMismatch is returned when they differ, w has then received the corrupted content.
+func (c *Client) Download(ctx context.Context, fileID uuid.UUID, w io.Writer) (*FileInfo, error) {
+	info, err := c.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doStorage(ctx, http.MethodGet, info.URL, info.Headers, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", info.Filename, err)
+	}
+	defer resp.Body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", info.Filename, err)
+	}
+	if info.ChecksumSHA256 != "" && encodeSum(h) != info.ChecksumSHA256 {
+		return info, fmt.Errorf("%w: %s is %s, expected %s", ErrChecksumMismatch, info.Filename, encodeSum(h), info.ChecksumSHA256)
+	}
+	return info, nil
+}
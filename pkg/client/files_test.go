@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"score-play/internal/core/domain"
+	"score-play/pkg/client"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Upload(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	content := "kick-off"
+	fileID := uuid.New()
+	url := presignedURL("default/1", 0)
+	headers := map[string]string{"x-amz-checksum-sha256": sum([]byte(content))}
+	expiresAt := time.Now()
+	env.files.On("RequestUploadFile", mock.Anything, "match.mp4", "video/mp4", int64(len(content)), sum([]byte(content)), []string{"football"}).
+		Return(&fileID, &url, headers, &expiresAt, nil)
+
+	// Act
+	id, err := env.client.Upload(context.Background(), source(content), []string{"football"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Equal(t, content, string(env.storage.objects["/bucket/default/1"]))
+	env.files.AssertExpectations(t)
+}
+
+func TestClient_Upload_Deduplicated(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t, client.WithDeduplication())
+	content := "kick-off"
+	fileID := uuid.New()
+	env.files.On("DeduplicateUpload", mock.Anything, "match.mp4", "video/mp4", int64(len(content)), sum([]byte(content)), []string{"football"}).
+		Return(&fileID, nil)
+
+	// Act
+	id, err := env.client.Upload(context.Background(), source(content), []string{"football"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Empty(t, env.storage.puts)
+	env.files.AssertNotCalled(t, "RequestUploadFile")
+}
+
+func TestClient_Upload_QuotaExceeded(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	env.files.On("RequestUploadFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return((*uuid.UUID)(nil), (*string)(nil), map[string]string(nil), (*time.Time)(nil), domain.ErrQuotaExceeded)
+
+	// Act
+	_, err := env.client.Upload(context.Background(), source("kick-off"), []string{"football"})
+
+	// Assert
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 413, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.Code)
+	assert.Empty(t, env.storage.puts)
+}
+
+func TestClient_Download(t *testing.T) {
+	tests := []struct {
+		name        string
+		stored      string
+		expectedErr error
+	}{
+		{"checksum matches", "kick-off", nil},
+		{"checksum mismatch", "corrupted", client.ErrChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			env := newTestEnv(t)
+			fileID := uuid.New()
+			url := presignedURL("default/1", 0)
+			filename := "match.mp4"
+			expiresAt := time.Now()
+			env.storage.objects["/bucket/default/1"] = []byte(tt.stored)
+			env.files.On("GetFile", mock.Anything, fileID, mock.Anything).
+				Return(&url, &filename, []domain.Tag{{Name: "football"}}, map[string]string(nil), &expiresAt, sum([]byte("kick-off")), nil)
+			var out bytes.Buffer
+
+			// Act
+			info, err := env.client.Download(context.Background(), fileID, &out)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedErr)
+			require.NotNil(t, info)
+			assert.Equal(t, filename, info.Filename)
+			assert.Equal(t, tt.stored, out.String())
+		})
+	}
+}
+
+func TestClient_GetFile_Restoring(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	fileID := uuid.New()
+	env.files.On("GetFile", mock.Anything, fileID, mock.Anything).
+		Return((*string)(nil), (*string)(nil), []domain.Tag(nil), map[string]string(nil), (*time.Time)(nil), "", domain.ErrFileRestoring)
+
+	// Act
+	_, err := env.client.GetFile(context.Background(), fileID)
+
+	// Assert
+	assert.True(t, errors.Is(err, client.ErrFileRestoring))
+}
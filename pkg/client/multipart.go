@@ -0,0 +1,319 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultConcurrency is the number of parts uploaded at once when MultipartOptions does not set one
+const DefaultConcurrency = 4
+
+// listPartsPageSize is the number of parts listed per request
+const listPartsPageSize = 1000
+
+// MultipartSession is an open multipart upload, to keep in order to resume it.
+// A deduplicated upload opens no session, FileID is then the completed file.
+type MultipartSession struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	PartSize     int       `json:"part_size"`
+	FileID       uuid.UUID `json:"file_id,omitzero"`
+	Deduplicated bool      `json:"deduplicated,omitempty"`
+}
+
+// PartRequest asks for the presigned url of a part
+type PartRequest struct {
+	PartNumber    int    `json:"part_number"`
+	Checksum      string `json:"checksum"`
+	ContentLength int64  `json:"content_length"`
+}
+
+// PresignedPart is where the content of a part is sent, with its signed headers
+type PresignedPart struct {
+	PartNumber int               `json:"part_number"`
+	URL        string            `json:"presigned_url"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	Headers    map[string]string `json:"headers"`
+}
+
+// UploadedPart is a part the storage has received
+type UploadedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// CompletedPart is a part of a multipart upload to complete
+type CompletedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	Checksum   string `json:"checksum"`
+}
+
+// MultipartOptions tunes a multipart upload
+type MultipartOptions struct {
+	// Concurrency is the number of parts uploaded at once, DefaultConcurrency if not positive
+	Concurrency int
+	// StatePath is the file the progress is saved to after each part, and resumed from after a restart.
+	// It is removed once the upload is completed. The progress is only kept in memory if empty.
+	StatePath string
+	// OnPart is called after each part is uploaded or found already uploaded, from any goroutine
+	OnPart func(part CompletedPart, skipped bool)
+}
+
+// RequestMultipartUpload opens a multipart upload session
+func (c *Client) RequestMultipartUpload(ctx context.Context, req UploadRequest) (*MultipartSession, error) {
+	var session MultipartSession
+	if _, err := c.doJSON(ctx, http.MethodPost, "/file/upload/multipart", nil, req, http.StatusCreated, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// PresignParts requests the presigned urls of parts. The storage rejects content not matching their checksums.
+func (c *Client) PresignParts(ctx context.Context, sessionID uuid.UUID, parts []PartRequest) ([]PresignedPart, error) {
+	var resp struct {
+		Parts []PresignedPart `json:"presigned_parts"`
+	}
+	body := map[string][]PartRequest{"parts": parts}
+	if _, err := c.doJSON(ctx, http.MethodPost, "/file/upload/multipart/"+sessionID.String()+"/parts", nil, body, http.StatusCreated, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Parts, nil
+}
+
+// ListParts lists every part the storage has received for the session, page by page
+func (c *Client) ListParts(ctx context.Context, sessionID uuid.UUID) ([]UploadedPart, error) {
+	var parts []UploadedPart
+	marker := 0
+	for {
+		query := url.Values{"nb_parts": {strconv.Itoa(listPartsPageSize)}}
+		if marker != 0 {
+			query.Set("marker", strconv.Itoa(marker))
+		}
+		var page struct {
+			Parts       []UploadedPart `json:"parts"`
+			PartsMarker int            `json:"parts_marker"`
+		}
+		if _, err := c.doJSON(ctx, http.MethodGet, "/file/upload/multipart/"+sessionID.String()+"/parts", query, nil, http.StatusOK, &page); err != nil {
+			return nil, err
+		}
+		parts = append(parts, page.Parts...)
+		if page.PartsMarker == 0 || page.PartsMarker == marker {
+			return parts, nil
+		}
+		marker = page.PartsMarker
+	}
+}
+
+// CompleteMultipartUpload completes the session with every part, and returns the id of the file
+func (c *Client) CompleteMultipartUpload(ctx context.Context, sessionID uuid.UUID, parts []CompletedPart) (uuid.UUID, error) {
+	var resp struct {
+		FileID uuid.UUID `json:"file_id"`
+	}
+	body := map[string][]CompletedPart{"parts": parts}
+	if _, err := c.doJSON(ctx, http.MethodPost, "/file/upload/multipart/"+sessionID.String()+"/complete", nil, body, http.StatusCreated, &resp); err != nil {
+		return uuid.Nil, err
+	}
+	return resp.FileID, nil
+}
+
+// UploadMultipart uploads src in parts sent in parallel, and returns the id of the file.
+// If opts.StatePath holds the state of an interrupted upload of src, that upload is resumed instead of starting over.
+// If it fails, the session is returned with the error so the upload can be resumed with ResumeMultipartUpload.
+// A deduplicated upload returns the id of the completed file without session, nothing is sent.
+func (c *Client) UploadMultipart(ctx context.Context, src *Source, tags []string, opts MultipartOptions) (uuid.UUID, *MultipartSession, error) {
+	req, err := c.uploadRequest(src, tags)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	state, err := loadState(opts.StatePath)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	if state != nil && (state.SizeBytes != req.SizeBytes || state.ChecksumSHA256 != req.ChecksumSHA256) {
+		return uuid.Nil, nil, fmt.Errorf("%w: %s was saved for %s", ErrStateMismatch, opts.StatePath, state.Filename)
+	}
+	if state == nil {
+		session, err := c.RequestMultipartUpload(ctx, req)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		if session.Deduplicated {
+			return session.FileID, nil, nil
+		}
+		state = &UploadState{
+			SessionID:      session.SessionID,
+			PartSize:       session.PartSize,
+			Filename:       src.Filename,
+			SizeBytes:      src.Size,
+			ChecksumSHA256: req.ChecksumSHA256,
+		}
+	}
+
+	session := &MultipartSession{SessionID: state.SessionID, PartSize: state.PartSize}
+	fileID, err := c.upload(ctx, &progress{path: opts.StatePath, state: state}, src, opts)
+	return fileID, session, err
+}
+
+// ResumeMultipartUpload uploads the parts of src the storage has not received yet, then completes the session.
+// src must be the content the session was opened for, the parts already received are not sent again.
+// The parts recorded in opts.StatePath for the same session are trusted without reading them again.
+func (c *Client) ResumeMultipartUpload(ctx context.Context, session MultipartSession, src *Source, opts MultipartOptions) (uuid.UUID, error) {
+	if session.PartSize <= 0 {
+		return uuid.Nil, errors.New("part size must be positive")
+	}
+	state, err := loadState(opts.StatePath)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if state != nil && (state.SessionID != session.SessionID || state.SizeBytes != src.Size) {
+		return uuid.Nil, fmt.Errorf("%w: %s was saved for session %s", ErrStateMismatch, opts.StatePath, state.SessionID)
+	}
+	if state == nil {
+		state = &UploadState{SessionID: session.SessionID, PartSize: session.PartSize, Filename: src.Filename, SizeBytes: src.Size}
+	}
+	return c.upload(ctx, &progress{path: opts.StatePath, state: state}, src, opts)
+}
+
+// loadState loads the state at path, if any
+func loadState(path string) (*UploadState, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return LoadUploadState(path)
+}
+
+// upload sends the parts of src missing from the session then completes it.
+// The parts the storage lists are reconciled with the recorded ones: a recorded part is only trusted if the storage
+// has it under the same etag, other listed parts are read again for their checksums, and the rest are uploaded.
+// Listing the parts refreshes the ttl of the session. A session gone from the api removes the state.
+func (c *Client) upload(ctx context.Context, progress *progress, src *Source, opts MultipartOptions) (uuid.UUID, error) {
+	fileID, err := c.sendParts(ctx, progress, src, opts)
+	if isSessionGone(err) {
+		if removeErr := progress.remove(); removeErr != nil {
+			return uuid.Nil, errors.Join(fmt.Errorf("%w: %w", ErrSessionExpired, err), removeErr)
+		}
+		return uuid.Nil, fmt.Errorf("%w: %w", ErrSessionExpired, err)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return fileID, progress.remove()
+}
+
+func (c *Client) sendParts(ctx context.Context, progress *progress, src *Source, opts MultipartOptions) (uuid.UUID, error) {
+	session := MultipartSession{SessionID: progress.state.SessionID, PartSize: progress.state.PartSize}
+	uploaded, err := c.ListParts(ctx, session.SessionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := progress.save(); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to save upload state: %w", err)
+	}
+	etags := make(map[int]string, len(uploaded))
+	for _, part := range uploaded {
+		etags[part.PartNumber] = strings.Trim(part.ETag, `"`)
+	}
+
+	partCount := int((src.Size + int64(session.PartSize) - 1) / int64(session.PartSize))
+	parts := make([]CompletedPart, partCount)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	partNumbers := make(chan int)
+	errs := make(chan error, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partNumber := range partNumbers {
+				part, skipped, err := c.uploadPart(ctx, session, src, partNumber, etags[partNumber], progress.recorded(partNumber))
+				if err == nil {
+					err = progress.record(part)
+				}
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				parts[partNumber-1] = part
+				if opts.OnPart != nil {
+					opts.OnPart(part, skipped)
+				}
+			}
+		}()
+	}
+
+feed:
+	for partNumber := 1; partNumber <= partCount; partNumber++ {
+		select {
+		case partNumbers <- partNumber:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(partNumbers)
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return uuid.Nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	return c.CompleteMultipartUpload(ctx, session.SessionID, parts)
+}
+
+// uploadPart sends part partNumber of src, unless the storage already has it under etag.
+// The checksum of recorded is reused when the storage has it under the recorded etag.
+func (c *Client) uploadPart(ctx context.Context, session MultipartSession, src *Source, partNumber int, etag string, recorded CompletedPart) (CompletedPart, bool, error) {
+	if etag != "" && etag == strings.Trim(recorded.ETag, `"`) && recorded.Checksum != "" {
+		return recorded, true, nil
+	}
+
+	offset := int64(partNumber-1) * int64(session.PartSize)
+	length := min(int64(session.PartSize), src.Size-offset)
+	checksum, err := src.checksum(offset, length)
+	if err != nil {
+		return CompletedPart{}, false, err
+	}
+	if etag != "" {
+		return CompletedPart{PartNumber: partNumber, ETag: etag, Checksum: checksum}, true, nil
+	}
+
+	presigned, err := c.PresignParts(ctx, session.SessionID, []PartRequest{{PartNumber: partNumber, Checksum: checksum, ContentLength: length}})
+	if err != nil {
+		return CompletedPart{}, false, fmt.Errorf("failed to presign part %d: %w", partNumber, err)
+	}
+	if len(presigned) != 1 {
+		return CompletedPart{}, false, fmt.Errorf("failed to presign part %d: got %d urls", partNumber, len(presigned))
+	}
+
+	resp, err := c.doStorage(ctx, http.MethodPut, presigned[0].URL, presigned[0].Headers, io.NewSectionReader(src.Reader, offset, length), length)
+	if err != nil {
+		return CompletedPart{}, false, fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	_ = resp.Body.Close()
+
+	etag = strings.Trim(resp.Header.Get("ETag"), `"`)
+	if etag == "" {
+		return CompletedPart{}, false, fmt.Errorf("failed to upload part %d: no etag returned", partNumber)
+	}
+	return CompletedPart{PartNumber: partNumber, ETag: etag, Checksum: checksum}, false, nil
+}
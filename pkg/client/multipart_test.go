@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"score-play/pkg/client"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	multipartContent = "first-second-third"
+	partSize         = 6
+)
+
+// expectPresign expects part partNumber of multipartContent to be presigned with its checksum and length
+func expectPresign(env *testEnv, sessionID uuid.UUID, partNumber int) {
+	chunk := []byte(multipartContent[(partNumber-1)*partSize : partNumber*partSize])
+	expiresAt := time.Now()
+	env.files.On("GetPresignedParts", mock.Anything, sessionID, []domain.UploadPart{{PartNumber: partNumber, ChecksumSHA256: sum(chunk), ContentLength: int64(len(chunk))}}).
+		Return([]domain.UploadPart{{PartNumber: partNumber, PresignedURL: presignedURL("default/1", partNumber), Headers: map[string]string{"x-amz-checksum-sha256": sum(chunk)}, ExpiresAt: &expiresAt}}, nil).
+		Once()
+}
+
+// completedParts are the parts of multipartContent as the fake storage tags them
+func completedParts(etags map[int]string) []domain.UploadPart {
+	parts := make([]domain.UploadPart, 0, 3)
+	for partNumber := 1; partNumber <= 3; partNumber++ {
+		etag, ok := etags[partNumber]
+		if !ok {
+			etag = "etag-/bucket/default/1#" + strconv.Itoa(partNumber)
+		}
+		chunk := []byte(multipartContent[(partNumber-1)*partSize : partNumber*partSize])
+		parts = append(parts, domain.UploadPart{PartNumber: partNumber, ETag: etag, ChecksumSHA256: sum(chunk)})
+	}
+	return parts
+}
+
+func TestClient_UploadMultipart(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	sessionID := uuid.New()
+	fileID := uuid.New()
+	env.files.On("RequestUploadMultipartFile", mock.Anything, "match.mp4", "video/mp4", int64(len(multipartContent)), sum([]byte(multipartContent)), []string{"football"}).
+		Return(&sessionID, partSize, nil)
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart{}, 0, nil)
+	for partNumber := 1; partNumber <= 3; partNumber++ {
+		expectPresign(env, sessionID, partNumber)
+	}
+	env.files.On("CompleteMultipartUpload", mock.Anything, sessionID, completedParts(nil)).Return(&fileID, nil)
+
+	// Act
+	id, session, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, client.MultipartOptions{Concurrency: 2})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Equal(t, client.MultipartSession{SessionID: sessionID, PartSize: partSize}, *session)
+	assert.Equal(t, "first-", string(env.storage.objects["/bucket/default/1#1"]))
+	assert.Equal(t, "-third", string(env.storage.objects["/bucket/default/1#3"]))
+	assert.Len(t, env.storage.puts, 3)
+	env.files.AssertExpectations(t)
+}
+
+func TestClient_UploadMultipart_Deduplicated(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t, client.WithDeduplication())
+	fileID := uuid.New()
+	env.files.On("DeduplicateUpload", mock.Anything, "match.mp4", "video/mp4", int64(len(multipartContent)), sum([]byte(multipartContent)), []string{"football"}).
+		Return(&fileID, nil)
+
+	// Act
+	id, session, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, client.MultipartOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Nil(t, session)
+	assert.Empty(t, env.storage.puts)
+	env.files.AssertNotCalled(t, "RequestUploadMultipartFile")
+}
+
+func TestClient_ResumeMultipartUpload(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	sessionID := uuid.New()
+	fileID := uuid.New()
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart{{PartNumber: 1, ETag: "etag-1"}}, 1, nil)
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 1).Return([]domain.UploadPart{{PartNumber: 2, ETag: "etag-2"}}, 0, nil)
+	expectPresign(env, sessionID, 3)
+	env.files.On("CompleteMultipartUpload", mock.Anything, sessionID, completedParts(map[int]string{1: "etag-1", 2: "etag-2"})).Return(&fileID, nil)
+	var skipped []int
+	opts := client.MultipartOptions{Concurrency: 1, OnPart: func(part client.CompletedPart, wasSkipped bool) {
+		if wasSkipped {
+			skipped = append(skipped, part.PartNumber)
+		}
+	}}
+
+	// Act
+	id, err := env.client.ResumeMultipartUpload(context.Background(), client.MultipartSession{SessionID: sessionID, PartSize: partSize}, source(multipartContent), opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Equal(t, []int{1, 2}, skipped)
+	assert.Equal(t, []string{"/bucket/default/1#3"}, env.storage.puts)
+	env.files.AssertExpectations(t)
+}
+
+func TestClient_UploadMultipart_SessionExpired(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	sessionID := uuid.New()
+	env.files.On("RequestUploadMultipartFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&sessionID, partSize, nil)
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart{}, 0, nil)
+	env.files.On("GetPresignedParts", mock.Anything, sessionID, mock.Anything).Return([]domain.UploadPart(nil), domain.ErrSessionNotFound)
+
+	// Act
+	_, session, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, client.MultipartOptions{})
+
+	// Assert
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "session_not_found", apiErr.Code)
+	require.NotNil(t, session)
+	assert.Equal(t, sessionID, session.SessionID)
+	env.files.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSessionExpired is returned when the session of an upload is gone from the api, expired or cleaned up.
+	// The upload has to start over, its state file has been removed.
+	ErrSessionExpired = errors.New("upload session expired")
+	// ErrStateMismatch is returned when a state file was saved for another content than the one uploaded
+	ErrStateMismatch = errors.New("upload state belongs to another file")
+)
+
+// codeSessionNotFound is the problem code of sessions expired or cleaned up
+const codeSessionNotFound = "session_not_found"
+
+// UploadState is the progress of a multipart upload, saved to a file so the upload resumes after a restart
+type UploadState struct {
+	SessionID      uuid.UUID       `json:"session_id"`
+	PartSize       int             `json:"part_size"`
+	Filename       string          `json:"filename"`
+	SizeBytes      int64           `json:"size_bytes"`
+	ChecksumSHA256 string          `json:"checksum_sha256,omitempty"`
+	Parts          []CompletedPart `json:"parts"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// LoadUploadState reads the state saved at path, nil if there is none
+func LoadUploadState(path string) (*UploadState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state UploadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid upload state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes s to path. It writes a temporary file renamed over path, so a crash never leaves a truncated state.
+func (s *UploadState) Save(path string) error {
+	s.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// progress is the state shared by the part workers, saved after each part when path is set
+type progress struct {
+	mu    sync.Mutex
+	path  string
+	state *UploadState
+}
+
+// recorded returns the recorded part partNumber, zero if it was not recorded
+func (p *progress) recorded(partNumber int) CompletedPart {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, part := range p.state.Parts {
+		if part.PartNumber == partNumber {
+			return part
+		}
+	}
+	return CompletedPart{}
+}
+
+// save writes the state, if it is persisted
+func (p *progress) save() error {
+	if p.path == "" {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state.Save(p.path)
+}
+
+// record adds part to the state, replacing a previous upload of the same part, and saves it
+func (p *progress) record(part CompletedPart) error {
+	p.mu.Lock()
+	parts := make([]CompletedPart, 0, len(p.state.Parts)+1)
+	for _, recorded := range p.state.Parts {
+		if recorded.PartNumber != part.PartNumber {
+			parts = append(parts, recorded)
+		}
+	}
+	parts = append(parts, part)
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	p.state.Parts = parts
+	p.mu.Unlock()
+	return p.save()
+}
+
+// remove deletes the state file, once the upload is completed or its session is gone
+func (p *progress) remove() error {
+	if p.path == "" {
+		return nil
+	}
+	if err := os.Remove(p.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// isSessionGone tells whether err is the api answering the session is expired or cleaned up
+func isSessionGone(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == codeSessionNotFound
+}
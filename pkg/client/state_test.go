@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"score-play/internal/core/domain"
+	"score-play/pkg/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadState_SaveAndLoad(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "upload.json")
+	state := &client.UploadState{SessionID: uuid.New(), PartSize: partSize, Filename: "match.mp4", SizeBytes: 18, Parts: []client.CompletedPart{{PartNumber: 1, ETag: "etag-1", Checksum: "sum"}}}
+
+	// Act
+	missing, missingErr := client.LoadUploadState(path)
+	saveErr := state.Save(path)
+	loaded, err := client.LoadUploadState(path)
+
+	// Assert
+	require.NoError(t, missingErr)
+	assert.Nil(t, missing)
+	require.NoError(t, saveErr)
+	require.NoError(t, err)
+	assert.Equal(t, state.SessionID, loaded.SessionID)
+	assert.Equal(t, state.Parts, loaded.Parts)
+	assert.False(t, loaded.UpdatedAt.IsZero())
+}
+
+func TestClient_UploadMultipart_ResumesAfterRestart(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	statePath := filepath.Join(t.TempDir(), "upload.json")
+	sessionID := uuid.New()
+	fileID := uuid.New()
+	opts := client.MultipartOptions{Concurrency: 1, StatePath: statePath}
+	env.files.On("RequestUploadMultipartFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&sessionID, partSize, nil).Once()
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart{}, 0, nil).Once()
+	expectPresign(env, sessionID, 1)
+	expectPresign(env, sessionID, 2)
+	env.files.On("GetPresignedParts", mock.Anything, sessionID, mock.Anything).Return([]domain.UploadPart(nil), errors.New("connection lost")).Once()
+
+	_, _, interruptedErr := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, opts)
+	saved, loadErr := client.LoadUploadState(statePath)
+
+	listed := completedParts(nil)[:2]
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return(listed, 0, nil).Once()
+	expectPresign(env, sessionID, 3)
+	env.files.On("CompleteMultipartUpload", mock.Anything, sessionID, completedParts(nil)).Return(&fileID, nil)
+
+	// Act
+	id, _, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, opts)
+
+	// Assert
+	require.Error(t, interruptedErr)
+	require.NoError(t, loadErr)
+	require.NotNil(t, saved)
+	assert.Equal(t, sessionID, saved.SessionID)
+	assert.Len(t, saved.Parts, 2)
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.Len(t, env.storage.puts, 3)
+	assert.NoFileExists(t, statePath)
+	env.files.AssertExpectations(t)
+}
+
+func TestClient_ResumeMultipartUpload_TrustsRecordedParts(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	statePath := filepath.Join(t.TempDir(), "upload.json")
+	sessionID := uuid.New()
+	fileID := uuid.New()
+	recorded := client.CompletedPart{PartNumber: 1, ETag: "etag-1", Checksum: "recorded-checksum"}
+	state := &client.UploadState{SessionID: sessionID, PartSize: partSize, SizeBytes: int64(len(multipartContent)), Parts: []client.CompletedPart{recorded, {PartNumber: 2, ETag: "stale", Checksum: "stale"}}}
+	require.NoError(t, state.Save(statePath))
+	expected := completedParts(map[int]string{1: "etag-1", 2: "etag-2"})
+	expected[0].ChecksumSHA256 = "recorded-checksum"
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart{{PartNumber: 1, ETag: `"etag-1"`}, {PartNumber: 2, ETag: "etag-2"}}, 0, nil)
+	expectPresign(env, sessionID, 3)
+	env.files.On("CompleteMultipartUpload", mock.Anything, sessionID, expected).Return(&fileID, nil)
+
+	// Act
+	id, err := env.client.ResumeMultipartUpload(context.Background(), client.MultipartSession{SessionID: sessionID, PartSize: partSize}, source(multipartContent), client.MultipartOptions{StatePath: statePath})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fileID, id)
+	assert.NoFileExists(t, statePath)
+	env.files.AssertExpectations(t)
+}
+
+func TestClient_UploadMultipart_StateSessionExpired(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	statePath := filepath.Join(t.TempDir(), "upload.json")
+	sessionID := uuid.New()
+	state := &client.UploadState{SessionID: sessionID, PartSize: partSize, SizeBytes: int64(len(multipartContent)), ChecksumSHA256: sum([]byte(multipartContent))}
+	require.NoError(t, state.Save(statePath))
+	env.files.On("ListParts", mock.Anything, sessionID, 1000, 0).Return([]domain.UploadPart(nil), 0, domain.ErrSessionNotFound)
+
+	// Act
+	_, _, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, client.MultipartOptions{StatePath: statePath})
+
+	// Assert
+	assert.ErrorIs(t, err, client.ErrSessionExpired)
+	assert.NoFileExists(t, statePath)
+	assert.Empty(t, env.storage.puts)
+	env.files.AssertNotCalled(t, "RequestUploadMultipartFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestClient_UploadMultipart_StateOfAnotherFile(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	statePath := filepath.Join(t.TempDir(), "upload.json")
+	state := &client.UploadState{SessionID: uuid.New(), PartSize: partSize, Filename: "other.mp4", SizeBytes: int64(len(multipartContent)), ChecksumSHA256: "other"}
+	require.NoError(t, state.Save(statePath))
+
+	// Act
+	_, _, err := env.client.UploadMultipart(context.Background(), source(multipartContent), []string{"football"}, client.MultipartOptions{StatePath: statePath})
+
+	// Assert
+	assert.ErrorIs(t, err, client.ErrStateMismatch)
+	assert.FileExists(t, statePath)
+	assert.Empty(t, env.storage.puts)
+}
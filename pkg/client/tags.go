@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a tag files can be labelled with
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TagPage is a page of tags, NextMarker is empty on the last page
+type TagPage struct {
+	Tags       []Tag  `json:"tags"`
+	NextMarker string `json:"nextMarker,omitempty"`
+}
+
+// CreateTags creates the tags named names
+func (c *Client) CreateTags(ctx context.Context, names ...string) error {
+	_, err := c.doJSON(ctx, http.MethodPost, "/tag", nil, map[string][]string{"tags": names}, http.StatusCreated, nil)
+	return err
+}
+
+// ListTags lists up to limit tags, after marker if not empty
+func (c *Client) ListTags(ctx context.Context, limit int, marker string) (*TagPage, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+	var page TagPage
+	if _, err := c.doJSON(ctx, http.MethodGet, "/tag/", query, nil, http.StatusOK, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"context"
+	"score-play/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateTags(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	env.tags.On("CreateTags", mock.Anything, []string{"football", "goal"}).Return(nil)
+
+	// Act
+	err := env.client.CreateTags(context.Background(), "football", "goal")
+
+	// Assert
+	require.NoError(t, err)
+	env.tags.AssertExpectations(t)
+}
+
+func TestClient_ListTags(t *testing.T) {
+	// Arrange
+	env := newTestEnv(t)
+	marker := "football"
+	next := "goal"
+	tag := domain.Tag{ID: uuid.New(), Name: "goal", CreatedAt: time.Now().UTC()}
+	env.tags.On("ListTags", mock.Anything, 1, &marker).Return([]domain.Tag{tag}, &next, nil)
+
+	// Act
+	page, err := env.client.ListTags(context.Background(), 1, marker)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, page.Tags, 1)
+	assert.Equal(t, tag.ID, page.Tags[0].ID)
+	assert.Equal(t, "goal", page.Tags[0].Name)
+	assert.Equal(t, next, page.NextMarker)
+	env.tags.AssertExpectations(t)
+}